@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
+	persistence_postgresql "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/persistence"
+)
+
+// runImport implements "discoveryservice import [-tenant id] [-format ndjson|csv] [file]".
+// Without a file the records are read from stdin. The report is printed as JSON to stdout.
+func runImport(ctx context.Context, configPath string, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	tenantID := fs.String("tenant", "", "Import into the schema of this tenant (required if multi-tenancy is enabled)")
+	formatName := fs.String("format", "", "Input format: ndjson or csv (default: derived from the file extension, else ndjson)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: discoveryservice [-config file] import [-tenant id] [-format ndjson|csv] [file]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	in := io.Reader(os.Stdin)
+	name := fs.Arg(0)
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	format, err := cliFormat(*formatName, name)
+	if err != nil {
+		return err
+	}
+	dec, err := bulk.NewDecoder(format, in)
+	if err != nil {
+		return err
+	}
+
+	database, ctx, err := bulkDatabase(ctx, configPath, *tenantID)
+	if err != nil {
+		return err
+	}
+
+	report, err := database.ImportAssetLinks(ctx, dec)
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	if encErr := out.Encode(report); encErr != nil {
+		log.Printf("Unable to print import report: %v", encErr)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d records were rejected", report.Failed, report.Failed+report.Imported)
+	}
+	return nil
+}
+
+// runExport implements "discoveryservice export [-tenant id] [-format ndjson|csv] [file]".
+// Without a file the records are written to stdout.
+func runExport(ctx context.Context, configPath string, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	tenantID := fs.String("tenant", "", "Export from the schema of this tenant (required if multi-tenancy is enabled)")
+	formatName := fs.String("format", "", "Output format: ndjson or csv (default: derived from the file extension, else ndjson)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: discoveryservice [-config file] export [-tenant id] [-format ndjson|csv] [file]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	format, err := cliFormat(*formatName, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	database, ctx, err := bulkDatabase(ctx, configPath, *tenantID)
+	if err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if name := fs.Arg(0); name != "" && name != "-" {
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	enc, err := bulk.NewEncoder(format, out)
+	if err != nil {
+		return err
+	}
+	exported, err := database.ExportAssetLinks(ctx, enc)
+	if err != nil {
+		return err
+	}
+	log.Printf("Exported asset links of %d shells", exported)
+	return nil
+}
+
+// cliFormat resolves the explicit format flag or falls back to the file extension.
+func cliFormat(flagValue string, fileName string) (bulk.Format, error) {
+	if flagValue == "" && strings.EqualFold(filepath.Ext(fileName), ".csv") {
+		return bulk.FormatCSV, nil
+	}
+	return bulk.ParseFormat(flagValue)
+}
+
+// bulkDatabase opens the PostgreSQL backend and returns ctx scoped to the schema of tenantID.
+// The CLI cannot reach the storage of a running InMemory instance, use the HTTP endpoints for
+// that.
+func bulkDatabase(ctx context.Context, configPath string, tenantID string) (*persistence_postgresql.PostgreSQLDiscoveryDatabase, context.Context, error) {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.Basyx.InMemory() {
+		return nil, nil, errors.New("import and export require the PostgreSQL backend, use POST /lookup/shells/$import or GET /lookup/shells/$export instead")
+	}
+	switch {
+	case !config.Tenancy.Enabled && tenantID != "":
+		return nil, nil, errors.New("-tenant requires tenancy.enabled")
+	case config.Tenancy.Enabled && !slices.Contains(config.Tenancy.Tenants, tenantID):
+		return nil, nil, fmt.Errorf("-tenant must be one of %v", config.Tenancy.Tenants)
+	case tenantID != "":
+		ctx = tenant.WithTenant(ctx, tenantID)
+	}
+	database, err := newDatabase(config)
+	return database, ctx, err
+}
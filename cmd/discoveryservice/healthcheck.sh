@@ -4,13 +4,24 @@
 PORT=${SERVER_PORT:-5004}
 # Get the context path from environment or use default
 CONTEXT_PATH=${SERVER_CONTEXTPATH:-}
+# Probe over HTTPS if the server has TLS enabled
+SCHEME=http
+WGET_OPTS=""
+if [ "$SERVER_TLS_ENABLED" = "true" ]; then
+    SCHEME=https
+    WGET_OPTS="--no-check-certificate"
+    # With server.tls.clientAuth require the probe has to present a client certificate
+    if [ -n "$HEALTHCHECK_CERTFILE" ]; then
+        WGET_OPTS="$WGET_OPTS --certificate=$HEALTHCHECK_CERTFILE --private-key=${HEALTHCHECK_KEYFILE:-$HEALTHCHECK_CERTFILE}"
+    fi
+fi
 
-# Construct the health check URL
+# Construct the readiness probe URL, which fails while the database is unreachable
 if [ -z "$CONTEXT_PATH" ]; then
-    HEALTH_URL="http://localhost:$PORT/health"
+    HEALTH_URL="$SCHEME://localhost:$PORT/health/readiness"
 else
-    HEALTH_URL="http://localhost:$PORT$CONTEXT_PATH/health"
+    HEALTH_URL="$SCHEME://localhost:$PORT$CONTEXT_PATH/health/readiness"
 fi
 
 # Perform health check
-wget --spider -q "$HEALTH_URL"
\ No newline at end of file
+wget --spider -q $WGET_OPTS "$HEALTH_URL"
\ No newline at end of file
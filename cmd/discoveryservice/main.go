@@ -2,250 +2,139 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"flag"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
 	"github.com/spf13/viper"
 
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/bootstrap"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
 	api "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/api"
 	persistence_postgresql "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/persistence"
+	persistence_inmemory "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/persistence/inmemory"
 	openapi "github.com/eclipse-basyx/basyx-go-components/pkg/discoveryapi"
 )
 
 func runServer(ctx context.Context, configPath string) error {
 	log.Default().Println("Loading Discovery Service...")
 	log.Default().Println("Config Path:", configPath)
-	// Load configuration
 	config, err := LoadConfig(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
 		return err
 	}
 
-	PrintConfiguration(config)
-
-	// Create Chi router
-	r := chi.NewRouter()
-
-	// Enable CORS
-	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions},
-		AllowedHeaders:   []string{"*"},
-		AllowCredentials: true,
-	})
-	r.Use(c.Handler)
+	shutdownTracing, err := bootstrap.Setup(ctx, config.Config, "discovery-service", config)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing(context.Background())
 
-	// Add health endpoint
-	r.Get(config.Server.ContextPath+"/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("{\"status\":\"UP\"}"))
-	})
+	r := bootstrap.NewRouter(config.Config, config.Server.ContextPath)
 
 	// Instantiate generated services & controllers
 	// ==== Discovery Service ====
-	dsn := "postgres://" +
-		config.Postgres.User + ":" +
-		config.Postgres.Password + "@" +
-		config.Postgres.Host + ":" +
-		strconv.Itoa(config.Postgres.Port) + "/" +
-		config.Postgres.DBName + "?sslmode=disable"
-
-	smDatabase, err := persistence_postgresql.NewPostgreSQLDiscoveryBackend(
-		dsn,
-		config.Postgres.MaxOpenConnections,
-	)
+	smDatabase, err := newBackend(config)
 	if err != nil {
-		log.Fatalf("Failed to initialize database connection: %v", err)
+		return fmt.Errorf("failed to initialize database connection: %w", err)
+	}
+	if err := metrics.Register(smDatabase); err != nil {
 		return err
 	}
-	smSvc := api.NewAssetAdministrationShellBasicDiscoveryAPIAPIService(*smDatabase)
-	smCtrl := openapi.NewAssetAdministrationShellBasicDiscoveryAPIAPIController(smSvc)
-	for _, rt := range smCtrl.Routes() {
-		r.Method(rt.Method, rt.Pattern, rt.HandlerFunc)
+	bootstrap.MountHealth(r, config.Config, config.Server.ContextPath, smDatabase)
+	authenticator, err := auth.New(ctx, config.Auth)
+	if err != nil {
+		return err
+	}
+	tenants, err := tenant.New(config.Tenancy, config.Auth.Enabled)
+	if err != nil {
+		return err
 	}
+	smSvc := api.NewAssetAdministrationShellBasicDiscoveryAPIAPIService(smDatabase)
+	smCtrl := openapi.NewAssetAdministrationShellBasicDiscoveryAPIAPIController(smSvc)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticator.Middleware)
+		r.Use(tenants.Middleware)
+		for name, rt := range smCtrl.Routes() {
+			r.Method(rt.Method, rt.Pattern, common.WithTimeout(rt.HandlerFunc, config.StatementTimeouts.For(name)))
+		}
+	})
 
 	// ==== Description Service ====
 	descSvc := openapi.NewDescriptionAPIAPIService()
 	descCtrl := openapi.NewDescriptionAPIAPIController(descSvc)
-	for _, rt := range descCtrl.Routes() {
-		r.Method(rt.Method, rt.Pattern, rt.HandlerFunc)
+	for name, rt := range descCtrl.Routes() {
+		r.Method(rt.Method, rt.Pattern, common.WithTimeout(rt.HandlerFunc, config.StatementTimeouts.For(name)))
 	}
 
-	// Start the server
-	addr := "0.0.0.0:" + fmt.Sprintf("%d", config.Server.Port)
-	log.Printf("▶️  Submodel Repository listening on %s\n", addr)
-	// Start server in a goroutine
-	go func() {
-		if err := http.ListenAndServe(addr, r); err != http.ErrServerClosed {
-			log.Printf("Server error: %v", err)
-		}
-	}()
-
-	<-ctx.Done()
-	log.Println("Shutting down server...")
-	return nil
+	return bootstrap.Serve(ctx, config.Server, r, "Discovery Service")
 }
 
-func main() {
-	ctx := context.Background()
-	//load config path from flag
-	configPath := ""
-	flag.StringVar(&configPath, "config", "", "Path to config file")
-	flag.Parse()
-	if err := runServer(ctx, configPath); err != nil {
-		log.Fatalf("Server error: %v", err)
+// newBackend creates the storage selected by basyx.backend.
+func newBackend(config *Config) (api.DiscoveryBackend, error) {
+	if config.Basyx.InMemory() {
+		log.Println("Using the InMemory backend - asset links are lost on restart")
+		return persistence_inmemory.NewInMemoryDiscoveryBackend(), nil
 	}
+	return newDatabase(config)
 }
 
-type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Postgres PostgresConfig `yaml:"postgres"`
+func newDatabase(config *Config) (*persistence_postgresql.PostgreSQLDiscoveryDatabase, error) {
+	return persistence_postgresql.NewPostgreSQLDiscoveryBackend(
+		config.Postgres.DSN(),
+		config.Postgres.MaxOpenConnections,
+		tenantIDs(config),
+	)
 }
 
-type ServerConfig struct {
-	Port        int    `yaml:"port"`
-	ContextPath string `yaml:"contextPath"`
+// tenantIDs returns the tenants whose schemas the backend serves, nil if multi-tenancy is
+// disabled.
+func tenantIDs(config *Config) []string {
+	if !config.Tenancy.Enabled {
+		return nil
+	}
+	return config.Tenancy.Tenants
 }
 
-type PostgresConfig struct {
-	Host                   string `yaml:"host"`
-	Port                   int    `yaml:"port"`
-	User                   string `yaml:"user"`
-	Password               string `yaml:"password"`
-	DBName                 string `yaml:"dbname"`
-	MaxOpenConnections     int    `yaml:"maxOpenConnections"`
-	MaxIdleConnections     int    `yaml:"maxIdleConnections"`
-	ConnMaxLifetimeMinutes int    `yaml:"connMaxLifetimeMinutes"`
+func main() {
+	bootstrap.Main("Discovery Service", runServer,
+		bootstrap.Command{Name: "import", Usage: "load asset links from NDJSON or CSV (see 'import -h')", Run: runImport},
+		bootstrap.Command{Name: "export", Usage: "dump all asset links as NDJSON or CSV (see 'export -h')", Run: runExport},
+		bootstrap.Command{Name: "migrate", Usage: "manage the database schema: up, down [n] or status", Run: runMigrate},
+	)
 }
 
-type CorsConfig struct {
-	AllowedOrigins   []string `yaml:"allowedOrigins"`
-	AllowedMethods   []string `yaml:"allowedMethods"`
-	AllowedHeaders   []string `yaml:"allowedHeaders"`
-	AllowCredentials bool     `yaml:"allowCredentials"`
+type Config struct {
+	bootstrap.Config `mapstructure:",squash"`
+	Server           bootstrap.ServerConfig   `yaml:"server"`
+	Postgres         bootstrap.PostgresConfig `yaml:"postgres"`
 }
 
-// LoadConfig loads the configuration from files and environment variables
-func LoadConfig(configPath string) (*Config, error) {
-	v := viper.New()
-
-	// Set default values
-	setDefaults(v)
-
-	// Read config file if provided
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-		if err := v.ReadInConfig(); err != nil {
-			return nil, err
-		}
+// Validate checks the shared sections and, for the PostgreSQL backend, the connection.
+func (c *Config) Validate() error {
+	errs := []error{c.Config.Validate(), c.Server.Validate()}
+	if !c.Basyx.InMemory() {
+		errs = append(errs, c.Postgres.Validate())
 	}
+	return errors.Join(errs...)
+}
 
-	// Override config with environment variables
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
+// LoadConfig loads the configuration from files and environment variables, see bootstrap.Load.
+func LoadConfig(configPath string) (*Config, error) {
 	var config Config
-	if err := v.Unmarshal(&config); err != nil {
+	if err := bootstrap.Load(configPath, &config, setDefaults); err != nil {
 		return nil, err
 	}
-
 	return &config, nil
 }
 
-// setDefaults sets sensible defaults for configuration
+// setDefaults sets the defaults of the settings of the Discovery Service.
 func setDefaults(v *viper.Viper) {
-	// Server defaults
-	v.SetDefault("server.host", "0.0.0.0")
-	v.SetDefault("server.port", "5004")
-	v.SetDefault("server.contextPath", "")
-	v.SetDefault("server.cacheEnabled", false)
-
-	// MongoDB defaults
-	v.SetDefault("postgres.host", "localhost")
-	v.SetDefault("postgres.port", 5432)
-	v.SetDefault("postgres.user", "admin")
-	v.SetDefault("postgres.password", "admin123")
-	v.SetDefault("postgres.dbname", "basyx")
-	v.SetDefault("postgres.maxOpenConnections", 50)
-	v.SetDefault("postgres.maxIdleConnections", 50)
-	v.SetDefault("postgres.connMaxLifetimeMinutes", 5)
-
-	// CORS defaults
-	v.SetDefault("cors.allowedOrigins", []string{"*"})
-	v.SetDefault("cors.allowedMethods", []string{"GET", "POST", "DELETE", "OPTIONS"})
-	v.SetDefault("cors.allowedHeaders", []string{"*"})
-	v.SetDefault("cors.allowCredentials", true)
-
-}
-
-// PrintConfiguration prints the current configuration with sensitive data redacted
-func PrintConfiguration(cfg *Config) {
-	// Create a copy of the config to avoid modifying the original
-	cfgCopy := *cfg
-
-	// Redact sensitive information if present in the Postgres configuration
-	if cfg.Postgres.Host != "" {
-		// Simple redaction that preserves the structure but hides credentials
-		cfgCopy.Postgres.Host = "****"
-		cfgCopy.Postgres.User = "****"
-		cfgCopy.Postgres.Password = "****"
-	}
-
-	// Convert to JSON for pretty printing
-	configJSON, err := json.MarshalIndent(cfgCopy, "", "  ")
-	if err != nil {
-		log.Printf("Unable to marshal configuration to JSON: %v", err)
-		return
-	}
-
-	log.Printf("Configuration:\n%s", string(configJSON))
-}
-
-func ConfigureServer(configPath string) (*Config, *chi.Mux) {
-	PrintSplash()
-
-	if configPath == "" {
-		cfgPathFlag := flag.String("config", "", "Path to config file")
-		flag.Parse()
-		configPath = *cfgPathFlag
-	}
-
-	// Load configuration
-	cfg, err := LoadConfig(configPath)
-	if err != nil {
-		log.Fatalf("failed to load configuration: %v", err)
-		return nil, nil
-	}
-
-	PrintConfiguration(cfg)
-
-	// Create Chi router
-	r := chi.NewRouter()
-	return cfg, r
-}
-
-func PrintSplash() {
-	log.Printf(`
-	██████╗  █████╗ ███████╗██╗   ██╗██╗  ██╗     ██████╗  ██████╗ 
-	██╔══██╗██╔══██╗██╔════╝╚██╗ ██╔╝╚██╗██╔╝    ██╔════╝ ██╔═══██╗
-	██████╔╝███████║███████╗ ╚████╔╝  ╚███╔╝     ██║  ███╗██║   ██║
-	██╔══██╗██╔══██║╚════██║  ╚██╔╝   ██╔██╗     ██║   ██║██║   ██║
-	██████╔╝██║  ██║███████║   ██║   ██╔╝ ██╗    ╚██████╔╝╚██████╔╝
-	╚═════╝ ╚═╝  ╚═╝╚══════╝   ╚═╝   ╚═╝  ╚═╝     ╚═════╝  ╚═════╝ 
-																
-	█████╗ ██████╗ ██╗                                            
-	██╔══██╗██╔══██╗██║                                            
-	███████║██████╔╝██║                                            
-	██╔══██║██╔═══╝ ██║                                            
-	██║  ██║██║     ██║                                            
-	╚═╝  ╚═╝╚═╝     ╚═╝                                            
-	`)
+	// Imports and exports stream the whole data set and are not limited
+	v.SetDefault("statementTimeouts.routes.ImportAssetLinks", 0)
+	v.SetDefault("statementTimeouts.routes.ExportAssetLinks", 0)
 }
@@ -2,257 +2,203 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"flag"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"net/http"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
 	"github.com/spf13/viper"
 
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/bootstrap"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/cache"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
 	api "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/api"
 	persistence_postgresql "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence"
+	persistence_inmemory "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/inmemory"
 	openapi "github.com/eclipse-basyx/basyx-go-components/pkg/submodelrepositoryapi/go"
 )
 
 func runServer(ctx context.Context, configPath string) error {
 	log.Default().Println("Loading Submodel Repository Service...")
 	log.Default().Println("Config Path:", configPath)
-	// Load configuration
 	config, err := LoadConfig(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
 		return err
 	}
 
-	PrintConfiguration(config)
-
-	// Create Chi router
-	r := chi.NewRouter()
-
-	// Enable CORS
-	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions},
-		AllowedHeaders:   []string{"*"},
-		AllowCredentials: true,
-	})
-	r.Use(c.Handler)
+	shutdownTracing, err := bootstrap.Setup(ctx, config.Config, "submodel-repository-service", config)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing(context.Background())
 
-	// Add health endpoint
-	r.Get(config.Server.ContextPath+"/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("{\"status\":\"UP\"}"))
-	})
+	r := bootstrap.NewRouter(config.Config, config.Server.ContextPath)
 
 	// Instantiate generated services & controllers
-	// ==== Discovery Service ====
-	smDatabase, err := persistence_postgresql.NewPostgreSQLSubmodelBackend("postgres://"+config.Postgres.User+":"+config.Postgres.Password+"@"+config.Postgres.Host+":"+strconv.Itoa(config.Postgres.Port)+"/"+config.Postgres.DBName+"?sslmode=disable", config.Postgres.MaxOpenConnections, config.Postgres.MaxIdleConnections, config.Postgres.ConnMaxLifetimeMinutes, config.Server.CacheEnabled)
+	// ==== Submodel Repository ====
+	smDatabase, err := newBackend(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database connection: %w", err)
+	}
+	if closer, ok := smDatabase.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if err := metrics.Register(smDatabase); err != nil {
+		return err
+	}
+	bootstrap.MountHealth(r, config.Config, config.Server.ContextPath, smDatabase)
+	authenticator, err := auth.New(ctx, config.Auth)
 	if err != nil {
-		log.Fatalf("Failed to initialize database connection: %v", err)
 		return err
 	}
-	smSvc := api.NewSubmodelRepositoryAPIAPIService(*smDatabase)
-	smCtrl := openapi.NewSubmodelRepositoryAPIAPIController(smSvc, config.Server.ContextPath)
-	for _, rt := range smCtrl.Routes() {
-		r.Method(rt.Method, rt.Pattern, rt.HandlerFunc)
+	tenants, err := tenant.New(config.Tenancy, config.Auth.Enabled)
+	if err != nil {
+		return err
+	}
+	enforcer, err := abac.Load(config.AccessControl.RulesFile)
+	if err != nil {
+		return err
 	}
+	smSvc := api.NewSubmodelRepositoryAPIAPIService(smDatabase, enforcer)
+	smCtrl := openapi.NewSubmodelRepositoryAPIAPIController(smSvc, config.Server.ContextPath)
+	queryCtrl := openapi.NewQueryAPIAPIController(smSvc, config.Server.ContextPath)
+	searchCtrl := openapi.NewSearchAPIAPIController(smSvc, config.Server.ContextPath)
+	lookupCtrl := openapi.NewLookupAPIAPIController(smSvc, config.Server.ContextPath)
+	aggregateCtrl := openapi.NewAggregateAPIAPIController(smSvc, config.Server.ContextPath)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticator.Middleware)
+		r.Use(tenants.Middleware)
+		for _, routes := range []openapi.Routes{smCtrl.Routes(), queryCtrl.Routes(), searchCtrl.Routes(), lookupCtrl.Routes(), aggregateCtrl.Routes()} {
+			for name, rt := range routes {
+				r.Method(rt.Method, rt.Pattern, common.WithTimeout(rt.HandlerFunc, config.StatementTimeouts.For(name)))
+			}
+		}
+	})
 
 	// ==== Description Service ====
 	descSvc := openapi.NewDescriptionAPIAPIService()
 	descCtrl := openapi.NewDescriptionAPIAPIController(descSvc)
-	for _, rt := range descCtrl.Routes() {
-		r.Method(rt.Method, rt.Pattern, rt.HandlerFunc)
+	for name, rt := range descCtrl.Routes() {
+		r.Method(rt.Method, rt.Pattern, common.WithTimeout(rt.HandlerFunc, config.StatementTimeouts.For(name)))
 	}
-	// Add a demo-insert endpoint to bypass validation for POST
-	// r.Post("/demo-insert", func(w http.ResponseWriter, r *http.Request) {
-	// 	m := openapi.Submodel{
-	// 		Id:        "sm-99",
-	// 		IdShort:   "Demo",
-	// 		ModelType: "Submodel",
-	// 		Kind:      "Instance",
-	// 	}
-	// 	_, err := smDatabase.CreateSubmodel(m)
-	// 	if err != nil {
-	// 		http.Error(w, err.Error(), http.StatusInternalServerError)
-	// 		return
-	// 	}
-	// 	w.WriteHeader(http.StatusCreated)
-	// 	w.Write([]byte("inserted sm-99"))
-	// })
 
-	// Start the server
-	addr := "0.0.0.0:" + fmt.Sprintf("%d", config.Server.Port)
-	log.Printf("▶️  Submodel Repository listening on %s\n", addr)
-	// Start server in a goroutine
-	go func() {
-		if err := http.ListenAndServe(addr, r); err != http.ErrServerClosed {
-			log.Printf("Server error: %v", err)
-		}
-	}()
+	return bootstrap.Serve(ctx, config.Server.ServerConfig, r, "Submodel Repository")
+}
+
+// newBackend creates the storage selected by basyx.backend.
+func newBackend(ctx context.Context, config *Config) (api.SubmodelBackend, error) {
+	if config.Basyx.InMemory() {
+		log.Println("Using the InMemory backend - submodels are lost on restart")
+		return persistence_inmemory.NewInMemorySubmodelBackend(), nil
+	}
+	return persistence_postgresql.NewPostgreSQLSubmodelBackend(ctx, config.Postgres.DSN(), config.Postgres.MaxOpenConnections, config.Postgres.MaxIdleConnections, config.Postgres.ConnMaxLifetimeMinutes, cacheOptions(config), strings.EqualFold(config.Postgres.ReadMode, "json"), tenantIDs(config))
+}
 
-	<-ctx.Done()
-	log.Println("Shutting down server...")
-	return nil
+// tenantIDs returns the tenants whose schemas the backend serves, nil if multi-tenancy is
+// disabled.
+func tenantIDs(config *Config) []string {
+	if !config.Tenancy.Enabled {
+		return nil
+	}
+	return config.Tenancy.Tenants
 }
 
-func main() {
-	ctx := context.Background()
-	//load config path from flag
-	configPath := ""
-	flag.StringVar(&configPath, "config", "", "Path to config file")
-	flag.Parse()
-	if err := runServer(ctx, configPath); err != nil {
-		log.Fatalf("Server error: %v", err)
+// cacheOptions returns the submodel cache limits, or nil if the cache is disabled.
+func cacheOptions(config *Config) *cache.Options {
+	if !config.Server.CacheEnabled {
+		return nil
+	}
+	return &cache.Options{
+		MaxEntries: config.Cache.MaxEntries,
+		MaxBytes:   int64(config.Cache.MaxSizeMB) * 1024 * 1024,
+		TTL:        time.Duration(config.Cache.TTLSeconds) * time.Second,
 	}
 }
 
+func main() {
+	bootstrap.Main("Submodel Repository", runServer, bootstrap.Command{
+		Name:  "migrate",
+		Usage: "manage the database schema: up, down [n] or status",
+		Run:   runMigrate,
+	})
+}
+
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Postgres PostgresConfig `yaml:"postgres"`
+	bootstrap.Config `mapstructure:",squash"`
+	Server           ServerConfig   `yaml:"server"`
+	Postgres         PostgresConfig `yaml:"postgres"`
+	// AccessControl enforces access rules on the claims of authenticated requests.
+	AccessControl AccessControlConfig `yaml:"accessControl"`
+	Cache         CacheConfig         `yaml:"cache"`
 }
 
 type ServerConfig struct {
-	Port         int    `yaml:"port"`
-	ContextPath  string `yaml:"contextPath"`
-	CacheEnabled bool   `yaml:"cacheEnabled"`
+	bootstrap.ServerConfig `mapstructure:",squash"`
+	CacheEnabled           bool `yaml:"cacheEnabled"`
 }
 
 type PostgresConfig struct {
-	Host                   string `yaml:"host"`
-	Port                   int    `yaml:"port"`
-	User                   string `yaml:"user"`
-	Password               string `yaml:"password"`
-	DBName                 string `yaml:"dbname"`
-	MaxOpenConnections     int    `yaml:"maxOpenConnections"`
-	MaxIdleConnections     int    `yaml:"maxIdleConnections"`
-	ConnMaxLifetimeMinutes int    `yaml:"connMaxLifetimeMinutes"`
+	bootstrap.PostgresConfig `mapstructure:",squash"`
+	// ReadMode selects how submodels are read: go (default) assembles them from rows,
+	// json builds their JSON inside PostgreSQL with a single query.
+	ReadMode string `yaml:"readMode"`
 }
 
-type CorsConfig struct {
-	AllowedOrigins   []string `yaml:"allowedOrigins"`
-	AllowedMethods   []string `yaml:"allowedMethods"`
-	AllowedHeaders   []string `yaml:"allowedHeaders"`
-	AllowCredentials bool     `yaml:"allowCredentials"`
+// AccessControlConfig names the file with the access rules, see abac.Rules. Without one all
+// requests that pass authentication are allowed.
+type AccessControlConfig struct {
+	RulesFile string `yaml:"rulesFile"`
 }
 
-// LoadConfig loads the configuration from files and environment variables
-func LoadConfig(configPath string) (*Config, error) {
-	v := viper.New()
-
-	// Set default values
-	setDefaults(v)
+// CacheConfig bounds the submodel cache that is enabled with server.cacheEnabled.
+// Zero values disable the respective limit.
+type CacheConfig struct {
+	MaxEntries int `yaml:"maxEntries"`
+	MaxSizeMB  int `yaml:"maxSizeMB"`
+	TTLSeconds int `yaml:"ttlSeconds"`
+}
 
-	// Read config file if provided
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-		if err := v.ReadInConfig(); err != nil {
-			return nil, err
-		}
+// Validate checks the shared sections and the settings of the Submodel Repository.
+func (c *Config) Validate() error {
+	errs := []error{c.Config.Validate(), c.Server.Validate()}
+	if !c.Basyx.InMemory() {
+		errs = append(errs, c.Postgres.Validate())
 	}
+	switch strings.ToLower(c.Postgres.ReadMode) {
+	case "", "go", "json":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported postgres.readMode '%s': valid values are [go json]", c.Postgres.ReadMode))
+	}
+	if c.Cache.MaxEntries < 0 || c.Cache.MaxSizeMB < 0 || c.Cache.TTLSeconds < 0 {
+		errs = append(errs, errors.New("cache limits must not be negative"))
+	}
+	return errors.Join(errs...)
+}
 
-	// Override config with environment variables
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
+// LoadConfig loads the configuration from files and environment variables, see bootstrap.Load.
+func LoadConfig(configPath string) (*Config, error) {
 	var config Config
-	if err := v.Unmarshal(&config); err != nil {
+	if err := bootstrap.Load(configPath, &config, setDefaults); err != nil {
 		return nil, err
 	}
-
 	return &config, nil
 }
 
-// setDefaults sets sensible defaults for configuration
+// setDefaults sets the defaults of the settings of the Submodel Repository.
 func setDefaults(v *viper.Viper) {
-	// Server defaults
-	v.SetDefault("server.host", "0.0.0.0")
-	v.SetDefault("server.port", "5004")
-	v.SetDefault("server.contextPath", "")
 	v.SetDefault("server.cacheEnabled", false)
 
-	// MongoDB defaults
-	v.SetDefault("postgres.host", "localhost")
-	v.SetDefault("postgres.port", 5432)
-	v.SetDefault("postgres.user", "admin")
-	v.SetDefault("postgres.password", "admin123")
-	v.SetDefault("postgres.dbname", "basyx")
-	v.SetDefault("postgres.maxOpenConnections", 50)
-	v.SetDefault("postgres.maxIdleConnections", 50)
-	v.SetDefault("postgres.connMaxLifetimeMinutes", 5)
-
-	// CORS defaults
-	v.SetDefault("cors.allowedOrigins", []string{"*"})
-	v.SetDefault("cors.allowedMethods", []string{"GET", "POST", "DELETE", "OPTIONS"})
-	v.SetDefault("cors.allowedHeaders", []string{"*"})
-	v.SetDefault("cors.allowCredentials", true)
-
-}
-
-// PrintConfiguration prints the current configuration with sensitive data redacted
-func PrintConfiguration(cfg *Config) {
-	// Create a copy of the config to avoid modifying the original
-	cfgCopy := *cfg
-
-	// Redact sensitive information if present in the Postgres configuration
-	if cfg.Postgres.Host != "" {
-		// Simple redaction that preserves the structure but hides credentials
-		cfgCopy.Postgres.Host = "****"
-		cfgCopy.Postgres.User = "****"
-		cfgCopy.Postgres.Password = "****"
-	}
-
-	// Convert to JSON for pretty printing
-	configJSON, err := json.MarshalIndent(cfgCopy, "", "  ")
-	if err != nil {
-		log.Printf("Unable to marshal configuration to JSON: %v", err)
-		return
-	}
-
-	log.Printf("Configuration:\n%s", string(configJSON))
-}
-
-func ConfigureServer(configPath string) (*Config, *chi.Mux) {
-	PrintSplash()
-
-	if configPath == "" {
-		cfgPathFlag := flag.String("config", "", "Path to config file")
-		flag.Parse()
-		configPath = *cfgPathFlag
-	}
-
-	// Load configuration
-	cfg, err := LoadConfig(configPath)
-	if err != nil {
-		log.Fatalf("failed to load configuration: %v", err)
-		return nil, nil
-	}
-
-	PrintConfiguration(cfg)
-
-	// Create Chi router
-	r := chi.NewRouter()
-	return cfg, r
-}
+	// Cache defaults
+	v.SetDefault("cache.maxEntries", 1000)
+	v.SetDefault("cache.maxSizeMB", 256)
+	v.SetDefault("cache.ttlSeconds", 300)
 
-func PrintSplash() {
-	log.Printf(`
-	██████╗  █████╗ ███████╗██╗   ██╗██╗  ██╗     ██████╗  ██████╗ 
-	██╔══██╗██╔══██╗██╔════╝╚██╗ ██╔╝╚██╗██╔╝    ██╔════╝ ██╔═══██╗
-	██████╔╝███████║███████╗ ╚████╔╝  ╚███╔╝     ██║  ███╗██║   ██║
-	██╔══██╗██╔══██║╚════██║  ╚██╔╝   ██╔██╗     ██║   ██║██║   ██║
-	██████╔╝██║  ██║███████║   ██║   ██╔╝ ██╗    ╚██████╔╝╚██████╔╝
-	╚═════╝ ╚═╝  ╚═╝╚══════╝   ╚═╝   ╚═╝  ╚═╝     ╚═════╝  ╚═════╝ 
-																
-	█████╗ ██████╗ ██╗                                            
-	██╔══██╗██╔══██╗██║                                            
-	███████║██████╔╝██║                                            
-	██╔══██║██╔═══╝ ██║                                            
-	██║  ██║██║     ██║                                            
-	╚═╝  ╚═╝╚═╝     ╚═╝                                            
-	`)
+	v.SetDefault("postgres.readMode", "go")
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/migrate"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+	persistence_postgresql "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence"
+)
+
+// runMigrate implements "submodelrepositoryservice migrate up|down [n]|status". With multi-tenancy the
+// command runs for the public schema and then for the schema of every tenant, "down" in the
+// reverse order so that the tenants are rolled back before public.
+func runMigrate(ctx context.Context, configPath string, args []string) error {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := pgxpool.ParseConfig(config.Postgres.DSN())
+	if err != nil {
+		return err
+	}
+	tenants := tenantIDs(config)
+	if len(tenants) > 0 {
+		tenant.ConfigurePool(cfg)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	db := stdlib.OpenDBFromPool(pool)
+	defer db.Close()
+
+	migrator, err := persistence_postgresql.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	forEachSchema := tenant.ForEachSchema
+	if len(args) > 0 && args[0] == "down" {
+		forEachSchema = tenant.ForEachSchemaReversed
+	}
+	return forEachSchema(ctx, pool, tenants, func(ctx context.Context, schema string) error {
+		if len(tenants) > 0 {
+			fmt.Fprintf(os.Stdout, "schema %s:\n", schema)
+		}
+		return migrate.RunCommand(ctx, migrator, args, os.Stdout)
+	})
+}
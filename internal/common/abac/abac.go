@@ -0,0 +1,362 @@
+// Package abac decides whether the subject of a request may perform an action on an object,
+// following the access rules of the AAS security model (IDTA Part 4): a rule grants or denies
+// rights on objects to subjects identified by claims of their access token, optionally under
+// conditions that compare attributes of the subject and the object.
+package abac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+)
+
+// Right is an action on an object.
+type Right string
+
+const (
+	RightRead    Right = "READ"
+	RightCreate  Right = "CREATE"
+	RightUpdate  Right = "UPDATE"
+	RightDelete  Right = "DELETE"
+	RightExecute Right = "EXECUTE"
+	// RightAll matches every right in a rule.
+	RightAll Right = "ALL"
+)
+
+const (
+	EffectAllow = "ALLOW"
+	EffectDeny  = "DENY"
+)
+
+// Resource is the object of a request. IdShortPath is empty for the submodel itself.
+type Resource struct {
+	SubmodelID  string
+	SemanticID  string
+	IdShortPath string
+}
+
+// Rules is the content of a rules file:
+//
+//	rules:
+//	  - description: suppliers read their nameplates
+//	    subject:
+//	      - claim: roles
+//	        values: [supplier]
+//	    objects:
+//	      - semanticId: https://admin-shell.io/zvei/nameplate/*
+//	    rights: [READ]
+//	    conditions:
+//	      - attribute: submodelId
+//	        operator: prefix
+//	        value: "urn:${claim:org}:"
+type Rules struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule applies to a request if the subject, one of the objects, one of the rights and all
+// conditions match. An empty subject or object list matches everything.
+type Rule struct {
+	Description string      `yaml:"description"`
+	Subject     []Match     `yaml:"subject"`
+	Objects     []Object    `yaml:"objects"`
+	Rights      []Right     `yaml:"rights"`
+	Conditions  []Condition `yaml:"conditions"`
+	// Effect is ALLOW (default) or DENY. A matching DENY rule overrides all ALLOW rules.
+	Effect string `yaml:"effect"`
+}
+
+// Match requires the claim to have one of the values; "*" accepts any value.
+type Match struct {
+	Claim  string   `yaml:"claim"`
+	Values []string `yaml:"values"`
+}
+
+// Object selects submodels by id and semanticId, which may contain * wildcards, and the
+// elements below an idShortPath. Empty fields match everything.
+type Object struct {
+	SubmodelID  string `yaml:"submodelId"`
+	SemanticID  string `yaml:"semanticId"`
+	IdShortPath string `yaml:"idShortPath"`
+}
+
+// Condition compares an attribute (submodelId, semanticId, idShortPath or claim:<name>) with
+// Value using the operator equals (default), prefix or glob. Value may reference attributes
+// as ${attribute}.
+type Condition struct {
+	Attribute string `yaml:"attribute"`
+	Operator  string `yaml:"operator"`
+	Value     string `yaml:"value"`
+}
+
+// Enforcer evaluates rules. A nil Enforcer allows everything.
+type Enforcer struct {
+	rules           []Rule
+	needsSemanticID bool
+}
+
+// Load reads the rules file at path. It returns nil if path is empty, which disables access
+// control.
+func Load(path string) (*Enforcer, error) {
+	if path == "" {
+		return nil, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var rules Rules
+	if err := v.Unmarshal(&rules); err != nil {
+		return nil, err
+	}
+	return New(rules.Rules)
+}
+
+// New validates the rules and returns an Enforcer for them.
+func New(rules []Rule) (*Enforcer, error) {
+	e := &Enforcer{}
+	for i, rule := range rules {
+		rule.Effect = strings.ToUpper(rule.Effect)
+		if rule.Effect == "" {
+			rule.Effect = EffectAllow
+		}
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("rule %d: unsupported effect '%s': valid values are [ALLOW DENY]", i, rule.Effect)
+		}
+		if len(rule.Rights) == 0 {
+			return nil, fmt.Errorf("rule %d: no rights", i)
+		}
+		for j, right := range rule.Rights {
+			right = Right(strings.ToUpper(string(right)))
+			switch right {
+			case RightRead, RightCreate, RightUpdate, RightDelete, RightExecute, RightAll:
+			default:
+				return nil, fmt.Errorf("rule %d: unsupported right '%s'", i, right)
+			}
+			rule.Rights[j] = right
+		}
+		for _, c := range rule.Conditions {
+			switch strings.ToLower(c.Operator) {
+			case "", "equals", "prefix", "glob":
+			default:
+				return nil, fmt.Errorf("rule %d: unsupported operator '%s': valid values are [equals prefix glob]", i, c.Operator)
+			}
+			if c.Attribute == "semanticId" || strings.Contains(c.Value, "${semanticId}") {
+				e.needsSemanticID = true
+			}
+		}
+		for _, o := range rule.Objects {
+			if o.SemanticID != "" {
+				e.needsSemanticID = true
+			}
+		}
+		e.rules = append(e.rules, rule)
+	}
+	return e, nil
+}
+
+// NeedsSemanticID reports whether rules refer to semanticIds, so callers only need to look up
+// the semanticId of a submodel if it is relevant.
+func (e *Enforcer) NeedsSemanticID() bool {
+	return e != nil && e.needsSemanticID
+}
+
+// Allowed reports whether the subject with claims may perform right on res.
+func (e *Enforcer) Allowed(claims auth.Claims, right Right, res Resource) bool {
+	if e == nil {
+		return true
+	}
+	allowed := false
+	for _, rule := range e.rules {
+		if !rule.applies(claims, right, res) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// Check returns a Forbidden error if the subject of ctx may not perform right on res.
+func (e *Enforcer) Check(ctx context.Context, right Right, res Resource) error {
+	claims, _ := auth.ClaimsFromContext(ctx)
+	if e.Allowed(claims, right, res) {
+		return nil
+	}
+	return common.NewError(common.ErrCodeForbidden, fmt.Sprintf("%s access to '%s' is not allowed", right, describe(res)), nil)
+}
+
+// AllowedFor is Allowed for the subject of ctx.
+func (e *Enforcer) AllowedFor(ctx context.Context, right Right, res Resource) bool {
+	claims, _ := auth.ClaimsFromContext(ctx)
+	return e.Allowed(claims, right, res)
+}
+
+func describe(res Resource) string {
+	if res.IdShortPath == "" {
+		return res.SubmodelID
+	}
+	return res.SubmodelID + "/" + res.IdShortPath
+}
+
+func (r Rule) applies(claims auth.Claims, right Right, res Resource) bool {
+	if !r.hasRight(right) || !r.matchesSubject(claims) || !r.matchesObject(res) {
+		return false
+	}
+	for _, c := range r.Conditions {
+		if !c.holds(claims, res) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) hasRight(right Right) bool {
+	for _, rr := range r.Rights {
+		if rr == right || rr == RightAll {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesSubject(claims auth.Claims) bool {
+	for _, m := range r.Subject {
+		if !m.matches(claims) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Match) matches(claims auth.Claims) bool {
+	values := claims.Strings(m.Claim)
+	for _, want := range m.Values {
+		for _, have := range values {
+			if want == "*" || want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesObject(res Resource) bool {
+	if len(r.Objects) == 0 {
+		return true
+	}
+	for _, o := range r.Objects {
+		if o.matches(res) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o Object) matches(res Resource) bool {
+	return (o.SubmodelID == "" || glob(o.SubmodelID, res.SubmodelID)) &&
+		(o.SemanticID == "" || glob(o.SemanticID, res.SemanticID)) &&
+		(o.IdShortPath == "" || hasPathPrefix(res.IdShortPath, o.IdShortPath))
+}
+
+// hasPathPrefix reports whether p is prefix or an element below it, e.g. a.b[0] below a.b.
+func hasPathPrefix(p, prefix string) bool {
+	if !strings.HasPrefix(p, prefix) {
+		return false
+	}
+	rest := p[len(prefix):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+func (c Condition) holds(claims auth.Claims, res Resource) bool {
+	actual := attribute(c.Attribute, claims, res)
+	want, ok := expand(c.Value, claims, res)
+	if !ok {
+		return false
+	}
+	for _, a := range actual {
+		switch strings.ToLower(c.Operator) {
+		case "prefix":
+			if strings.HasPrefix(a, want) {
+				return true
+			}
+		case "glob":
+			if glob(want, a) {
+				return true
+			}
+		default:
+			if a == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func attribute(name string, claims auth.Claims, res Resource) []string {
+	switch {
+	case name == "submodelId":
+		return []string{res.SubmodelID}
+	case name == "semanticId":
+		return []string{res.SemanticID}
+	case name == "idShortPath":
+		return []string{res.IdShortPath}
+	case strings.HasPrefix(name, "claim:"):
+		return claims.Strings(strings.TrimPrefix(name, "claim:"))
+	}
+	return nil
+}
+
+// expand replaces ${attribute} references in value by the first value of the attribute. It
+// reports false if a referenced attribute has no value, e.g. a claim the token lacks: left
+// empty, the reference would turn a prefix or glob condition into one that matches everything.
+func expand(value string, claims auth.Claims, res Resource) (string, bool) {
+	var b strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(value[:start])
+		values := attribute(value[start+2:start+end], claims, res)
+		if len(values) == 0 || values[0] == "" {
+			return "", false
+		}
+		b.WriteString(values[0])
+		value = value[start+end+1:]
+	}
+	b.WriteString(value)
+	return b.String(), true
+}
+
+// glob matches s against a pattern in which * stands for any sequence of characters,
+// including slashes.
+func glob(pattern, s string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == s
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
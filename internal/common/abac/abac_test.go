@@ -0,0 +1,124 @@
+package abac
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+)
+
+const testRules = `
+rules:
+  - description: suppliers read the nameplates of their own submodels
+    subject:
+      - claim: roles
+        values: [supplier]
+    objects:
+      - semanticId: https://admin-shell.io/zvei/nameplate/*
+    rights: [READ]
+    conditions:
+      - attribute: submodelId
+        operator: prefix
+        value: "urn:${claim:org}:"
+  - description: maintenance staff may do everything
+    subject:
+      - claim: roles
+        values: [maintenance]
+    rights: [ALL]
+  - description: but nobody deletes the technical data
+    objects:
+      - idShortPath: TechnicalData
+    rights: [delete]
+    effect: deny
+`
+
+func loadTestRules(t *testing.T) *Enforcer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(testRules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	e, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestAllowed(t *testing.T) {
+	e := loadTestRules(t)
+	supplier := auth.Claims{"roles": []any{"supplier"}, "org": "acme"}
+	maintenance := auth.Claims{"roles": "maintenance"}
+	nameplate := Resource{SubmodelID: "urn:acme:nameplate", SemanticID: "https://admin-shell.io/zvei/nameplate/2/0/Nameplate"}
+
+	tests := []struct {
+		name    string
+		claims  auth.Claims
+		right   Right
+		res     Resource
+		allowed bool
+	}{
+		{"supplier reads own nameplate", supplier, RightRead, nameplate, true},
+		{"supplier reads other nameplate", supplier, RightRead, Resource{SubmodelID: "urn:other:nameplate", SemanticID: nameplate.SemanticID}, false},
+		{"supplier reads other semanticId", supplier, RightRead, Resource{SubmodelID: "urn:acme:bom", SemanticID: "urn:bom"}, false},
+		{"supplier executes", supplier, RightExecute, Resource{SubmodelID: "urn:acme:nameplate", IdShortPath: "Reset"}, false},
+		{"maintenance executes", maintenance, RightExecute, Resource{SubmodelID: "urn:acme:nameplate", IdShortPath: "Reset"}, true},
+		{"deny overrides allow", maintenance, RightDelete, Resource{SubmodelID: "urn:x", IdShortPath: "TechnicalData[0]"}, false},
+		{"deny only below the path", maintenance, RightDelete, Resource{SubmodelID: "urn:x", IdShortPath: "TechnicalDataSheet"}, true},
+		{"anonymous", nil, RightRead, nameplate, false},
+		{"supplier without org claim", auth.Claims{"roles": "supplier"}, RightRead, Resource{SubmodelID: "urn::nameplate", SemanticID: nameplate.SemanticID}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Allowed(tt.claims, tt.right, tt.res); got != tt.allowed {
+				t.Errorf("expected %v, got %v", tt.allowed, got)
+			}
+		})
+	}
+}
+
+func TestConditionFailsWithoutAttributeValue(t *testing.T) {
+	res := Resource{SubmodelID: "urn:acme:nameplate"}
+	for _, operator := range []string{"equals", "prefix", "glob"} {
+		c := Condition{Attribute: "submodelId", Operator: operator, Value: "${claim:org}*"}
+		if c.holds(auth.Claims{"roles": "supplier"}, res) {
+			t.Errorf("%s: expected a token without the claim not to match", operator)
+		}
+		if c.holds(auth.Claims{"org": ""}, res) {
+			t.Errorf("%s: expected an empty claim not to match", operator)
+		}
+	}
+
+	c := Condition{Attribute: "submodelId", Operator: "glob", Value: "urn:${claim:org}:*"}
+	if !c.holds(auth.Claims{"org": "acme"}, res) {
+		t.Error("expected the condition to hold with the claim")
+	}
+}
+
+func TestCheckReturnsForbidden(t *testing.T) {
+	e := loadTestRules(t)
+	err := e.Check(context.Background(), RightRead, Resource{SubmodelID: "urn:x"})
+	if e, ok := common.AsError(err); !ok || e.Code != common.ErrCodeForbidden {
+		t.Fatalf("expected a Forbidden error, got %v", err)
+	}
+
+	var nilEnforcer *Enforcer
+	if err := nilEnforcer.Check(context.Background(), RightDelete, Resource{SubmodelID: "urn:x"}); err != nil {
+		t.Fatalf("expected a nil Enforcer to allow everything, got %v", err)
+	}
+}
+
+func TestNewRejectsUnknownRight(t *testing.T) {
+	if _, err := New([]Rule{{Rights: []Right{"WRITE"}}}); err == nil {
+		t.Fatal("expected an error for an unknown right")
+	}
+}
+
+func TestGlob(t *testing.T) {
+	if !glob("urn:*:nameplate", "urn:acme:sub:nameplate") || glob("urn:*:nameplate", "urn:acme:bom") {
+		t.Error("unexpected glob result")
+	}
+}
@@ -0,0 +1,273 @@
+// Package aggregate describes aggregations of a property over all submodels with a
+// semanticId, e.g. the average MaxTemperature of the TechnicalData submodels per
+// ManufacturerName:
+//
+//	{"semanticId": "https://admin-shell.io/ZVEI/TechnicalData/Submodel/1/2",
+//	 "idShortPath": "TechnicalProperties.MaxTemperature",
+//	 "groupBy": "GeneralInformation.ManufacturerName",
+//	 "functions": ["count", "min", "max", "avg"],
+//	 "histogram": {"buckets": 10}}
+//
+// The backends compute the aggregates over the typed values of the properties: numeric
+// properties for the type number, xs:dateTime and xs:date properties for dateTime. Submodels
+// without the property or with a value of another type do not count.
+package aggregate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+// Type is the type of the aggregated values.
+type Type string
+
+const (
+	TypeNumber   Type = "number"
+	TypeDateTime Type = "dateTime"
+)
+
+// Function is an aggregate function.
+type Function string
+
+const (
+	FuncCount Function = "count"
+	FuncMin   Function = "min"
+	FuncMax   Function = "max"
+	FuncAvg   Function = "avg"
+	FuncSum   Function = "sum"
+)
+
+const (
+	// DefaultLimit and MaxLimit bound the number of groups of a result.
+	DefaultLimit = 100
+	MaxLimit     = 1000
+	// MaxBuckets is the largest number of histogram buckets.
+	MaxBuckets = 1000
+)
+
+// Request is the body of the aggregation endpoint.
+type Request struct {
+	// SemanticID is the first key of the semanticId of the submodels.
+	SemanticID string `json:"semanticId"`
+	// IdShortPath is the property whose values are aggregated.
+	IdShortPath string `json:"idShortPath"`
+	// Type defaults to number.
+	Type Type `json:"type,omitempty"`
+	// Functions default to all functions of Type.
+	Functions []Function `json:"functions,omitempty"`
+	// GroupBy is the property whose value groups the submodels, "" for a single group.
+	GroupBy   string     `json:"groupBy,omitempty"`
+	Histogram *Histogram `json:"histogram,omitempty"`
+	// Limit is the maximum number of groups, the largest groups are returned.
+	Limit int32 `json:"limit,omitempty"`
+}
+
+// Histogram divides the range from Min to Max into Buckets buckets of equal width. Min and
+// Max are numbers or date-times as the values and default to the smallest and largest value;
+// values outside are not counted.
+type Histogram struct {
+	Buckets int `json:"buckets"`
+	Min     any `json:"min,omitempty"`
+	Max     any `json:"max,omitempty"`
+}
+
+// Parse reads a request and validates it. Syntax errors and invalid requests are reported as
+// bad requests.
+func Parse(r io.Reader) (Request, error) {
+	var req Request
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	d.UseNumber()
+	if err := d.Decode(&req); err != nil {
+		return req, common.NewErrBadRequest("invalid aggregation: " + err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// Validate checks the request and fills in the defaults of Type, Functions and Limit.
+func (r *Request) Validate() error {
+	bad := func(format string, args ...any) error {
+		return common.NewErrBadRequest("invalid aggregation: " + fmt.Sprintf(format, args...))
+	}
+	if strings.TrimSpace(r.SemanticID) == "" {
+		return bad("semanticId is required")
+	}
+	if strings.TrimSpace(r.IdShortPath) == "" {
+		return bad("idShortPath is required")
+	}
+	switch r.Type {
+	case "":
+		r.Type = TypeNumber
+	case TypeNumber, TypeDateTime:
+	default:
+		return bad("type must be %q or %q", TypeNumber, TypeDateTime)
+	}
+	if len(r.Functions) == 0 {
+		r.Functions = []Function{FuncCount, FuncMin, FuncMax, FuncAvg}
+		if r.Type == TypeNumber {
+			r.Functions = append(r.Functions, FuncSum)
+		}
+	}
+	for _, f := range r.Functions {
+		switch f {
+		case FuncCount, FuncMin, FuncMax, FuncAvg:
+		case FuncSum:
+			if r.Type == TypeDateTime {
+				return bad("sum does not apply to date-times")
+			}
+		default:
+			return bad("unknown function %q", f)
+		}
+	}
+	switch {
+	case r.Limit == 0:
+		r.Limit = DefaultLimit
+	case r.Limit < 0 || r.Limit > MaxLimit:
+		return bad("limit must be between 1 and %d", MaxLimit)
+	}
+	if h := r.Histogram; h != nil {
+		if h.Buckets < 1 || h.Buckets > MaxBuckets {
+			return bad("histogram.buckets must be between 1 and %d", MaxBuckets)
+		}
+		lo, hi, err := r.Bounds()
+		if err != nil {
+			return bad("%v", err)
+		}
+		if lo != nil && hi != nil && *lo >= *hi {
+			return bad("histogram.min must be less than histogram.max")
+		}
+	}
+	return nil
+}
+
+// Has reports whether f was requested.
+func (r Request) Has(f Function) bool {
+	for _, requested := range r.Functions {
+		if requested == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the histogram bounds on the scale of Scalar, nil where they default to the
+// smallest or largest value.
+func (r Request) Bounds() (lo, hi *float64, err error) {
+	if r.Histogram == nil {
+		return nil, nil, nil
+	}
+	if lo, err = r.bound(r.Histogram.Min); err != nil {
+		return nil, nil, fmt.Errorf("histogram.min: %w", err)
+	}
+	if hi, err = r.bound(r.Histogram.Max); err != nil {
+		return nil, nil, fmt.Errorf("histogram.max: %w", err)
+	}
+	return lo, hi, nil
+}
+
+func (r Request) bound(v any) (*float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var x float64
+	switch v := v.(type) {
+	case json.Number:
+		if r.Type != TypeNumber {
+			return nil, fmt.Errorf("%s is not a date-time", v)
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		x = f
+	case float64:
+		if r.Type != TypeNumber {
+			return nil, fmt.Errorf("%v is not a date-time", v)
+		}
+		x = v
+	case string:
+		if r.Type != TypeDateTime {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an RFC 3339 date-time", v)
+		}
+		x = Scalar(t)
+	default:
+		return nil, fmt.Errorf("%v is neither a number nor a date-time", v)
+	}
+	return &x, nil
+}
+
+// Scalar is the number a date-time is aggregated as, the seconds since the Unix epoch, as
+// PostgreSQL's extract(epoch from ...).
+func Scalar(t time.Time) float64 {
+	return float64(t.UnixMicro()) / 1e6
+}
+
+// Value returns x as a number or, for the type dateTime, as an RFC 3339 date-time.
+func (r Request) Value(x float64) any {
+	if r.Type != TypeDateTime {
+		return x
+	}
+	sec, frac := math.Modf(x)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*1e3).UTC().Format(time.RFC3339Nano)
+}
+
+// Result is the answer of an aggregation.
+type Result struct {
+	Groups []Group `json:"groups"`
+	// Truncated is set if there are more than Limit groups.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Group holds the aggregates of the submodels with the same value of GroupBy. The aggregates
+// are numbers or date-times as the values.
+type Group struct {
+	// Value is the value of GroupBy as text, null for submodels without it and without
+	// GroupBy.
+	Value     *string  `json:"value"`
+	Count     *int64   `json:"count,omitempty"`
+	Min       any      `json:"min,omitempty"`
+	Max       any      `json:"max,omitempty"`
+	Avg       any      `json:"avg,omitempty"`
+	Sum       any      `json:"sum,omitempty"`
+	Histogram []Bucket `json:"histogram,omitempty"`
+}
+
+// Bucket counts the values from From, inclusive, to To, exclusive, except for the last
+// bucket, which includes To.
+type Bucket struct {
+	From  any   `json:"from"`
+	To    any   `json:"to"`
+	Count int64 `json:"count"`
+}
+
+// Buckets returns the empty buckets of a histogram from lo to hi. Equal bounds give a single
+// bucket.
+func (r Request) Buckets(lo, hi float64) []Bucket {
+	n := r.Histogram.Buckets
+	if lo == hi {
+		n = 1
+	}
+	buckets := make([]Bucket, n)
+	width := (hi - lo) / float64(n)
+	for i := range buckets {
+		to := lo + float64(i+1)*width
+		if i == n-1 {
+			to = hi
+		}
+		buckets[i] = Bucket{From: r.Value(lo + float64(i)*width), To: r.Value(to)}
+	}
+	return buckets
+}
@@ -0,0 +1,77 @@
+package aggregate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+func TestParseDefaults(t *testing.T) {
+	req, err := Parse(strings.NewReader(`{"semanticId":"urn:td","idShortPath":"MaxTemperature"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != TypeNumber || req.Limit != DefaultLimit || !req.Has(FuncSum) || len(req.Functions) != 5 {
+		t.Errorf("defaults: %+v", req)
+	}
+
+	req, err = Parse(strings.NewReader(`{"semanticId":"urn:td","idShortPath":"Built","type":"dateTime"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Has(FuncSum) {
+		t.Error("date-times have no sum")
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, body := range []string{
+		`{"idShortPath":"X"}`,
+		`{"semanticId":"urn:td"}`,
+		`{"semanticId":"urn:td","idShortPath":"X","type":"text"}`,
+		`{"semanticId":"urn:td","idShortPath":"X","functions":["median"]}`,
+		`{"semanticId":"urn:td","idShortPath":"X","type":"dateTime","functions":["sum"]}`,
+		`{"semanticId":"urn:td","idShortPath":"X","limit":5000}`,
+		`{"semanticId":"urn:td","idShortPath":"X","histogram":{"buckets":0}}`,
+		`{"semanticId":"urn:td","idShortPath":"X","histogram":{"buckets":4,"min":10,"max":10}}`,
+		`{"semanticId":"urn:td","idShortPath":"X","histogram":{"buckets":4,"min":"2024-01-01T00:00:00Z"}}`,
+		`{"semanticId":"urn:td","idShortPath":"X","type":"dateTime","histogram":{"buckets":4,"min":0}}`,
+		`{"semanticId":"urn:td","idShortPath":"X","unknown":1}`,
+	} {
+		if _, err := Parse(strings.NewReader(body)); !common.IsErrBadRequest(err) {
+			t.Errorf("%s: want a bad request, got %v", body, err)
+		}
+	}
+}
+
+func TestDateTimeBuckets(t *testing.T) {
+	req, err := Parse(strings.NewReader(`{"semanticId":"urn:td","idShortPath":"Built","type":"dateTime",
+		"histogram":{"buckets":2,"min":"2024-01-01T00:00:00Z","max":"2024-01-03T00:00:00Z"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo, hi, err := req.Bounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := req.Buckets(*lo, *hi)
+	want := []Bucket{
+		{From: "2024-01-01T00:00:00Z", To: "2024-01-02T00:00:00Z"},
+		{From: "2024-01-02T00:00:00Z", To: "2024-01-03T00:00:00Z"},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("got %+v", buckets)
+	}
+	for i := range want {
+		if buckets[i] != want[i] {
+			t.Errorf("bucket %d: got %+v, want %+v", i, buckets[i], want[i])
+		}
+	}
+
+	// equal bounds, a single value, give a single bucket
+	req.Type, req.Histogram.Buckets = TypeNumber, 10
+	if got := req.Buckets(3, 3); len(got) != 1 || got[0].From != 3.0 || got[0].To != 3.0 {
+		t.Errorf("equal bounds: %+v", got)
+	}
+}
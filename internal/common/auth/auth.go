@@ -0,0 +1,239 @@
+// Package auth authenticates requests with JWT access tokens, e.g. issued by an OAuth2
+// authorization server, and exposes their claims to the handlers.
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+// Config enables authentication and names where the signing keys come from: a JWKS URL,
+// which is refreshed periodically, a local JWKS file or a PEM file with a single public key.
+// Issuer and Audience are checked if set; expiry is always checked, allowing Leeway of clock
+// skew. With ClientCerts, requests without a token are also authenticated by a client
+// certificate that the TLS server verified, see ClientCertClaims.
+type Config struct {
+	Enabled     bool          `yaml:"enabled"`
+	JWKSURL     string        `yaml:"jwksUrl"`
+	JWKSFile    string        `yaml:"jwksFile"`
+	PEMFile     string        `yaml:"pemFile"`
+	Issuer      string        `yaml:"issuer"`
+	Audience    string        `yaml:"audience"`
+	Leeway      time.Duration `yaml:"leeway"`
+	ClientCerts bool          `yaml:"clientCerts"`
+}
+
+// Claims are the claims of a validated access token.
+type Claims jwt.MapClaims
+
+type claimsKey struct{}
+
+// Subject returns the sub claim.
+func (c Claims) Subject() string {
+	sub, _ := c["sub"].(string)
+	return sub
+}
+
+// Strings returns a claim that is a string or a list of strings, and scope, which is a
+// space-separated list, as a list.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		if name == "scope" {
+			return strings.Fields(v)
+		}
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// ClaimsFromContext returns the claims stored by the Authenticator, false for unauthenticated
+// requests.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// WithClaims returns a copy of ctx that carries claims.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// Authenticator validates the bearer tokens of requests.
+type Authenticator struct {
+	keyfunc     jwt.Keyfunc
+	parser      *jwt.Parser
+	clientCerts bool
+}
+
+// New loads the keys of cfg. It returns nil if authentication is disabled.
+func New(ctx context.Context, cfg Config) (*Authenticator, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.ClientCerts && cfg.JWKSURL == "" && cfg.JWKSFile == "" && cfg.PEMFile == "" {
+		return &Authenticator{clientCerts: true}, nil
+	}
+	kf, err := newKeyfunc(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &Authenticator{keyfunc: kf, parser: jwt.NewParser(opts...), clientCerts: cfg.ClientCerts}, nil
+}
+
+func newKeyfunc(ctx context.Context, cfg Config) (jwt.Keyfunc, error) {
+	switch {
+	case cfg.JWKSURL != "":
+		k, err := keyfunc.NewDefaultCtx(ctx, []string{cfg.JWKSURL})
+		if err != nil {
+			return nil, fmt.Errorf("loading JWKS from %s: %w", cfg.JWKSURL, err)
+		}
+		return k.Keyfunc, nil
+	case cfg.JWKSFile != "":
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		k, err := keyfunc.NewJWKSetJSON(json.RawMessage(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWKS file %s: %w", cfg.JWKSFile, err)
+		}
+		return k.Keyfunc, nil
+	case cfg.PEMFile != "":
+		data, err := os.ReadFile(cfg.PEMFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PEM file %s: %w", cfg.PEMFile, err)
+		}
+		return func(*jwt.Token) (any, error) { return key, nil }, nil
+	default:
+		return nil, errors.New("auth.jwksUrl, auth.jwksFile or auth.pemFile is required if authentication is enabled")
+	}
+}
+
+func parsePublicKey(data []byte) (any, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return jwt.ParseEdPublicKeyFromPEM(data)
+}
+
+// Authenticate validates a raw token and returns its claims.
+func (a *Authenticator) Authenticate(token string) (Claims, error) {
+	if a.parser == nil {
+		return nil, errors.New("no keys to validate access tokens are configured")
+	}
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.keyfunc); err != nil {
+		return nil, err
+	}
+	return Claims(claims), nil
+}
+
+// ClientCertClaims describes the subject of a verified client certificate as claims: sub and
+// cn are its common name, o and ou its organizations and organizational units and dns its DNS
+// names. client_cert is true, so access rules can tell machines from users.
+func ClientCertClaims(cert *x509.Certificate) Claims {
+	claims := Claims{
+		"sub":         cert.Subject.CommonName,
+		"cn":          cert.Subject.CommonName,
+		"client_cert": true,
+	}
+	add := func(name string, values []string) {
+		if len(values) > 0 {
+			list := make([]any, len(values))
+			for i, v := range values {
+				list[i] = v
+			}
+			claims[name] = list
+		}
+	}
+	add("o", cert.Subject.Organization)
+	add("ou", cert.Subject.OrganizationalUnit)
+	add("dns", cert.DNSNames)
+	return claims
+}
+
+// Middleware rejects requests without a valid bearer token with 401 and stores the claims of
+// valid tokens in the request context. If client certificates are accepted, requests without
+// a token that presented a verified certificate pass with its claims. A nil Authenticator
+// lets all requests pass.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok && a.clientCerts && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), ClientCertClaims(r.TLS.VerifiedChains[0][0]))))
+			return
+		}
+		if !ok {
+			unauthorized(w, r, "A bearer token is required", nil)
+			return
+		}
+		claims, err := a.Authenticate(token)
+		if err != nil {
+			slog.InfoContext(r.Context(), "Rejected access token", "error", err)
+			unauthorized(w, r, "The access token is invalid", err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, message string, cause error) {
+	challenge := "Bearer"
+	if cause != nil {
+		challenge = `Bearer error="invalid_token"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	common.HandleError(w, r, common.NewError(common.ErrCodeUnauthorized, message, cause), nil)
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := New(context.Background(), Config{Enabled: true, PEMFile: pemFile, Issuer: "https://idp", Audience: "basyx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, key
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestMiddleware(t *testing.T) {
+	a, key := newTestAuthenticator(t)
+	valid := jwt.MapClaims{"sub": "alice", "iss": "https://idp", "aud": "basyx", "exp": time.Now().Add(time.Minute).Unix()}
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"valid token", "Bearer " + sign(t, key, valid), http.StatusOK},
+		{"wrong audience", "Bearer " + sign(t, key, jwt.MapClaims{"iss": "https://idp", "aud": "other", "exp": valid["exp"]}), http.StatusUnauthorized},
+		{"expired", "Bearer " + sign(t, key, jwt.MapClaims{"iss": "https://idp", "aud": "basyx", "exp": time.Now().Add(-time.Minute).Unix()}), http.StatusUnauthorized},
+		{"no expiry", "Bearer " + sign(t, key, jwt.MapClaims{"iss": "https://idp", "aud": "basyx"}), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var subject string
+			handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims, _ := ClaimsFromContext(r.Context())
+				subject = claims.Subject()
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/submodels", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusOK && subject != "alice" {
+				t.Errorf("expected the claims in the context, got subject %q", subject)
+			}
+			if tt.status == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestClaimsStrings(t *testing.T) {
+	claims := Claims{"scope": "read write", "roles": []any{"maintenance", "supplier"}}
+	if got := claims.Strings("scope"); len(got) != 2 || got[1] != "write" {
+		t.Errorf("unexpected scopes %v", got)
+	}
+	if got := claims.Strings("roles"); len(got) != 2 || got[0] != "maintenance" {
+		t.Errorf("unexpected roles %v", got)
+	}
+}
+
+func TestMiddlewareAcceptsClientCertificate(t *testing.T) {
+	a, err := New(context.Background(), Config{Enabled: true, ClientCerts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "plc-17", OrganizationalUnit: []string{"machines"}}}
+
+	var claims Claims
+	handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ = ClaimsFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/submodels", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if claims.Subject() != "plc-17" || claims.Strings("ou")[0] != "machines" {
+		t.Errorf("unexpected claims %v", claims)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submodels", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without a certificate, got %d", rec.Code)
+	}
+}
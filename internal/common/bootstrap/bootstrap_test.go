@@ -0,0 +1,126 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Config   `mapstructure:",squash"`
+	Server   ServerConfig   `yaml:"server"`
+	Postgres PostgresConfig `yaml:"postgres"`
+}
+
+func (c *testConfig) Validate() error {
+	return errors.Join(c.Config.Validate(), c.Server.Validate(), c.Postgres.Validate())
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadReadsFileSecrets(t *testing.T) {
+	configPath := writeFile(t, "config.yaml", "postgres:\n  dbname: assets\n  password: from-file\n")
+	t.Setenv("POSTGRES_PASSWORD_FILE", writeFile(t, "password", "s3cret\n"))
+	t.Setenv("SERVER_PORT", "8081")
+
+	var cfg testConfig
+	if err := Load(configPath, &cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Postgres.Password != "s3cret" {
+		t.Errorf("expected the password from the secret file, got %q", cfg.Postgres.Password)
+	}
+	if cfg.Postgres.DBName != "assets" || cfg.Server.Port != 8081 || cfg.Log.Level != "info" {
+		t.Errorf("unexpected configuration %+v", cfg)
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	configPath := writeFile(t, "config.yaml", `
+server:
+  contextPath: api/
+postgres:
+  sslMode: sometimes
+cors:
+  allowCredentials: true
+tenancy:
+  enabled: true
+  claim: tenant
+  tenants: [acme]
+`)
+	var cfg testConfig
+	err := Load(configPath, &cfg, nil)
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	for _, want := range []string{"server.contextPath", "postgres.sslMode", "cors.allowCredentials", "tenancy.claim"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+func TestDSNEscapesCredentials(t *testing.T) {
+	p := PostgresConfig{Host: "db", Port: 5432, User: "admin", Password: "p@ss/word", DBName: "basyx", SSLMode: "verify-full", SSLRootCert: "/certs/root.crt"}
+	want := "postgres://admin:p%40ss%2Fword@db:5432/basyx?sslmode=verify-full&sslrootcert=%2Fcerts%2Froot.crt"
+	if got := p.DSN(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := ServerConfig{Host: "127.0.0.1", Port: 18089, ShutdownTimeout: 5 * time.Second}
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, cfg, handler, "test") }()
+
+	var resp *http.Response
+	var err error
+	requested := make(chan struct{})
+	go func() {
+		defer close(requested)
+		for range 50 {
+			resp, err = http.Get("http://" + cfg.Addr())
+			if err == nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the server did not receive the request")
+	}
+	cancel()
+
+	<-requested
+	if err != nil {
+		t.Fatalf("the in-flight request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", resp.StatusCode)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("expected a clean shutdown, got %v", err)
+	}
+}
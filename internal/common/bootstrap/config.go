@@ -0,0 +1,324 @@
+// Package bootstrap holds what every service needs to start: the shared configuration
+// sections, loading and validating them, the router with the common middleware, serving with
+// graceful shutdown and the command line entry point.
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/certs"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/health"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// Config holds the sections that all services share. Services embed it with
+//
+//	bootstrap.Config `mapstructure:",squash"`
+//
+// next to their server and postgres sections, which may extend ServerConfig and PostgresConfig
+// in the same way, and their own sections.
+type Config struct {
+	Basyx   BasyxConfig      `yaml:"basyx"`
+	Log     common.LogConfig `yaml:"log"`
+	Tracing tracing.Config   `yaml:"tracing"`
+	Auth    auth.Config      `yaml:"auth"`
+	// Tenancy isolates the data of tenants in schemas of their own.
+	Tenancy tenant.Config `yaml:"tenancy"`
+	Cors    CorsConfig    `yaml:"cors"`
+	// StatementTimeouts bound the database work of each request, see common.StatementTimeouts.
+	StatementTimeouts common.StatementTimeouts `yaml:"statementTimeouts"`
+	Health            health.Config            `yaml:"health"`
+}
+
+type BasyxConfig struct {
+	// Backend selects the storage: PostgreSQL (default) or InMemory.
+	Backend string `yaml:"backend"`
+}
+
+// InMemory reports whether the InMemory backend is selected.
+func (b BasyxConfig) InMemory() bool {
+	return strings.EqualFold(b.Backend, "InMemory")
+}
+
+type ServerConfig struct {
+	Host        string `yaml:"host"`
+	Port        int    `yaml:"port"`
+	ContextPath string `yaml:"contextPath"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish after SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// TLS serves HTTPS, optionally verifying client certificates.
+	TLS certs.Config `yaml:"tls"`
+}
+
+// Addr is the address the server listens on.
+func (s ServerConfig) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+type PostgresConfig struct {
+	Host                   string `yaml:"host"`
+	Port                   int    `yaml:"port"`
+	User                   string `yaml:"user"`
+	Password               string `yaml:"password"`
+	DBName                 string `yaml:"dbname"`
+	MaxOpenConnections     int    `yaml:"maxOpenConnections"`
+	MaxIdleConnections     int    `yaml:"maxIdleConnections"`
+	ConnMaxLifetimeMinutes int    `yaml:"connMaxLifetimeMinutes"`
+	// SSLMode is the libpq sslmode: disable (default), allow, prefer, require, verify-ca or
+	// verify-full. SSLRootCert verifies the server, SSLCert and SSLKey authenticate the client.
+	SSLMode     string `yaml:"sslMode"`
+	SSLRootCert string `yaml:"sslRootCert"`
+	SSLCert     string `yaml:"sslCert"`
+	SSLKey      string `yaml:"sslKey"`
+}
+
+// DSN builds the connection URL, escaping the credentials and adding the TLS options.
+func (p PostgresConfig) DSN() string {
+	query := url.Values{}
+	query.Set("sslmode", p.SSLMode)
+	for name, value := range map[string]string{
+		"sslrootcert": p.SSLRootCert,
+		"sslcert":     p.SSLCert,
+		"sslkey":      p.SSLKey,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.User, p.Password),
+		Host:     net.JoinHostPort(p.Host, strconv.Itoa(p.Port)),
+		Path:     "/" + p.DBName,
+		RawQuery: query.Encode(),
+	}
+	return dsn.String()
+}
+
+type CorsConfig struct {
+	AllowedOrigins   []string `yaml:"allowedOrigins"`
+	AllowedMethods   []string `yaml:"allowedMethods"`
+	AllowedHeaders   []string `yaml:"allowedHeaders"`
+	AllowCredentials bool     `yaml:"allowCredentials"`
+}
+
+// Validator is implemented by service configurations.
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks the shared sections.
+func (c Config) Validate() error {
+	var errs []error
+	switch strings.ToLower(c.Basyx.Backend) {
+	case "", "postgresql", "postgres", "inmemory":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported basyx.backend '%s': valid values are [PostgreSQL InMemory]", c.Basyx.Backend))
+	}
+	if c.Tenancy.Enabled && c.Basyx.InMemory() {
+		errs = append(errs, errors.New("tenancy.enabled requires the PostgreSQL backend"))
+	}
+	if _, err := tenant.New(c.Tenancy, c.Auth.Enabled); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Health.MaxPoolSaturation < 0 || c.Health.MaxPoolSaturation > 1 {
+		errs = append(errs, fmt.Errorf("health.maxPoolSaturation %v must be between 0 and 1", c.Health.MaxPoolSaturation))
+	}
+	if c.Cors.AllowCredentials && slices.Contains(c.Cors.AllowedOrigins, "*") {
+		errs = append(errs, errors.New("cors.allowCredentials requires explicit cors.allowedOrigins instead of *"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the listen address, the context path and the TLS files.
+func (s ServerConfig) Validate() error {
+	var errs []error
+	if s.Port < 1 || s.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port %d is not a valid port", s.Port))
+	}
+	if s.ContextPath != "" && (!strings.HasPrefix(s.ContextPath, "/") || strings.HasSuffix(s.ContextPath, "/")) {
+		errs = append(errs, fmt.Errorf("server.contextPath '%s' must start and must not end with /", s.ContextPath))
+	}
+	if s.TLS.Enabled && (s.TLS.CertFile == "" || s.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("server.tls.certFile and server.tls.keyFile are required if TLS is enabled"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the connection settings. They are ignored by the InMemory backend, so
+// services only validate them for PostgreSQL.
+func (p PostgresConfig) Validate() error {
+	var errs []error
+	if p.Host == "" || p.DBName == "" {
+		errs = append(errs, errors.New("postgres.host and postgres.dbname are required"))
+	}
+	if p.Port < 1 || p.Port > 65535 {
+		errs = append(errs, fmt.Errorf("postgres.port %d is not a valid port", p.Port))
+	}
+	if p.MaxOpenConnections < 1 {
+		errs = append(errs, errors.New("postgres.maxOpenConnections must be at least 1"))
+	}
+	switch p.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported postgres.sslMode '%s': valid values are [disable allow prefer require verify-ca verify-full]", p.SSLMode))
+	}
+	if (p.SSLCert == "") != (p.SSLKey == "") {
+		errs = append(errs, errors.New("postgres.sslCert and postgres.sslKey must be set together"))
+	}
+	return errors.Join(errs...)
+}
+
+// SetDefaults sets the defaults of the shared sections and of the server and postgres sections.
+func SetDefaults(v *viper.Viper) {
+	// Server defaults
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", 5004)
+	v.SetDefault("server.contextPath", "")
+	v.SetDefault("server.shutdownTimeout", 30*time.Second)
+	v.SetDefault("server.tls.enabled", false)
+	v.SetDefault("server.tls.clientAuth", "none")
+	v.SetDefault("server.tls.minVersion", "1.2")
+	v.SetDefault("server.tls.reloadInterval", time.Minute)
+
+	// PostgreSQL defaults
+	v.SetDefault("postgres.host", "localhost")
+	v.SetDefault("postgres.port", 5432)
+	v.SetDefault("postgres.user", "admin")
+	v.SetDefault("postgres.password", "admin123")
+	v.SetDefault("postgres.dbname", "basyx")
+	v.SetDefault("postgres.maxOpenConnections", 50)
+	v.SetDefault("postgres.maxIdleConnections", 50)
+	v.SetDefault("postgres.connMaxLifetimeMinutes", 5)
+	v.SetDefault("postgres.sslMode", "disable")
+
+	// Storage backend
+	v.SetDefault("basyx.backend", "PostgreSQL")
+
+	// Logging defaults
+	v.SetDefault("log.level", "info")
+	v.SetDefault("log.format", "json")
+
+	// Tracing defaults
+	v.SetDefault("tracing.exporter", "none")
+
+	// Authentication defaults
+	v.SetDefault("auth.enabled", false)
+	v.SetDefault("auth.leeway", 30*time.Second)
+
+	// Multi-tenancy defaults
+	v.SetDefault("tenancy.enabled", false)
+	v.SetDefault("tenancy.header", tenant.DefaultHeader)
+
+	// Timeouts of the database work per request
+	v.SetDefault("statementTimeouts.default", 30*time.Second)
+
+	// Readiness probe defaults
+	v.SetDefault("health.timeout", 5*time.Second)
+	v.SetDefault("health.maxPoolSaturation", 0)
+
+	// CORS defaults
+	v.SetDefault("cors.allowedOrigins", []string{"*"})
+	v.SetDefault("cors.allowedMethods", []string{"GET", "POST", "DELETE", "OPTIONS"})
+	v.SetDefault("cors.allowedHeaders", []string{"*"})
+	v.SetDefault("cors.allowCredentials", false)
+}
+
+// Load reads the configuration file at configPath, if any, into cfg and validates it. Values
+// are taken from, in increasing precedence, SetDefaults, defaults, the file and environment
+// variables named after the key, e.g. POSTGRES_PASSWORD for postgres.password. A variable
+// with the suffix _FILE, e.g. POSTGRES_PASSWORD_FILE, names a file that holds the value,
+// which suits secrets mounted by Docker or Kubernetes.
+func Load(configPath string, cfg Validator, defaults func(v *viper.Viper)) error {
+	v := viper.New()
+	SetDefaults(v)
+	if defaults != nil {
+		defaults(v)
+	}
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return err
+		}
+	}
+
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	if err := applyFileSecrets(v, os.Environ()); err != nil {
+		return err
+	}
+
+	if err := v.Unmarshal(cfg); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// applyFileSecrets sets every known key for which environ has a <KEY>_FILE variable to the
+// content of that file, without a trailing newline.
+func applyFileSecrets(v *viper.Viper, environ []string) error {
+	keys := v.AllKeys()
+	for _, kv := range environ {
+		name, path, _ := strings.Cut(kv, "=")
+		base, ok := strings.CutSuffix(name, "_FILE")
+		if !ok || path == "" {
+			continue
+		}
+		key := strings.ToLower(strings.ReplaceAll(base, "_", "."))
+		if !slices.Contains(keys, key) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		v.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
+
+// Print logs cfg as JSON with the PostgreSQL host and credentials redacted.
+func Print(cfg any) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		log.Printf("Unable to marshal configuration to JSON: %v", err)
+		return
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		log.Printf("Unable to marshal configuration to JSON: %v", err)
+		return
+	}
+	if pg, ok := m["Postgres"].(map[string]any); ok {
+		for _, key := range []string{"Host", "User", "Password"} {
+			if pg[key] != "" {
+				pg[key] = "****"
+			}
+		}
+	}
+	configJSON, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Printf("Unable to marshal configuration to JSON: %v", err)
+		return
+	}
+	log.Printf("Configuration:\n%s", string(configJSON))
+}
@@ -0,0 +1,151 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/cors"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/certs"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/health"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// Setup installs the logger and the tracer provider of cfg and prints the configuration of the
+// service, all of which is in full. The returned function flushes pending spans.
+func Setup(ctx context.Context, cfg Config, serviceName string, full any) (func(context.Context) error, error) {
+	if err := common.SetupLogging(cfg.Log); err != nil {
+		return nil, err
+	}
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	Print(full)
+	return shutdownTracing, nil
+}
+
+// NewRouter returns a router with request logging, tracing, metrics and CORS as configured,
+// serving the Prometheus metrics below contextPath.
+func NewRouter(cfg Config, contextPath string) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(common.RequestLogger)
+	r.Use(tracing.Middleware)
+	r.Use(metrics.Middleware)
+	r.Use(cors.New(cors.Options{
+		AllowedOrigins:   cfg.Cors.AllowedOrigins,
+		AllowedMethods:   cfg.Cors.AllowedMethods,
+		AllowedHeaders:   cfg.Cors.AllowedHeaders,
+		AllowCredentials: cfg.Cors.AllowCredentials,
+	}).Handler)
+
+	r.Handle(contextPath+"/metrics", metrics.Handler())
+	return r
+}
+
+// MountHealth serves the liveness and readiness probes below contextPath. Readiness runs the
+// checks of backend, if it has any, and reports the configured storage backend.
+func MountHealth(r chi.Router, cfg Config, contextPath string, backend any) {
+	checker := health.NewChecker(cfg.Health)
+	storage := map[string]any{"backend": cfg.Basyx.Backend, "persistent": !cfg.Basyx.InMemory()}
+	checker.Add("storage", func(context.Context) health.Component {
+		return health.Up(storage)
+	})
+	checker.Register(backend)
+	checker.Mount(r, contextPath)
+}
+
+// Serve serves handler until ctx is done, then stops accepting connections and waits up to
+// ShutdownTimeout for in-flight requests to finish.
+func Serve(ctx context.Context, cfg ServerConfig, handler http.Handler, name string) error {
+	srv := &http.Server{Addr: cfg.Addr(), Handler: handler}
+	if err := certs.Configure(ctx, srv, cfg.TLS); err != nil {
+		return err
+	}
+	scheme := "http"
+	if srv.TLSConfig != nil {
+		scheme = "https"
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- certs.ListenAndServe(srv)
+	}()
+	slog.InfoContext(ctx, "Listening", "service", name, "address", scheme+"://"+srv.Addr)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down, waiting for in-flight requests", "service", name, "timeout", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Command is a subcommand of a service binary, e.g. "migrate".
+type Command struct {
+	Name string
+	// Usage is the one-line description shown by -h.
+	Usage string
+	Run   func(ctx context.Context, configPath string, args []string) error
+}
+
+// Main parses the command line and runs serve, or the command named by the first argument.
+// The context passed to them is cancelled on SIGINT and SIGTERM.
+func Main(description string, serve func(ctx context.Context, configPath string) error, commands ...Command) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	configPath := ""
+	flag.StringVar(&configPath, "config", "", "Path to config file")
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: %s [-config file] [command]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Commands:")
+		fmt.Fprintf(out, "  %-8s %s\n", "(none)", "start the "+description)
+		for _, c := range commands {
+			fmt.Fprintf(out, "  %-8s %s\n", c.Name, c.Usage)
+		}
+		fmt.Fprintln(out, "\nFlags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.Arg(0) == "" {
+		if err := serve(ctx, configPath); err != nil {
+			slog.Error("Server error", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	for _, c := range commands {
+		if c.Name == flag.Arg(0) {
+			if err := c.Run(ctx, configPath, flag.Args()[1:]); err != nil {
+				slog.Error("Command failed", "command", c.Name, "error", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	flag.Usage()
+	os.Exit(2)
+}
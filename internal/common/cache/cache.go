@@ -0,0 +1,175 @@
+// Package cache provides a concurrency-safe LRU cache with TTL and size accounting, and the
+// PostgreSQL LISTEN/NOTIFY plumbing that keeps the caches of several replicas coherent.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Options bound a cache. Zero values disable the respective limit.
+type Options struct {
+	// MaxEntries is the maximum number of entries.
+	MaxEntries int
+	// MaxBytes is the maximum sum of the entry sizes reported by the size function.
+	MaxBytes int64
+	// TTL is the time after which an entry is no longer returned.
+	TTL time.Duration
+}
+
+// Stats are counters of a cache since its creation.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	size    int64
+	expires time.Time
+}
+
+// Cache is a least-recently-used cache. All methods are safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu     sync.Mutex
+	opts   Options
+	sizeOf func(V) int64
+	now    func() time.Time
+
+	ll    *list.List
+	items map[K]*list.Element
+	bytes int64
+	// epoch is incremented by every invalidation, see Epoch and SetIfUnchanged.
+	epoch uint64
+	stats Stats
+}
+
+// New creates a cache. sizeOf reports the size of a value for Options.MaxBytes; it may be
+// nil if MaxBytes is not used.
+func New[K comparable, V any](opts Options, sizeOf func(V) int64) *Cache[K, V] {
+	if sizeOf == nil {
+		sizeOf = func(V) int64 { return 0 }
+	}
+	return &Cache[K, V]{
+		opts:   opts,
+		sizeOf: sizeOf,
+		now:    time.Now,
+		ll:     list.New(),
+		items:  make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value stored for key if it exists and has not expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		if e.expires.IsZero() || c.now().Before(e.expires) {
+			c.ll.MoveToFront(el)
+			c.stats.Hits++
+			return e.value, true
+		}
+		c.removeElement(el)
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Set stores value for key and evicts the least recently used entries that exceed the limits.
+// Values larger than MaxBytes are not stored.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value)
+}
+
+// Epoch returns a token that SetIfUnchanged uses to detect invalidations. Take it before
+// loading a value from the database.
+func (c *Cache[K, V]) Epoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// SetIfUnchanged stores value only if nothing was invalidated since epoch was taken. This
+// prevents a slow reader from caching a value that a concurrent writer has already replaced.
+func (c *Cache[K, V]) SetIfUnchanged(key K, value V, epoch uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch != epoch {
+		return false
+	}
+	c.set(key, value)
+	return true
+}
+
+// Delete removes key.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// Purge removes all entries.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	c.ll.Init()
+	c.items = make(map[K]*list.Element)
+	c.bytes = 0
+}
+
+// Stats returns the current counters.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.ll.Len()
+	s.Bytes = c.bytes
+	return s
+}
+
+func (c *Cache[K, V]) set(key K, value V) {
+	size := c.sizeOf(value)
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	if c.opts.MaxBytes > 0 && size > c.opts.MaxBytes {
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value, size: size}
+	if c.opts.TTL > 0 {
+		e.expires = c.now().Add(c.opts.TTL)
+	}
+	c.items[key] = c.ll.PushFront(e)
+	c.bytes += size
+
+	for c.overLimit() {
+		c.removeElement(c.ll.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache[K, V]) overLimit() bool {
+	return (c.opts.MaxEntries > 0 && c.ll.Len() > c.opts.MaxEntries) ||
+		(c.opts.MaxBytes > 0 && c.bytes > c.opts.MaxBytes)
+}
+
+func (c *Cache[K, V]) removeElement(el *list.Element) {
+	e := c.ll.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
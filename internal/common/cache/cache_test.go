@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUEviction(t *testing.T) {
+	c := New[string, int](Options{MaxEntries: 2}, nil)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // b is now the least recently used entry
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatal("expected a to be kept")
+	}
+	if s := c.Stats(); s.Entries != 2 || s.Evictions != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestSizeAccounting(t *testing.T) {
+	c := New[string, string](Options{MaxBytes: 10}, func(v string) int64 { return int64(len(v)) })
+	c.Set("a", "12345")
+	c.Set("b", "12345")
+	if s := c.Stats(); s.Bytes != 10 || s.Entries != 2 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	c.Set("a", "123") // replacing an entry releases its old size
+	if s := c.Stats(); s.Bytes != 8 {
+		t.Fatalf("expected 8 bytes after replace, got %d", s.Bytes)
+	}
+
+	c.Set("c", "12345")
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be evicted to stay within MaxBytes")
+	}
+
+	c.Set("huge", "12345678901")
+	if _, ok := c.Get("huge"); ok {
+		t.Fatal("values larger than MaxBytes must not be stored")
+	}
+}
+
+func TestTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](Options{TTL: time.Minute}, nil)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	now = now.Add(59 * time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a before expiry")
+	}
+	now = now.Add(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected a to expire")
+	}
+	if s := c.Stats(); s.Entries != 0 {
+		t.Fatalf("expired entry was not removed: %+v", s)
+	}
+}
+
+func TestSetIfUnchanged(t *testing.T) {
+	c := New[string, int](Options{}, nil)
+
+	epoch := c.Epoch()
+	c.Delete("a") // a concurrent writer invalidates while the reader loads
+	if c.SetIfUnchanged("a", 1, epoch) {
+		t.Fatal("stale value must not be stored after an invalidation")
+	}
+
+	epoch = c.Epoch()
+	if !c.SetIfUnchanged("a", 2, epoch) {
+		t.Fatal("expected value to be stored")
+	}
+
+	c.Purge()
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected purge to remove all entries")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	c := New[string, int](Options{MaxEntries: 50}, nil)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((g * i) % 100)
+				c.Set(key, i)
+				c.Get(key)
+				if i%10 == 0 {
+					c.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if s := c.Stats(); s.Entries > 50 {
+		t.Fatalf("cache exceeded MaxEntries: %+v", s)
+	}
+}
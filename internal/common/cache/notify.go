@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NotifyQuery publishes an invalidation; the arguments are the channel and the key. Executed
+// inside the writing transaction, the notification is only delivered if the transaction commits.
+const NotifyQuery = `SELECT pg_notify($1, $2)`
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Listener receives the notifications of a channel, see StartListener.
+type Listener struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartListener subscribes to channel on a dedicated connection and calls invalidate with the
+// payload of every notification until ctx is done or the listener is closed. The first
+// subscription happens before StartListener returns, so configuration errors are reported to
+// the caller.
+//
+// Notifications sent while the connection is lost cannot be recovered, so after reconnecting
+// reset is called and the cache must drop all entries.
+func StartListener(ctx context.Context, dsn string, channel string, invalidate func(payload string), reset func()) (*Listener, error) {
+	conn, err := listen(ctx, dsn, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	l := &Listener{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(l.done)
+		delay := minReconnectDelay
+		for {
+			err := receive(ctx, conn, invalidate)
+			conn.Close(context.Background())
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("Cache invalidation listener lost its connection", "channel", channel, "error", err)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				conn, err = listen(ctx, dsn, channel)
+				if err == nil {
+					break
+				}
+				slog.Warn("Cache invalidation listener failed to reconnect", "channel", channel, "error", err)
+				delay = min(2*delay, maxReconnectDelay)
+			}
+			delay = minReconnectDelay
+			reset()
+		}
+	}()
+	return l, nil
+}
+
+// Close stops the listener and waits until its connection is closed.
+func (l *Listener) Close() {
+	l.cancel()
+	<-l.done
+}
+
+func listen(ctx context.Context, dsn string, channel string) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+	return conn, nil
+}
+
+func receive(ctx context.Context, conn *pgx.Conn, invalidate func(payload string)) error {
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		invalidate(n.Payload)
+	}
+}
@@ -0,0 +1,209 @@
+// Package certs serves HTTPS with certificates that are reloaded when their files change, so
+// rotated certificates are picked up without a restart, and optionally verifies client
+// certificates for machine-to-machine calls.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Config enables HTTPS with the certificate and key in CertFile and KeyFile. ClientAuth selects
+// whether clients present certificates signed by ClientCAFile: none (default), optional, which
+// verifies a certificate if one is presented, or require. The files are checked for changes
+// every ReloadInterval.
+type Config struct {
+	Enabled        bool          `yaml:"enabled"`
+	CertFile       string        `yaml:"certFile"`
+	KeyFile        string        `yaml:"keyFile"`
+	ClientCAFile   string        `yaml:"clientCAFile"`
+	ClientAuth     string        `yaml:"clientAuth"`
+	MinVersion     string        `yaml:"minVersion"`
+	ReloadInterval time.Duration `yaml:"reloadInterval"`
+}
+
+// Reloader holds the TLS configuration built from the files of a Config and rebuilds it when
+// one of them changes.
+type Reloader struct {
+	cfg     Config
+	current atomic.Pointer[tls.Config]
+	modTime time.Time
+}
+
+// NewReloader loads the files of cfg.
+func NewReloader(cfg Config) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("server.tls.certFile and server.tls.keyFile are required if TLS is enabled")
+	}
+	r := &Reloader{cfg: cfg}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns the configuration to pass to an http.Server. It hands out the latest
+// loaded certificates to every new connection.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.current.Load().MinVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+	}
+}
+
+// Watch reloads the files whenever they change until ctx is done. A failed reload is logged
+// and the previous certificates stay in use.
+func (r *Reloader) Watch(ctx context.Context) {
+	interval := r.cfg.ReloadInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		modTime, err := latestModTime(r.files())
+		if err != nil || !modTime.After(r.modTime) {
+			continue
+		}
+		if err := r.load(); err != nil {
+			slog.Error("Failed to reload TLS certificates, keeping the previous ones", "error", err)
+			continue
+		}
+		slog.Info("Reloaded TLS certificates", "certFile", r.cfg.CertFile)
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) load() error {
+	modTime, err := latestModTime(r.files())
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading server certificate: %w", err)
+	}
+	minVersion, err := parseVersion(r.cfg.MinVersion)
+	if err != nil {
+		return err
+	}
+	clientAuth, err := parseClientAuth(r.cfg.ClientAuth)
+	if err != nil {
+		return err
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+		ClientAuth:   clientAuth,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if clientAuth != tls.NoClientCert {
+		if r.cfg.ClientCAFile == "" {
+			return errors.New("server.tls.clientCAFile is required to verify client certificates")
+		}
+		pool, err := LoadCertPool(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		cfg.ClientCAs = pool
+	}
+	r.current.Store(cfg)
+	r.modTime = modTime
+	return nil
+}
+
+// LoadCertPool reads the PEM encoded CA certificates in file.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+func latestModTime(files []string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func parseVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported server.tls.minVersion '%s': valid values are [1.2 1.3]", v)
+}
+
+func parseClientAuth(v string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(v) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "optional":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return 0, fmt.Errorf("unsupported server.tls.clientAuth '%s': valid values are [none optional require]", v)
+}
+
+// Configure makes srv serve HTTPS if cfg is enabled and watches the certificates for changes
+// until ctx is done. Invalid files are reported here rather than when serving starts.
+func Configure(ctx context.Context, srv *http.Server, cfg Config) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	r, err := NewReloader(cfg)
+	if err != nil {
+		return err
+	}
+	go r.Watch(ctx)
+	srv.TLSConfig = r.TLSConfig()
+	return nil
+}
+
+// ListenAndServe serves srv over HTTPS if Configure enabled TLS and over HTTP otherwise.
+func ListenAndServe(srv *http.Server) error {
+	if srv.TLSConfig == nil {
+		return srv.ListenAndServe()
+	}
+	return srv.ListenAndServeTLS("", "")
+}
@@ -0,0 +1,105 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate for cn and its key to dir.
+func writeCert(t *testing.T, dir string, cn string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		DNSNames:     []string{cn},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func servedCommonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+	cfg, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestReloaderPicksUpRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "old")
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cn := servedCommonName(t, r); cn != "old" {
+		t.Fatalf("expected the initial certificate, got %q", cn)
+	}
+
+	writeCert(t, dir, "new")
+	later := time.Now().Add(time.Minute)
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.load(); err != nil {
+		t.Fatal(err)
+	}
+	if cn := servedCommonName(t, r); cn != "new" {
+		t.Fatalf("expected the rotated certificate, got %q", cn)
+	}
+}
+
+func TestClientAuthRequiresCA(t *testing.T) {
+	certFile, keyFile := writeCert(t, t.TempDir(), "server")
+	if _, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: "require"}); err == nil {
+		t.Fatal("expected an error without clientCAFile")
+	}
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: "optional", ClientCAFile: certFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg := r.current.Load(); cfg.ClientAuth != tls.VerifyClientCertIfGiven || cfg.ClientCAs == nil {
+		t.Errorf("expected optional client certificate verification, got %v", cfg.ClientAuth)
+	}
+	if _, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: "sometimes"}); err == nil {
+		t.Error("expected an error for an unknown clientAuth")
+	}
+}
@@ -1,8 +1,11 @@
 package common
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,65 +13,192 @@ import (
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 )
 
-type ErrorHandler struct {
-	MessageType   string `json:"messageType"`
-	Text          string `json:"text"`
-	Code          string `json:"code,omitempty"`
-	CorrelationId string `json:"correlationId,omitempty"`
-	Timestamp     string `json:"timestamp,omitempty"`
+// ErrorCode classifies an Error and determines the HTTP status it is reported with.
+type ErrorCode string
+
+const (
+	ErrCodeBadRequest          ErrorCode = "BadRequest"
+	ErrCodeUnauthorized        ErrorCode = "Unauthorized"
+	ErrCodeForbidden           ErrorCode = "Forbidden"
+	ErrCodeNotFound            ErrorCode = "NotFound"
+	ErrCodeConflict            ErrorCode = "Conflict"
+	ErrCodeGone                ErrorCode = "Gone"
+	ErrCodeUnprocessableEntity ErrorCode = "UnprocessableEntity"
+	ErrCodeInternalServerError ErrorCode = "InternalServerError"
+	ErrCodeNotImplemented      ErrorCode = "NotImplemented"
+	ErrCodeTimeout             ErrorCode = "Timeout"
+)
+
+var errorCodeStatus = map[ErrorCode]int{
+	ErrCodeBadRequest:          http.StatusBadRequest,
+	ErrCodeUnauthorized:        http.StatusUnauthorized,
+	ErrCodeForbidden:           http.StatusForbidden,
+	ErrCodeNotFound:            http.StatusNotFound,
+	ErrCodeConflict:            http.StatusConflict,
+	ErrCodeGone:                http.StatusGone,
+	ErrCodeUnprocessableEntity: http.StatusUnprocessableEntity,
+	ErrCodeInternalServerError: http.StatusInternalServerError,
+	ErrCodeNotImplemented:      http.StatusNotImplemented,
+	ErrCodeTimeout:             http.StatusGatewayTimeout,
 }
 
-func NewErrorHandler(messageType string, text error, code string, correlationId string, timestamp string) *ErrorHandler {
-	return &ErrorHandler{
-		MessageType:   messageType,
-		Text:          text.Error(),
-		Code:          code,
-		CorrelationId: correlationId,
-		Timestamp:     timestamp,
+// Status returns the HTTP status of the code, 500 for unknown codes.
+func (c ErrorCode) Status() int {
+	if status, ok := errorCodeStatus[c]; ok {
+		return status
 	}
+	return http.StatusInternalServerError
+}
+
+// errorCodeOf returns the code of status, BadRequest or InternalServerError if it has none.
+func errorCodeOf(status int) ErrorCode {
+	for code, s := range errorCodeStatus {
+		if s == status {
+			return code
+		}
+	}
+	if status < http.StatusInternalServerError {
+		return ErrCodeBadRequest
+	}
+	return ErrCodeInternalServerError
+}
+
+// Error is a failure that is reported to the client. Message is returned in the response,
+// the wrapped Err is only logged.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	status := e.Code.Status()
+	msg := strconv.Itoa(status) + " " + http.StatusText(status) + ": " + e.Message
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns an Error with the given code that wraps cause, which may be nil.
+func NewError(code ErrorCode, message string, cause error) error {
+	return &Error{Code: code, Message: message, Err: cause}
 }
 
 func NewErrNotFound(elementId string) error {
-	return errors.New("404 Not Found: " + elementId)
+	return &Error{Code: ErrCodeNotFound, Message: elementId}
 }
 
 func NewErrBadRequest(message string) error {
-	return errors.New("400 Bad Request: " + message)
+	return &Error{Code: ErrCodeBadRequest, Message: message}
 }
 
 func NewInternalServerError(message string) error {
-	return errors.New("500 Internal Server Error: " + message)
+	return &Error{Code: ErrCodeInternalServerError, Message: message}
 }
 
 func NewErrConflict(message string) error {
-	return errors.New("409 Conflict: " + message)
+	return &Error{Code: ErrCodeConflict, Message: message}
+}
+
+// AsError returns the Error in the chain of err. sql.ErrNoRows, which pgx.ErrNoRows matches as
+// well, is reported as NotFound and an exceeded deadline as Timeout, also when an
+// InternalServerError wraps it.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	switch {
+	case err == nil:
+		return nil, false
+	case errors.As(err, &e):
+		if e.Code == ErrCodeInternalServerError && errors.Is(e, context.DeadlineExceeded) {
+			return &Error{Code: ErrCodeTimeout, Message: "The request took too long and was cancelled", Err: err}, true
+		}
+		return e, true
+	case errors.Is(err, sql.ErrNoRows):
+		return &Error{Code: ErrCodeNotFound, Message: "Resource not found", Err: err}, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Code: ErrCodeTimeout, Message: "The request took too long and was cancelled", Err: err}, true
+	}
+	return nil, false
+}
+
+func hasErrorCode(err error, code ErrorCode) bool {
+	e, ok := AsError(err)
+	return ok && e.Code == code
 }
 
 func IsErrNotFound(err error) bool {
-	return err != nil && strings.HasPrefix(err.Error(), "404 Not Found: ")
+	return hasErrorCode(err, ErrCodeNotFound)
 }
 
 func IsErrBadRequest(err error) bool {
-	return err != nil && strings.HasPrefix(err.Error(), "400 Bad Request: ")
+	return hasErrorCode(err, ErrCodeBadRequest)
 }
 
 func IsInternalServerError(err error) bool {
-	return err != nil && strings.HasPrefix(err.Error(), "500 Internal Server Error: ")
+	return hasErrorCode(err, ErrCodeInternalServerError)
 }
 
 func IsErrConflict(err error) bool {
-	return err != nil && strings.HasPrefix(err.Error(), "409 Conflict: ")
+	return hasErrorCode(err, ErrCodeConflict)
 }
 
-func NewErrorResponse(err error, errorCode int, component string, function string, info string) model.ImplResponse {
+// NewErrorResponse builds an error body whose code identifies the failing operation and whose
+// correlation id is the request id of ctx, which is also attached to the request's log records.
+func NewErrorResponse(ctx context.Context, err error, errorCode int, component string, function string, info string) model.ImplResponse {
 	codeStr := strconv.Itoa(errorCode)
 	statusText := strings.ReplaceAll(http.StatusText(errorCode), " ", "")
 	internalCode := fmt.Sprintf("%s-%s-%s-%s-%s", component, codeStr, function, statusText, info)
 
-	return model.Response(
-		errorCode,
-		[]ErrorHandler{
-			*NewErrorHandler("Error", err, codeStr, internalCode, string(GetCurrentTimestamp())),
-		},
-	)
+	return model.Response(errorCode, newResult(ctx, err.Error(), internalCode))
+}
+
+// ErrorResponse maps err to the status of its ErrorCode and a Result with a single error Message.
+// Errors without a code take the status of result if that is an error status other than 500.
+// If result is nil, the controller rejected the request before calling the service, which is
+// reported as 400.
+// Everything else is an internal error whose details are logged but not returned.
+func ErrorResponse(ctx context.Context, err error, result *model.ImplResponse) model.ImplResponse {
+	var parsingErr *model.ParsingError
+	var requiredErr *model.RequiredError
+
+	e, ok := AsError(err)
+	switch {
+	case ok:
+	case errors.As(err, &parsingErr):
+		e = &Error{Code: ErrCodeBadRequest, Message: err.Error()}
+	case errors.As(err, &requiredErr):
+		e = &Error{Code: ErrCodeUnprocessableEntity, Message: err.Error()}
+	case result == nil:
+		e = &Error{Code: ErrCodeBadRequest, Message: err.Error()}
+	case result.Code >= http.StatusBadRequest && result.Code != http.StatusInternalServerError:
+		e = &Error{Code: errorCodeOf(result.Code), Message: err.Error()}
+	default:
+		e = &Error{Code: ErrCodeInternalServerError, Message: "An unexpected error occurred", Err: err}
+	}
+
+	if e.Code == ErrCodeInternalServerError || e.Code == ErrCodeTimeout {
+		slog.ErrorContext(ctx, "Request failed", "code", string(e.Code), "error", err)
+	}
+	return model.Response(e.Code.Status(), newResult(ctx, e.Message, string(e.Code)))
+}
+
+// HandleError is the error handler of the controllers of all services, see ErrorResponse.
+func HandleError(w http.ResponseWriter, r *http.Request, err error, result *model.ImplResponse) {
+	res := ErrorResponse(r.Context(), err, result)
+	_ = model.EncodeJSONResponse(res.Body, &res.Code, w)
+}
+
+func newResult(ctx context.Context, text string, code string) model.Result {
+	return model.Result{Messages: []model.Message{{
+		MessageType:   "Error",
+		Text:          text,
+		Code:          code,
+		CorrelationId: RequestIDFromContext(ctx),
+		Timestamp:     GetCurrentTimestamp(),
+	}}}
 }
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func TestErrorResponseStatus(t *testing.T) {
+	failed := model.Response(http.StatusInternalServerError, nil)
+	notImplemented := model.Response(http.StatusNotImplemented, nil)
+	tests := []struct {
+		name   string
+		err    error
+		result *model.ImplResponse
+		status int
+		code   ErrorCode
+	}{
+		{"typed", NewErrNotFound("Submodel"), &failed, http.StatusNotFound, ErrCodeNotFound},
+		{"wrapped", fmt.Errorf("loading: %w", NewErrConflict("exists")), &failed, http.StatusConflict, ErrCodeConflict},
+		{"no rows", fmt.Errorf("query: %w", sql.ErrNoRows), &failed, http.StatusNotFound, ErrCodeNotFound},
+		{"deadline", context.DeadlineExceeded, &failed, http.StatusGatewayTimeout, ErrCodeTimeout},
+		{"wrapped deadline", NewError(ErrCodeInternalServerError, "Failed to query", context.DeadlineExceeded), &failed, http.StatusGatewayTimeout, ErrCodeTimeout},
+		{"parsing", &model.ParsingError{Param: "limit", Err: errors.New("not a number")}, nil, http.StatusBadRequest, ErrCodeBadRequest},
+		{"rejected by controller", errors.New("invalid"), nil, http.StatusBadRequest, ErrCodeBadRequest},
+		{"status of result", errors.New("not implemented"), &notImplemented, http.StatusNotImplemented, ErrCodeNotImplemented},
+		{"untyped", errors.New("connection refused"), &failed, http.StatusInternalServerError, ErrCodeInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ErrorResponse(context.Background(), tt.err, tt.result)
+			if res.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, res.Code)
+			}
+			if got := res.Body.(model.Result).Messages[0].Code; got != string(tt.code) {
+				t.Errorf("expected code %s, got %s", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestErrorResponseHidesInternalDetails(t *testing.T) {
+	failed := model.Response(http.StatusInternalServerError, nil)
+	res := ErrorResponse(context.Background(), errors.New("password authentication failed"), &failed)
+	if text := res.Body.(model.Result).Messages[0].Text; text != "An unexpected error occurred" {
+		t.Errorf("expected a generic message, got %q", text)
+	}
+}
+
+func TestWrappedErrorKeepsCause(t *testing.T) {
+	cause := errors.New("duplicate key")
+	err := NewError(ErrCodeConflict, "Submodel exists", cause)
+	if !errors.Is(err, cause) || !IsErrConflict(err) {
+		t.Fatalf("expected a conflict wrapping the cause, got %v", err)
+	}
+	if err.Error() != "409 Conflict: Submodel exists: duplicate key" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
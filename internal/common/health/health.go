@@ -0,0 +1,168 @@
+// Package health implements the liveness and readiness probes of the services. Readiness
+// reports its checks in the component breakdown of Spring Boot Actuator:
+//
+//	{"status":"DOWN","components":{"db":{"status":"DOWN","details":{"error":"..."}},...}}
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Status is the state of a component. UP is healthy; every other status fails readiness.
+type Status string
+
+const (
+	StatusUp           Status = "UP"
+	StatusDown         Status = "DOWN"
+	StatusOutOfService Status = "OUT_OF_SERVICE"
+	StatusUnknown      Status = "UNKNOWN"
+)
+
+// Component is the result of a check, or the aggregate of several.
+type Component struct {
+	Status     Status               `json:"status"`
+	Details    map[string]any       `json:"details,omitempty"`
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+// Up returns a healthy component with details.
+func Up(details map[string]any) Component {
+	return Component{Status: StatusUp, Details: details}
+}
+
+// Down returns a failed component with the error and further details.
+func Down(err error, details map[string]any) Component {
+	if details == nil {
+		details = map[string]any{}
+	}
+	details["error"] = err.Error()
+	return Component{Status: StatusDown, Details: details}
+}
+
+// Check reports the state of one dependency. It must return when ctx is done.
+type Check func(ctx context.Context) Component
+
+// Source is implemented by backends that know how to check their dependencies.
+type Source interface {
+	HealthChecks(cfg Config) map[string]Check
+}
+
+// Config bounds how long readiness may take and when the connection pool counts as exhausted.
+type Config struct {
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxPoolSaturation is the share of busy connections, e.g. 0.95, at which the pool
+	// reports DOWN; 0 only reports the saturation.
+	MaxPoolSaturation float64 `yaml:"maxPoolSaturation"`
+}
+
+// Checker runs the readiness checks.
+type Checker struct {
+	cfg    Config
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// NewChecker returns a Checker without checks.
+func NewChecker(cfg Config) *Checker {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	return &Checker{cfg: cfg, checks: map[string]Check{}}
+}
+
+// Add registers a check under the component name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Register adds the checks of backend if it is a Source.
+func (c *Checker) Register(backend any) {
+	if s, ok := backend.(Source); ok {
+		for name, check := range s.HealthChecks(c.cfg) {
+			c.Add(name, check)
+		}
+	}
+}
+
+// Check runs all checks concurrently and aggregates them: UP if all are UP, DOWN otherwise.
+// Checks that do not finish within the timeout are reported as UNKNOWN.
+func (c *Checker) Check(ctx context.Context) Component {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	c.mu.Lock()
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.Unlock()
+
+	results := make([]chan Component, len(checks))
+	for i, check := range checks {
+		results[i] = make(chan Component, 1)
+		go func() { results[i] <- check(ctx) }()
+	}
+
+	agg := Component{Status: StatusUp, Components: make(map[string]Component, len(names))}
+	for i, name := range names {
+		var comp Component
+		select {
+		case comp = <-results[i]:
+		case <-ctx.Done():
+			// checks that finished before the deadline still count
+			select {
+			case comp = <-results[i]:
+			default:
+				comp = Component{Status: StatusUnknown, Details: map[string]any{"error": "check timed out"}}
+			}
+		}
+		agg.Components[name] = comp
+		if comp.Status != StatusUp {
+			agg.Status = StatusDown
+		}
+	}
+	return agg
+}
+
+// Mount serves the probes below prefix:
+//
+//	/health/liveness   UP while the process serves requests
+//	/health/readiness  the result of all checks, 503 unless UP
+//	/health            the same as readiness
+func (c *Checker) Mount(r chi.Router, prefix string) {
+	r.Get(prefix+"/health/liveness", Liveness)
+	r.Get(prefix+"/health/readiness", c.Readiness)
+	r.Get(prefix+"/health", c.Readiness)
+}
+
+// Liveness does not look at dependencies: an unreachable database is no reason to restart.
+func Liveness(w http.ResponseWriter, r *http.Request) {
+	write(w, Component{Status: StatusUp})
+}
+
+// Readiness reports whether the service can handle requests.
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	write(w, c.Check(r.Context()))
+}
+
+func write(w http.ResponseWriter, comp Component) {
+	w.Header().Set("Content-Type", "application/json")
+	if comp.Status != StatusUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(comp)
+}
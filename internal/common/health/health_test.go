@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type source struct{}
+
+func (source) HealthChecks(Config) map[string]Check {
+	return map[string]Check{"db": func(context.Context) Component { return Up(nil) }}
+}
+
+func serve(t *testing.T, c *Checker, path string) (int, Component) {
+	t.Helper()
+	r := chi.NewRouter()
+	c.Mount(r, "/api")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var comp Component
+	if err := json.Unmarshal(rec.Body.Bytes(), &comp); err != nil {
+		t.Fatalf("%s: %v", rec.Body, err)
+	}
+	return rec.Code, comp
+}
+
+func TestReadinessAggregatesComponents(t *testing.T) {
+	c := NewChecker(Config{})
+	c.Register(source{})
+	c.Register(struct{}{})
+	c.Add("storage", func(context.Context) Component { return Up(map[string]any{"backend": "PostgreSQL"}) })
+
+	code, comp := serve(t, c, "/api/health/readiness")
+	if code != http.StatusOK || comp.Status != StatusUp || len(comp.Components) != 2 {
+		t.Fatalf("got %d %+v", code, comp)
+	}
+
+	c.Add("migrations", func(context.Context) Component { return Down(errors.New("pending"), nil) })
+	code, comp = serve(t, c, "/api/health")
+	if code != http.StatusServiceUnavailable || comp.Status != StatusDown {
+		t.Fatalf("got %d %+v", code, comp)
+	}
+	if m := comp.Components["migrations"]; m.Status != StatusDown || m.Details["error"] != "pending" {
+		t.Errorf("migrations = %+v", m)
+	}
+	if comp.Components["db"].Status != StatusUp {
+		t.Errorf("db = %+v", comp.Components["db"])
+	}
+}
+
+func TestReadinessTimesOut(t *testing.T) {
+	c := NewChecker(Config{Timeout: 20 * time.Millisecond})
+	block := make(chan struct{})
+	defer close(block)
+	c.Add("db", func(context.Context) Component {
+		<-block
+		return Up(nil)
+	})
+	c.Add("pool", func(context.Context) Component { return Up(nil) })
+
+	code, comp := serve(t, c, "/api/health/readiness")
+	if code != http.StatusServiceUnavailable || comp.Components["db"].Status != StatusUnknown || comp.Components["pool"].Status != StatusUp {
+		t.Fatalf("got %d %+v", code, comp)
+	}
+}
+
+func TestLivenessIgnoresChecks(t *testing.T) {
+	c := NewChecker(Config{})
+	c.Add("db", func(context.Context) Component { return Down(errors.New("connection refused"), nil) })
+
+	code, comp := serve(t, c, "/api/health/liveness")
+	if code != http.StatusOK || comp.Status != StatusUp || comp.Components != nil {
+		t.Fatalf("got %d %+v", code, comp)
+	}
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/migrate"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+)
+
+// PostgresChecks returns the checks of a PostgreSQL backend:
+//
+//	db          the database answers on a pooled connection
+//	migrations  every schema, public and those of tenants, has all migrations applied
+//	pool        the share of busy connections, DOWN from maxSaturation on if it is set
+func PostgresChecks(pool *pgxpool.Pool, migrator *migrate.Migrator, tenants []string, maxSaturation float64) map[string]Check {
+	return map[string]Check{
+		"db": func(ctx context.Context) Component {
+			details := map[string]any{"database": "PostgreSQL"}
+			var version string
+			if err := pool.QueryRow(ctx, `SHOW server_version`).Scan(&version); err != nil {
+				return Down(err, details)
+			}
+			details["version"] = version
+			return Up(details)
+		},
+		"migrations": func(ctx context.Context) Component {
+			return migrationStatus(ctx, migrator, tenants)
+		},
+		"pool": func(ctx context.Context) Component {
+			s := pool.Stat()
+			saturation := float64(s.AcquiredConns()) / float64(s.MaxConns())
+			details := map[string]any{
+				"acquired":   s.AcquiredConns(),
+				"idle":       s.IdleConns(),
+				"total":      s.TotalConns(),
+				"max":        s.MaxConns(),
+				"saturation": saturation,
+			}
+			if maxSaturation > 0 && saturation >= maxSaturation {
+				return Down(fmt.Errorf("%d of %d connections are in use", s.AcquiredConns(), s.MaxConns()), details)
+			}
+			return Up(details)
+		},
+	}
+}
+
+// migrationStatus sums up the migrations of public and the schemas of tenants. The
+// details only count schemas, since the probes are unauthenticated and must not
+// reveal which tenants exist.
+func migrationStatus(ctx context.Context, migrator *migrate.Migrator, tenants []string) Component {
+	ctxs := []context.Context{ctx}
+	for _, id := range tenants {
+		ctxs = append(ctxs, tenant.WithTenant(ctx, id))
+	}
+	var pending, outdated, failed int
+	for _, ctx := range ctxs {
+		_, n, err := migrator.Pending(ctx)
+		switch {
+		case err != nil:
+			slog.ErrorContext(ctx, "Reading the migrations failed", "error", err)
+			failed++
+		case n > 0:
+			pending += n
+			outdated++
+		}
+	}
+	details := map[string]any{"schemas": len(ctxs), "pending": pending}
+	switch {
+	case failed > 0:
+		return Down(fmt.Errorf("the migrations of %d of %d schemas could not be read", failed, len(ctxs)), details)
+	case outdated > 0:
+		return Down(fmt.Errorf("%d of %d schemas are not up to date, run 'migrate up'", outdated, len(ctxs)), details)
+	}
+	return Up(details)
+}
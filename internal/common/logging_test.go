@@ -0,0 +1,63 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func TestRequestLoggerKeepsClientRequestID(t *testing.T) {
+	var seen string
+	handler := RequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if seen != "abc" || rec.Header().Get(RequestIDHeader) != "abc" {
+		t.Fatalf("expected request id abc, got %q in context and %q in header", seen, rec.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestRequestLoggerReplacesInvalidClientRequestID(t *testing.T) {
+	for _, id := range []string{strings.Repeat("a", maxRequestIDLength+1), "abc\ninjected=1", "a b"} {
+		handler := RequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, id)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get(RequestIDHeader); got == id || !validRequestID(got) {
+			t.Errorf("expected a generated request id for %q, got %q", id, got)
+		}
+	}
+}
+
+func TestErrorResponseCarriesRequestID(t *testing.T) {
+	var body model.Result
+	handler := RequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = NewErrorResponse(r.Context(), errors.New("boom"), http.StatusNotFound, "TEST", "Get", "NotFound").Body.(model.Result)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id := rec.Header().Get(RequestIDHeader)
+	if id == "" || body.Messages[0].CorrelationId != id {
+		t.Fatalf("expected correlation id %q, got %q", id, body.Messages[0].CorrelationId)
+	}
+	if body.Messages[0].Code != "TEST-404-Get-NotFound-NotFound" {
+		t.Errorf("unexpected code %q", body.Messages[0].Code)
+	}
+}
+
+func TestSetupLoggingRejectsUnknownFormat(t *testing.T) {
+	if err := SetupLogging(LogConfig{Level: "info", Format: "xml"}); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,84 @@
+// Package lookup describes the lookup of submodel elements by semanticId across all
+// submodels. The backends find the elements whose semanticId, and optionally one of their
+// supplementalSemanticIds, has a key with the given value or a value starting with it.
+package lookup
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// Request is a lookup of the elements with SemanticID. Pages are addressed by the opaque
+// Cursor.
+type Request struct {
+	SemanticID string
+	// Prefix matches the keys whose value starts with SemanticID instead of equal ones.
+	Prefix bool
+	// Supplemental also matches the supplementalSemanticIds of the elements.
+	Supplemental bool
+	Limit        int32
+	Cursor       string
+}
+
+// Validate reports a missing semanticId and a cursor that was not returned by a lookup as
+// bad requests.
+func (r Request) Validate() error {
+	if strings.TrimSpace(r.SemanticID) == "" {
+		return common.NewErrBadRequest("the semanticId to look up is required")
+	}
+	if _, err := r.After(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// After returns the element id the page starts after: matches are ordered by the database
+// id of the element, which keeps pages stable while elements are added.
+func (r Request) After() (int64, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(r.Cursor, 10, 64)
+	if err != nil || id < 0 {
+		return 0, common.NewErrBadRequest("invalid cursor '" + r.Cursor + "'")
+	}
+	return id, nil
+}
+
+// Cursor is the cursor of the page after the element with the database id last.
+func Cursor(last int64) string {
+	return strconv.FormatInt(last, 10)
+}
+
+// Match is an element with the semanticId.
+type Match struct {
+	SubmodelID  string `json:"submodelId"`
+	IdShortPath string `json:"idShortPath"`
+}
+
+// ElementReference returns the ModelReference of the element at idShortPath of the submodel
+// with submodelID. Keys of parents are SubmodelElementList if an index follows and
+// SubmodelElement otherwise, the last key has the model type of el.
+func ElementReference(submodelID string, idShortPath string, el gen.SubmodelElement) gen.Reference {
+	keys := []gen.Key{{Type: gen.KEYTYPES_SUBMODEL, Value: submodelID}}
+	for _, segment := range strings.Split(idShortPath, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+		keys = append(keys, gen.Key{Type: gen.KEYTYPES_SUBMODEL_ELEMENT, Value: name})
+		if indexes == "" {
+			continue
+		}
+		keys[len(keys)-1].Type = gen.KEYTYPES_SUBMODEL_ELEMENT_LIST
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			keys = append(keys, gen.Key{Type: gen.KEYTYPES_SUBMODEL_ELEMENT_LIST, Value: index})
+		}
+		// the last index addresses an entry, which is a list only if another index follows
+		keys[len(keys)-1].Type = gen.KEYTYPES_SUBMODEL_ELEMENT
+	}
+	if el != nil && el.GetModelType() != "" {
+		keys[len(keys)-1].Type = gen.KeyTypes(el.GetModelType())
+	}
+	return gen.Reference{Type: gen.REFERENCETYPES_MODEL_REFERENCE, Keys: keys}
+}
@@ -0,0 +1,69 @@
+package lookup
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func TestRequestValidate(t *testing.T) {
+	r := Request{SemanticID: "0173-1#02-AAO677#002", Cursor: "42"}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := r.After(); after != 42 {
+		t.Errorf("After = %d", after)
+	}
+	for _, r := range []Request{{SemanticID: " "}, {SemanticID: "x", Cursor: "y"}, {SemanticID: "x", Cursor: "-1"}} {
+		if err := r.Validate(); !common.IsErrBadRequest(err) {
+			t.Errorf("%+v: want a bad request, got %v", r, err)
+		}
+	}
+}
+
+func TestElementReference(t *testing.T) {
+	ref := ElementReference("urn:sm", "Sensors.Readings[2][0].Value", &gen.Property{ModelType: "Property"})
+	want := []gen.Key{
+		{Type: gen.KEYTYPES_SUBMODEL, Value: "urn:sm"},
+		{Type: gen.KEYTYPES_SUBMODEL_ELEMENT, Value: "Sensors"},
+		{Type: gen.KEYTYPES_SUBMODEL_ELEMENT_LIST, Value: "Readings"},
+		{Type: gen.KEYTYPES_SUBMODEL_ELEMENT_LIST, Value: "2"},
+		{Type: gen.KEYTYPES_SUBMODEL_ELEMENT, Value: "0"},
+		{Type: gen.KeyTypes("Property"), Value: "Value"},
+	}
+	if ref.Type != gen.REFERENCETYPES_MODEL_REFERENCE || len(ref.Keys) != len(want) {
+		t.Fatalf("got %+v", ref)
+	}
+	for i := range want {
+		if ref.Keys[i] != want[i] {
+			t.Errorf("key %d: got %+v, want %+v", i, ref.Keys[i], want[i])
+		}
+	}
+}
+
+func TestValueOnly(t *testing.T) {
+	collection := &gen.SubmodelElementCollection{Value: []gen.SubmodelElement{
+		&gen.Property{IdShort: "Temperature", ValueType: gen.DATATYPEDEFXSD_XS_DOUBLE, Value: "21.5"},
+		&gen.Property{IdShort: "Running", ValueType: gen.DATATYPEDEFXSD_XS_BOOLEAN, Value: "true"},
+		&gen.Property{IdShort: "Broken", ValueType: gen.DATATYPEDEFXSD_XS_DOUBLE, Value: "NaN"},
+		&gen.MultiLanguageProperty{IdShort: "Name", Value: []gen.LangStringTextType{{Language: "en", Text: "Pump"}}},
+		&gen.SubmodelElementList{IdShort: "Limits", Value: []gen.SubmodelElement{
+			&gen.Range{ValueType: gen.DATATYPEDEFXSD_XS_INT, Min: "1", Max: "5"},
+		}},
+		&gen.Capability{IdShort: "CanPump"},
+	}}
+	value, ok := ValueOnly(collection)
+	if !ok {
+		t.Fatal("a collection has a value")
+	}
+	got, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Broken":"NaN","Limits":[{"max":5,"min":1}],"Name":[{"en":"Pump"}],"Running":true,"Temperature":21.5}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package lookup
+
+import (
+	"encoding/json"
+	"strconv"
+
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// ValueOnly returns the value-only serialization of el (IDTA-01002 Part 2): properties and
+// ranges are typed JSON values, multi-language properties an array of {language: text},
+// files and blobs {contentType, value}, references the reference, collections and entity
+// statements an object by idShort and lists an array. Capabilities and operations have no
+// value, ValueOnly reports them with false.
+func ValueOnly(el gen.SubmodelElement) (any, bool) {
+	switch v := el.(type) {
+	case *gen.Property:
+		return typedValue(v.Value, v.ValueType), true
+	case *gen.MultiLanguageProperty:
+		texts := make([]map[string]string, 0, len(v.Value))
+		for _, t := range v.Value {
+			texts = append(texts, map[string]string{t.Language: t.Text})
+		}
+		return texts, true
+	case *gen.Range:
+		return map[string]any{"min": typedValue(v.Min, v.ValueType), "max": typedValue(v.Max, v.ValueType)}, true
+	case *gen.File:
+		return map[string]any{"contentType": v.ContentType, "value": v.Value}, true
+	case *gen.Blob:
+		return map[string]any{"contentType": v.ContentType, "value": v.Value}, true
+	case *gen.ReferenceElement:
+		return v.Value, true
+	case *gen.RelationshipElement:
+		return map[string]any{"first": v.First, "second": v.Second}, true
+	case *gen.AnnotatedRelationshipElement:
+		value := map[string]any{"first": v.First, "second": v.Second}
+		if len(v.Annotations) > 0 {
+			value["annotations"] = valuesByIdShort(v.Annotations)
+		}
+		return value, true
+	case *gen.BasicEventElement:
+		return map[string]any{"observed": v.Observed}, true
+	case *gen.Entity:
+		value := map[string]any{"statements": valuesByIdShort(v.Statements), "entityType": v.EntityType}
+		if v.GlobalAssetId != "" {
+			value["globalAssetId"] = v.GlobalAssetId
+		}
+		if len(v.SpecificAssetIds) > 0 {
+			value["specificAssetIds"] = v.SpecificAssetIds
+		}
+		return value, true
+	case *gen.SubmodelElementCollection:
+		return valuesByIdShort(v.Value), true
+	case *gen.SubmodelElementList:
+		values := make([]any, 0, len(v.Value))
+		for _, child := range v.Value {
+			if value, ok := ValueOnly(child); ok {
+				values = append(values, value)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func valuesByIdShort(elements []gen.SubmodelElement) map[string]any {
+	values := make(map[string]any, len(elements))
+	for _, el := range elements {
+		if value, ok := ValueOnly(el); ok {
+			values[el.GetIdShort()] = value
+		}
+	}
+	return values
+}
+
+// typedValue returns numbers and booleans as JSON numbers and booleans, everything else and
+// values that do not parse as strings.
+func typedValue(s string, valueType gen.DataTypeDefXsd) any {
+	switch valueType {
+	case gen.DATATYPEDEFXSD_XS_BOOLEAN:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case gen.DATATYPEDEFXSD_XS_BYTE, gen.DATATYPEDEFXSD_XS_INT, gen.DATATYPEDEFXSD_XS_INTEGER, gen.DATATYPEDEFXSD_XS_LONG,
+		gen.DATATYPEDEFXSD_XS_SHORT, gen.DATATYPEDEFXSD_XS_DECIMAL, gen.DATATYPEDEFXSD_XS_DOUBLE, gen.DATATYPEDEFXSD_XS_FLOAT,
+		gen.DATATYPEDEFXSD_XS_NON_NEGATIVE_INTEGER, gen.DATATYPEDEFXSD_XS_NON_POSITIVE_INTEGER, gen.DATATYPEDEFXSD_XS_POSITIVE_INTEGER,
+		gen.DATATYPEDEFXSD_XS_NEGATIVE_INTEGER, gen.DATATYPEDEFXSD_XS_UNSIGNED_BYTE, gen.DATATYPEDEFXSD_XS_UNSIGNED_INT,
+		gen.DATATYPEDEFXSD_XS_UNSIGNED_LONG, gen.DATATYPEDEFXSD_XS_UNSIGNED_SHORT:
+		// ParseFloat also accepts NaN and Inf, which are no JSON numbers
+		if _, err := strconv.ParseFloat(s, 64); err == nil && json.Valid([]byte(s)) {
+			return json.Number(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,186 @@
+// Package metrics exposes Prometheus metrics of the services: request durations per route,
+// the state of the database connection pools, failed transaction begins, cache usage and the
+// requests and cache lookups of each tenant.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/cache"
+)
+
+const namespace = "basyx"
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "http_request_duration_seconds",
+	Help:      "Duration of HTTP requests by method, route pattern and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// TransactionBeginFailures counts database transactions that could not be started, which
+// clients see as "Failed to begin PostgreSQL transaction".
+var TransactionBeginFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "db_transaction_begin_failures_total",
+	Help:      "Number of database transactions that could not be started.",
+})
+
+// TenantRequests counts the requests of each tenant if multi-tenancy is enabled.
+var TenantRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "tenant_requests_total",
+	Help:      "Number of requests by tenant.",
+}, []string{"tenant"})
+
+// TenantCacheRequests counts the cache lookups of each tenant by result (hit or miss).
+var TenantCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "tenant_cache_requests_total",
+	Help:      "Number of cache lookups by cache, tenant and result (hit or miss).",
+}, []string{"cache", "tenant", "result"})
+
+// Source is implemented by backends that expose metrics of their own, e.g. pool statistics.
+type Source interface {
+	RegisterMetrics(reg prometheus.Registerer) error
+}
+
+// Register registers the metrics of backend with the default registry if it is a Source.
+func Register(backend any) error {
+	if s, ok := backend.(Source); ok {
+		return s.RegisterMetrics(prometheus.DefaultRegisterer)
+	}
+	return nil
+}
+
+// Handler serves the metrics of the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware records the duration of each request. Requests are labeled with the chi route
+// pattern rather than the path, so ids in the path do not create new series.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		requestDuration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RegisterPool registers the statistics of pool and of db, the database/sql handle opened on
+// top of it, under the given database name.
+func RegisterPool(reg prometheus.Registerer, name string, pool *pgxpool.Pool, db *sql.DB) error {
+	if err := reg.Register(newPoolCollector(name, pool)); err != nil {
+		return err
+	}
+	return reg.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterCache registers the counters of a cache under the given cache name.
+func RegisterCache(reg prometheus.Registerer, name string, stats func() cache.Stats) error {
+	return reg.Register(&cacheCollector{name: name, stats: stats})
+}
+
+type poolCollector struct {
+	pool  *pgxpool.Pool
+	descs map[string]*prometheus.Desc
+}
+
+func newPoolCollector(name string, pool *pgxpool.Pool) *poolCollector {
+	labels := prometheus.Labels{"db_name": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgxpool", metric), help, nil, labels)
+	}
+	return &poolCollector{pool: pool, descs: map[string]*prometheus.Desc{
+		"acquired":    desc("acquired_connections", "Number of connections currently in use."),
+		"idle":        desc("idle_connections", "Number of idle connections."),
+		"total":       desc("total_connections", "Number of open connections."),
+		"max":         desc("max_connections", "Maximum size of the pool."),
+		"acquires":    desc("acquires_total", "Number of successful connection acquisitions."),
+		"wait":        desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		"empty":       desc("empty_acquires_total", "Number of acquisitions that had to wait for a connection."),
+		"canceled":    desc("canceled_acquires_total", "Number of acquisitions canceled by their context."),
+		"newConns":    desc("new_connections_total", "Number of connections opened."),
+		"maxLifetime": desc("max_lifetime_destroys_total", "Number of connections closed because they reached their maximum lifetime."),
+	}}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range c.descs {
+		ch <- d
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	gauge := func(name string, v float64) {
+		ch <- prometheus.MustNewConstMetric(c.descs[name], prometheus.GaugeValue, v)
+	}
+	counter := func(name string, v float64) {
+		ch <- prometheus.MustNewConstMetric(c.descs[name], prometheus.CounterValue, v)
+	}
+	gauge("acquired", float64(s.AcquiredConns()))
+	gauge("idle", float64(s.IdleConns()))
+	gauge("total", float64(s.TotalConns()))
+	gauge("max", float64(s.MaxConns()))
+	counter("acquires", float64(s.AcquireCount()))
+	counter("wait", s.AcquireDuration().Seconds())
+	counter("empty", float64(s.EmptyAcquireCount()))
+	counter("canceled", float64(s.CanceledAcquireCount()))
+	counter("newConns", float64(s.NewConnsCount()))
+	counter("maxLifetime", float64(s.MaxLifetimeDestroyCount()))
+}
+
+type cacheCollector struct {
+	name  string
+	stats func() cache.Stats
+}
+
+var (
+	cacheRequestsDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", "requests_total"),
+		"Number of cache lookups by result (hit or miss).", []string{"cache", "result"}, nil)
+	cacheEvictionsDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", "evictions_total"),
+		"Number of entries evicted to stay within the cache limits.", []string{"cache"}, nil)
+	cacheEntriesDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", "entries"),
+		"Number of cached entries.", []string{"cache"}, nil)
+	cacheBytesDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", "bytes"),
+		"Approximate size of the cached entries.", []string{"cache"}, nil)
+)
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheRequestsDesc
+	ch <- cacheEvictionsDesc
+	ch <- cacheEntriesDesc
+	ch <- cacheBytesDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+	ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(s.Hits), c.name, "hit")
+	ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(s.Misses), c.name, "miss")
+	ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(s.Evictions), c.name)
+	ch <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(s.Entries), c.name)
+	ch <- prometheus.MustNewConstMetric(cacheBytesDesc, prometheus.GaugeValue, float64(s.Bytes), c.name)
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/cache"
+)
+
+func TestMiddlewareLabelsRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/submodels/{submodelIdentifier}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/submodels/abc", nil))
+
+	if n := testutil.CollectAndCount(requestDuration); n != 1 {
+		t.Fatalf("expected one series, got %d", n)
+	}
+	if _, err := requestDuration.GetMetricWithLabelValues(http.MethodGet, "/submodels/{submodelIdentifier}", "404"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheCollector(t *testing.T) {
+	c := cache.New[string, int](cache.Options{}, nil)
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("b")
+
+	reg := prometheus.NewRegistry()
+	if err := RegisterCache(reg, "submodel", c.Stats); err != nil {
+		t.Fatal(err)
+	}
+	expected := `
+# HELP basyx_cache_requests_total Number of cache lookups by result (hit or miss).
+# TYPE basyx_cache_requests_total counter
+basyx_cache_requests_total{cache="submodel",result="hit"} 1
+basyx_cache_requests_total{cache="submodel",result="miss"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "basyx_cache_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadOrdersAndPairsMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0010_add_index.up.sql":    {Data: []byte("CREATE INDEX i ON t (c);")},
+		"m/0002_add_column.up.sql":   {Data: []byte("ALTER TABLE t ADD COLUMN c TEXT;")},
+		"m/0002_add_column.down.sql": {Data: []byte("ALTER TABLE t DROP COLUMN c;")},
+		"m/0001_initial.up.sql":      {Data: []byte("CREATE TABLE t (id INT);")},
+		"m/README.md":                {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("expected 3 migrations, got %d", len(migrations))
+	}
+	for i, want := range []int{1, 2, 10} {
+		if migrations[i].Version != want {
+			t.Fatalf("migration %d: expected version %d, got %d", i, want, migrations[i].Version)
+		}
+	}
+	if migrations[1].Name != "add_column" || migrations[1].Down == "" {
+		t.Fatalf("down file was not paired with its up file: %+v", migrations[1])
+	}
+	if migrations[2].Down != "" {
+		t.Fatalf("expected no down script for version 10")
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"no direction":    {"m/0001_initial.sql": {}},
+		"no version":      {"m/initial.up.sql": {}},
+		"zero version":    {"m/0000_initial.up.sql": {}},
+		"missing up file": {"m/0001_initial.down.sql": {Data: []byte("DROP TABLE t;")}},
+		"name mismatch": {
+			"m/0001_initial.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+			"m/0001_other.down.sql": {Data: []byte("DROP TABLE t;")},
+		},
+	}
+	for name, fsys := range cases {
+		if _, err := Load(fsys, "m"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	version, name, direction, err := parseFileName("0003_asset_link_value_trgm.down.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 3 || name != "asset_link_value_trgm" || direction != "down" {
+		t.Fatalf("unexpected result: %d %q %q", version, name, direction)
+	}
+	if _, _, _, err := parseFileName("0003-trgm.up.sql"); err == nil || !strings.Contains(err.Error(), "<version>_<name>") {
+		t.Fatalf("expected naming error, got %v", err)
+	}
+}
@@ -15,6 +15,10 @@ type AssetLink struct {
 	Name string `json:"name" validate:"regexp=^([\\\\x09\\\\x0a\\\\x0d\\\\x20-\\\\ud7ff\\\\ue000-\\\\ufffd]|\\\\ud800[\\\\udc00-\\\\udfff]|[\\\\ud801-\\\\udbfe][\\\\udc00-\\\\udfff]|\\\\udbff[\\\\udc00-\\\\udfff])*$"`
 
 	Value string `json:"value" validate:"regexp=^([\\\\x09\\\\x0a\\\\x0d\\\\x20-\\\\ud7ff\\\\ue000-\\\\ufffd]|\\\\ud800[\\\\udc00-\\\\udfff]|[\\\\ud801-\\\\udbfe][\\\\udc00-\\\\udfff]|\\\\udbff[\\\\udc00-\\\\udfff])*$"`
+
+	// ExternalSubjectId optionally scopes the link to the issuing party. When set,
+	// only asset links with an identical externalSubjectId match.
+	ExternalSubjectId *Reference `json:"externalSubjectId,omitempty"`
 }
 
 // AssertAssetLinkRequired checks if the required fields are not zero-ed
@@ -34,5 +38,10 @@ func AssertAssetLinkRequired(obj AssetLink) error {
 
 // AssertAssetLinkConstraints checks if the values respects the defined constraints
 func AssertAssetLinkConstraints(obj AssetLink) error {
+	if obj.ExternalSubjectId != nil {
+		if err := AssertReferenceConstraints(*obj.ExternalSubjectId); err != nil {
+			return err
+		}
+	}
 	return nil
 }
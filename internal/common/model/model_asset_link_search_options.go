@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+)
+
+// AssetLinkMatchMode defines how the value of an AssetLink is compared against stored asset links.
+type AssetLinkMatchMode string
+
+// List of AssetLinkMatchMode
+const (
+	ASSETLINKMATCHMODE_EXACT    AssetLinkMatchMode = "exact"
+	ASSETLINKMATCHMODE_PREFIX   AssetLinkMatchMode = "prefix"
+	ASSETLINKMATCHMODE_CONTAINS AssetLinkMatchMode = "contains"
+)
+
+// AllowedAssetLinkMatchModeEnumValues is all the allowed values of AssetLinkMatchMode enum
+var AllowedAssetLinkMatchModeEnumValues = []AssetLinkMatchMode{
+	"exact",
+	"prefix",
+	"contains",
+}
+
+// validAssetLinkMatchModeEnumValues provides a map of AssetLinkMatchModes for fast verification of use input
+var validAssetLinkMatchModeEnumValues = map[AssetLinkMatchMode]struct{}{
+	"exact":    {},
+	"prefix":   {},
+	"contains": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v AssetLinkMatchMode) IsValid() bool {
+	_, ok := validAssetLinkMatchModeEnumValues[v]
+	return ok
+}
+
+// NewAssetLinkMatchModeFromValue returns a valid AssetLinkMatchMode
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewAssetLinkMatchModeFromValue(v string) (AssetLinkMatchMode, error) {
+	ev := AssetLinkMatchMode(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for AssetLinkMatchMode: valid values are %v", v, AllowedAssetLinkMatchModeEnumValues)
+}
+
+// AssetLinkSearchOptions controls how SearchAllAssetAdministrationShellIdsByAssetLink matches asset links.
+// The zero value keeps the specified behaviour: exact, case-sensitive values and all links must match.
+type AssetLinkSearchOptions struct {
+	// MatchMode selects exact, prefix or substring comparison of the value.
+	MatchMode AssetLinkMatchMode
+
+	// CaseInsensitive compares values ignoring case. Names are always compared exactly.
+	CaseInsensitive bool
+
+	// MatchAny returns shells matching at least one of the links instead of all of them.
+	MatchAny bool
+}
@@ -9,6 +9,11 @@
 
 package model
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type Entity struct {
 	Extensions []Extension `json:"extensions,omitempty"`
 
@@ -39,6 +44,37 @@ type Entity struct {
 	SpecificAssetIds []SpecificAssetId `json:"specificAssetIds,omitempty"`
 }
 
+// UnmarshalJSON implements custom JSON unmarshaling for Entity
+func (e *Entity) UnmarshalJSON(data []byte) error {
+	// Create a temporary struct with the same fields but Statements as []json.RawMessage
+	type Alias Entity
+	aux := &struct {
+		Statements []json.RawMessage `json:"statements,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	// Unmarshal into the temporary struct
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	// Now process the Statements field manually
+	if aux.Statements != nil {
+		e.Statements = make([]SubmodelElement, len(aux.Statements))
+		for i, rawElement := range aux.Statements {
+			element, err := UnmarshalSubmodelElement(rawElement)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal statement at index %d: %w", i, err)
+			}
+			e.Statements[i] = element
+		}
+	}
+
+	return nil
+}
+
 // Getters
 func (a Entity) GetExtensions() []Extension {
 	return a.Extensions
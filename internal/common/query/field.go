@@ -0,0 +1,104 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Scope is the object a field belongs to.
+type Scope string
+
+const (
+	ScopeSubmodel Scope = "$sm"
+	ScopeElement  Scope = "$sme"
+)
+
+// Attribute is the attribute a field refers to.
+type Attribute string
+
+const (
+	AttrID         Attribute = "id"
+	AttrIdShort    Attribute = "idShort"
+	AttrValue      Attribute = "value"
+	AttrValueType  Attribute = "valueType"
+	AttrSemanticID Attribute = "semanticId"
+)
+
+// Part is the part of a semanticId a field refers to.
+type Part string
+
+const (
+	PartKeyValue      Part = "value"
+	PartKeyType       Part = "type"
+	PartReferenceType Part = "referenceType"
+)
+
+// AnyKey is the Key of semanticId fields that match any key, e.g. semanticId.keys[].value.
+const AnyKey = -1
+
+// Field is a parsed $field, e.g. "$sme.Sensors.Temperature#value".
+type Field struct {
+	Scope Scope
+	// Path is the idShortPath of the element, "" for any element of the submodel.
+	Path      string
+	Attribute Attribute
+	// Part and Key address the semanticId: "$sm#semanticId" is the value of the first key.
+	Part Part
+	Key  int
+}
+
+var (
+	idShortPath = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(\[\d+\])*(\.[A-Za-z][A-Za-z0-9_-]*(\[\d+\])*)*$`)
+	semanticKey = regexp.MustCompile(`^semanticId\.keys\[(\d*)\]\.(type|value)$`)
+)
+
+// ParseField parses the field references of the Submodel Repository:
+//
+//	$sm#id, $sm#idShort, $sm#semanticId, $sm#semanticId.type, $sm#semanticId.keys[0].value
+//	$sme.<idShortPath>#idShort, #value, #valueType and #semanticId... as for submodels
+//	$sme#value and the other attributes without a path match any element
+func ParseField(s string) (Field, error) {
+	ref, attr, ok := strings.Cut(s, "#")
+	if !ok {
+		return Field{}, fmt.Errorf("field %q has no attribute after #", s)
+	}
+	var f Field
+	switch {
+	case ref == string(ScopeSubmodel):
+		f.Scope = ScopeSubmodel
+	case ref == string(ScopeElement):
+		f.Scope = ScopeElement
+	case strings.HasPrefix(ref, string(ScopeElement)+"."):
+		f.Scope = ScopeElement
+		f.Path = strings.TrimPrefix(ref, string(ScopeElement)+".")
+		if !idShortPath.MatchString(f.Path) {
+			return Field{}, fmt.Errorf("field %q has an invalid idShortPath", s)
+		}
+	default:
+		return Field{}, fmt.Errorf("field %q is not supported: only $sm and $sme fields refer to submodels", s)
+	}
+
+	switch {
+	case attr == string(AttrSemanticID):
+		f.Attribute, f.Part, f.Key = AttrSemanticID, PartKeyValue, 0
+	case attr == "semanticId.type":
+		f.Attribute, f.Part = AttrSemanticID, PartReferenceType
+	case semanticKey.MatchString(attr):
+		m := semanticKey.FindStringSubmatch(attr)
+		f.Attribute, f.Part, f.Key = AttrSemanticID, Part(m[2]), AnyKey
+		if m[1] != "" {
+			f.Key, _ = strconv.Atoi(m[1])
+		}
+	case attr == string(AttrIdShort):
+		f.Attribute = AttrIdShort
+	case attr == string(AttrID) && f.Scope == ScopeSubmodel:
+		f.Attribute = AttrID
+	case (attr == string(AttrValue) || attr == string(AttrValueType)) && f.Scope == ScopeElement:
+		f.Attribute = Attribute(attr)
+	default:
+		return Field{}, fmt.Errorf("field %q has an unsupported attribute %q", s, attr)
+	}
+	return f, nil
+}
@@ -0,0 +1,271 @@
+// Package query parses the JSON grammar of the AAS Query Language (IDTA-01002 Part 2) into
+// conditions that the backends compile into their own query language. Example:
+//
+//	{"$condition": {"$and": [
+//	    {"$eq": [{"$field": "$sm#semanticId"}, {"$strVal": "https://admin-shell.io/idta/nameplate/3/0"}]},
+//	    {"$gt": [{"$field": "$sme.MaxTemperature#value"}, {"$numVal": 80}]}
+//	]}}
+//
+// Supported are the logical operators $and, $or and $not, the comparisons $eq, $ne, $gt, $ge,
+// $lt and $le, the string operators $contains, $starts-with, $ends-with and $regex and the
+// values $field, $strVal, $numVal, $dateTimeVal, $timeVal and $boolean. Casts and $match are
+// rejected. $regex patterns are POSIX regular expressions as in PostgreSQL, which the backend
+// checks when it runs them.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+// Query is the body of the query endpoints.
+type Query struct {
+	Select    string             `json:"$select,omitempty"`
+	Condition *LogicalExpression `json:"$condition"`
+}
+
+// LogicalExpression is a condition. Exactly one of its operators is set.
+type LogicalExpression struct {
+	And []LogicalExpression `json:"$and,omitempty"`
+	Or  []LogicalExpression `json:"$or,omitempty"`
+	Not *LogicalExpression  `json:"$not,omitempty"`
+
+	Eq []Value `json:"$eq,omitempty"`
+	Ne []Value `json:"$ne,omitempty"`
+	Gt []Value `json:"$gt,omitempty"`
+	Ge []Value `json:"$ge,omitempty"`
+	Lt []Value `json:"$lt,omitempty"`
+	Le []Value `json:"$le,omitempty"`
+
+	Contains   []Value `json:"$contains,omitempty"`
+	StartsWith []Value `json:"$starts-with,omitempty"`
+	EndsWith   []Value `json:"$ends-with,omitempty"`
+	Regex      []Value `json:"$regex,omitempty"`
+
+	Boolean *bool `json:"$boolean,omitempty"`
+}
+
+// Value is an operand. Exactly one of its fields is set.
+type Value struct {
+	Field       *string      `json:"$field,omitempty"`
+	StrVal      *string      `json:"$strVal,omitempty"`
+	NumVal      *json.Number `json:"$numVal,omitempty"`
+	DateTimeVal *string      `json:"$dateTimeVal,omitempty"`
+	TimeVal     *string      `json:"$timeVal,omitempty"`
+	Boolean     *bool        `json:"$boolean,omitempty"`
+}
+
+// Operator names the operator of a comparison or string expression.
+type Operator string
+
+const (
+	OpEq         Operator = "$eq"
+	OpNe         Operator = "$ne"
+	OpGt         Operator = "$gt"
+	OpGe         Operator = "$ge"
+	OpLt         Operator = "$lt"
+	OpLe         Operator = "$le"
+	OpContains   Operator = "$contains"
+	OpStartsWith Operator = "$starts-with"
+	OpEndsWith   Operator = "$ends-with"
+	OpRegex      Operator = "$regex"
+)
+
+// IsString reports whether op only applies to strings.
+func (op Operator) IsString() bool {
+	switch op {
+	case OpContains, OpStartsWith, OpEndsWith, OpRegex:
+		return true
+	}
+	return false
+}
+
+// Mirror returns the operator that gives the same result with swapped operands.
+func (op Operator) Mirror() Operator {
+	switch op {
+	case OpGt:
+		return OpLt
+	case OpGe:
+		return OpLe
+	case OpLt:
+		return OpGt
+	case OpLe:
+		return OpGe
+	}
+	return op
+}
+
+type comparison struct {
+	op       Operator
+	operands []Value
+}
+
+func (e LogicalExpression) comparisons() []comparison {
+	var set []comparison
+	for _, c := range []comparison{
+		{OpEq, e.Eq}, {OpNe, e.Ne}, {OpGt, e.Gt}, {OpGe, e.Ge}, {OpLt, e.Lt}, {OpLe, e.Le},
+		{OpContains, e.Contains}, {OpStartsWith, e.StartsWith}, {OpEndsWith, e.EndsWith}, {OpRegex, e.Regex},
+	} {
+		if c.operands != nil {
+			set = append(set, c)
+		}
+	}
+	return set
+}
+
+// Comparison returns the operator and the operands of a comparison or string expression,
+// false if e is a logical operator or a constant.
+func (e LogicalExpression) Comparison() (Operator, []Value, bool) {
+	if set := e.comparisons(); len(set) > 0 {
+		return set[0].op, set[0].operands, true
+	}
+	return "", nil, false
+}
+
+// Parse reads a query and validates it. Syntax errors and unsupported operators are
+// reported as bad requests.
+func Parse(r io.Reader) (Query, error) {
+	var q Query
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return q, err
+	}
+	// the schema of the specification wraps the query in {"Query": ...}
+	var wrapped struct {
+		Query *json.RawMessage `json:"Query"`
+	}
+	if json.Unmarshal(body, &wrapped) == nil && wrapped.Query != nil {
+		body = *wrapped.Query
+	}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&q); err != nil {
+		return q, common.NewErrBadRequest("invalid query: " + err.Error())
+	}
+	if err := q.Validate(); err != nil {
+		return q, common.NewErrBadRequest("invalid query: " + err.Error())
+	}
+	return q, nil
+}
+
+// Validate checks that q has a condition, that every expression and value has exactly one
+// operator and that fields and literals are well-formed.
+func (q Query) Validate() error {
+	if q.Select != "" {
+		return errors.New("$select is not supported, the query returns whole submodels")
+	}
+	if q.Condition == nil {
+		return errors.New("$condition is required")
+	}
+	return q.Condition.Validate()
+}
+
+// Validate checks e and its operands recursively.
+func (e LogicalExpression) Validate() error {
+	set := 0
+	if e.And != nil {
+		set++
+	}
+	if e.Or != nil {
+		set++
+	}
+	if e.Not != nil {
+		set++
+	}
+	if e.Boolean != nil {
+		set++
+	}
+	set += len(e.comparisons())
+	op, operands, isComparison := e.Comparison()
+	if set != 1 {
+		return errors.New("every expression needs exactly one operator")
+	}
+
+	switch {
+	case e.And != nil || e.Or != nil:
+		children := e.And
+		if e.Or != nil {
+			children = e.Or
+		}
+		if len(children) < 2 {
+			return errors.New("$and and $or need at least two expressions")
+		}
+		for _, c := range children {
+			if err := c.Validate(); err != nil {
+				return err
+			}
+		}
+	case e.Not != nil:
+		return e.Not.Validate()
+	case isComparison:
+		if len(operands) != 2 {
+			return fmt.Errorf("%s needs two operands", op)
+		}
+		for _, v := range operands {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that v has exactly one well-formed value.
+func (v Value) Validate() error {
+	set := 0
+	for _, isSet := range []bool{v.Field != nil, v.StrVal != nil, v.NumVal != nil, v.DateTimeVal != nil, v.TimeVal != nil, v.Boolean != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("every value needs exactly one of $field, $strVal, $numVal, $dateTimeVal, $timeVal or $boolean")
+	}
+	switch {
+	case v.Field != nil:
+		_, err := ParseField(*v.Field)
+		return err
+	case v.NumVal != nil:
+		if _, err := strconv.ParseFloat(v.NumVal.String(), 64); err != nil {
+			return fmt.Errorf("$numVal %q is not a number", v.NumVal.String())
+		}
+	case v.DateTimeVal != nil:
+		if _, err := time.Parse(time.RFC3339, *v.DateTimeVal); err != nil {
+			return fmt.Errorf("$dateTimeVal %q is not an RFC 3339 date-time", *v.DateTimeVal)
+		}
+	case v.TimeVal != nil:
+		if _, err := time.Parse("15:04:05", strings.SplitN(*v.TimeVal, ".", 2)[0]); err != nil {
+			return fmt.Errorf("$timeVal %q is not a time of day hh:mm:ss", *v.TimeVal)
+		}
+	}
+	return nil
+}
+
+// Fields returns the fields that e refers to, in order of appearance.
+func (e LogicalExpression) Fields() []Field {
+	var fields []Field
+	for _, c := range append(append([]LogicalExpression{}, e.And...), e.Or...) {
+		fields = append(fields, c.Fields()...)
+	}
+	if e.Not != nil {
+		fields = append(fields, e.Not.Fields()...)
+	}
+	if _, operands, ok := e.Comparison(); ok {
+		for _, v := range operands {
+			if v.Field != nil {
+				if f, err := ParseField(*v.Field); err == nil {
+					fields = append(fields, f)
+				}
+			}
+		}
+	}
+	return fields
+}
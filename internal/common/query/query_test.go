@@ -0,0 +1,74 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+func TestParse(t *testing.T) {
+	for _, body := range []string{
+		`{"$condition":{"$and":[{"$eq":[{"$field":"$sm#semanticId"},{"$strVal":"urn:x"}]},{"$gt":[{"$field":"$sme.MaxTemperature#value"},{"$numVal":80}]}]}}`,
+		`{"Query":{"$condition":{"$not":{"$starts-with":[{"$field":"$sm#idShort"},{"$strVal":"Tech"}]}}}}`,
+		`{"$condition":{"$le":[{"$dateTimeVal":"2025-01-01T00:00:00Z"},{"$field":"$sme.Built#value"}]}}`,
+		// back references and word boundaries are valid in PostgreSQL, but not in Go's RE2
+		`{"$condition":{"$regex":[{"$field":"$sm#idShort"},{"$strVal":"^(a)\\1\\y"}]}}`,
+	} {
+		if _, err := Parse(strings.NewReader(body)); err != nil {
+			t.Errorf("%s: %v", body, err)
+		}
+	}
+}
+
+func TestParseRejectsInvalidQueries(t *testing.T) {
+	for _, body := range []string{
+		`{}`,
+		`{"$select":"id","$condition":{"$boolean":true}}`,
+		`{"$condition":{"$match":[]}}`,
+		`{"$condition":{"$eq":[{"$field":"$sm#idShort"}]}}`,
+		`{"$condition":{"$and":[{"$boolean":true}]}}`,
+		`{"$condition":{"$eq":[{"$field":"$sm#idShort"},{"$strVal":"a"}],"$ne":[{"$field":"$sm#idShort"},{"$strVal":"b"}]}}`,
+		`{"$condition":{"$eq":[{"$field":"$aas#idShort"},{"$strVal":"a"}]}}`,
+		`{"$condition":{"$eq":[{"$field":"$sme.a..b#value"},{"$strVal":"a"}]}}`,
+		`{"$condition":{"$eq":[{"$field":"$sme.a#value"},{"$numVal":"x"}]}}`,
+		`{"$condition":{"$eq":[{"$field":"$sme.a#value"},{"$strVal":"a","$numVal":1}]}}`,
+	} {
+		if _, err := Parse(strings.NewReader(body)); !common.IsErrBadRequest(err) {
+			t.Errorf("%s: want a bad request, got %v", body, err)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	for s, want := range map[string]Field{
+		"$sm#id":                             {Scope: ScopeSubmodel, Attribute: AttrID},
+		"$sm#semanticId":                     {Scope: ScopeSubmodel, Attribute: AttrSemanticID, Part: PartKeyValue},
+		"$sm#semanticId.type":                {Scope: ScopeSubmodel, Attribute: AttrSemanticID, Part: PartReferenceType},
+		"$sm#semanticId.keys[2].type":        {Scope: ScopeSubmodel, Attribute: AttrSemanticID, Part: PartKeyType, Key: 2},
+		"$sme#semanticId.keys[].value":       {Scope: ScopeElement, Attribute: AttrSemanticID, Part: PartKeyValue, Key: AnyKey},
+		"$sme.Sensors[1].Temperature#value":  {Scope: ScopeElement, Path: "Sensors[1].Temperature", Attribute: AttrValue},
+		"$sme.Nameplate.Serial-No#valueType": {Scope: ScopeElement, Path: "Nameplate.Serial-No", Attribute: AttrValueType},
+	} {
+		got, err := ParseField(s)
+		if err != nil || got != want {
+			t.Errorf("%s: got %+v, %v", s, got, err)
+		}
+	}
+	for _, s := range []string{"$sm#value", "$sme.a#id", "$sm", "$sme.List[]#value"} {
+		if _, err := ParseField(s); err == nil {
+			t.Errorf("%s: want an error", s)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	q, err := Parse(strings.NewReader(`{"$condition":{"$or":[{"$eq":[{"$field":"$sm#idShort"},{"$strVal":"a"}]},{"$not":{"$lt":[{"$numVal":1},{"$field":"$sme.A.B#value"}]}}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := q.Condition.Fields()
+	if len(fields) != 2 || fields[0].Attribute != AttrIdShort || fields[1].Path != "A.B" {
+		t.Errorf("got %+v", fields)
+	}
+}
@@ -0,0 +1,121 @@
+// Package search describes the full-text search over the texts of submodels: string
+// values of properties, multi-language property values, file paths, idShortPaths,
+// descriptions and display names. The backends find the texts that contain the search text,
+// case-insensitively, and rank them by similarity.
+package search
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+// Field is the kind of text a hit was found in.
+type Field string
+
+const (
+	FieldValue       Field = "value"
+	FieldFile        Field = "file"
+	FieldIdShortPath Field = "idShortPath"
+	FieldDescription Field = "description"
+	FieldDisplayName Field = "displayName"
+)
+
+// MinLength is the shortest search text. Shorter texts have no trigrams, so the indexes
+// cannot narrow the search down.
+const MinLength = 3
+
+// snippetRadius is the number of characters a snippet shows around the match.
+const snippetRadius = 40
+
+// Hit is a text that contains the search text.
+type Hit struct {
+	SubmodelID string `json:"submodelId"`
+	// IdShortPath is the element the text belongs to, "" for the submodel itself.
+	IdShortPath string `json:"idShortPath,omitempty"`
+	Field       Field  `json:"field"`
+	// Language is set for texts of multi-language properties, descriptions and display names.
+	Language string  `json:"language,omitempty"`
+	Snippet  string  `json:"snippet"`
+	Score    float64 `json:"score"`
+}
+
+// Request is a search for Text. Languages restrict the hits in language-tagged texts; hits in
+// values without a language are always returned. Pages are addressed by the opaque Cursor.
+type Request struct {
+	Text      string
+	Languages []string
+	Limit     int32
+	Cursor    string
+}
+
+// Validate reports a text that is too short and a cursor that was not returned by a search
+// as bad requests.
+func (r Request) Validate() error {
+	if utf8.RuneCountInString(strings.TrimSpace(r.Text)) < MinLength {
+		return common.NewErrBadRequest("the search text needs at least " + strconv.Itoa(MinLength) + " characters")
+	}
+	if _, err := r.Offset(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Offset returns the number of hits on the previous pages.
+func (r Request) Offset() (int, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(r.Cursor)
+	if err != nil || offset < 0 {
+		return 0, common.NewErrBadRequest("invalid cursor '" + r.Cursor + "'")
+	}
+	return offset, nil
+}
+
+// NextCursor is the cursor of the page after the one at offset with limit hits.
+func NextCursor(offset int, limit int32) string {
+	return strconv.Itoa(offset + int(limit))
+}
+
+// Snippet returns the part of text around the first case-insensitive occurrence of match,
+// with ellipses where text is cut.
+func Snippet(text, match string) string {
+	runes := []rune(text)
+	start := indexFold(runes, []rune(match))
+	end := start + utf8.RuneCountInString(match)
+	if start < 0 {
+		start, end = 0, 0
+	}
+	from := max(start-snippetRadius, 0)
+	to := min(end+snippetRadius, len(runes))
+	snippet := string(runes[from:to])
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// indexFold returns the rune index of the first case-insensitive occurrence of sub in s, -1
+// if there is none.
+func indexFold(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		found := true
+		for j, r := range sub {
+			if unicode.ToLower(s[i+j]) != unicode.ToLower(r) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+func TestRequestValidate(t *testing.T) {
+	if err := (Request{Text: "pump", Cursor: "20"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []Request{{Text: " ab "}, {Text: "pump", Cursor: "x"}, {Text: "pump", Cursor: "-1"}} {
+		if err := r.Validate(); !common.IsErrBadRequest(err) {
+			t.Errorf("%+v: want a bad request, got %v", r, err)
+		}
+	}
+	if got := NextCursor(20, 10); got != "30" {
+		t.Errorf("NextCursor = %q", got)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	if got := Snippet("Centrifugal PUMP", "pump"); got != "Centrifugal PUMP" {
+		t.Errorf("short text: %q", got)
+	}
+
+	text := strings.Repeat("a", 100) + "Übergröße" + strings.Repeat("b", 100)
+	got := Snippet(text, "übergröße")
+	want := "…" + strings.Repeat("a", snippetRadius) + "Übergröße" + strings.Repeat("b", snippetRadius) + "…"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// without an exact match the snippet is the start of the text
+	if got := Snippet(strings.Repeat("c", 100), "xyz"); got != strings.Repeat("c", snippetRadius)+"…" {
+		t.Errorf("no match: %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+// Package tenant isolates the data of tenants that share one deployment. Every tenant has its
+// own PostgreSQL schema, tenant_<id>, with a full copy of the tables; the tenant of a request is
+// taken from a header or a claim of its access token, and every connection handed out by the
+// pool is switched to the schema of the tenant in the context that acquires it.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+)
+
+// DefaultHeader carries the tenant if neither Config.Header nor Config.Claim is set.
+const DefaultHeader = "X-Tenant-ID"
+
+// Config enables multi-tenancy. With authentication the tenant of a request is read from the
+// Claim of its access token, without it from Header. Only the listed Tenants are accepted;
+// their schemas are created and migrated at startup.
+type Config struct {
+	Enabled bool     `yaml:"enabled"`
+	Header  string   `yaml:"header"`
+	Claim   string   `yaml:"claim"`
+	Tenants []string `yaml:"tenants"`
+}
+
+// validID keeps tenant ids usable as part of a schema name without quoting.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_]{0,47}$`)
+
+// Validate checks that tenant ids are lowercase letters, digits and underscores.
+func Validate(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("invalid tenant id '%s': expected up to 48 lowercase letters, digits and underscores", id)
+	}
+	return nil
+}
+
+// Schema returns the name of the database schema of a tenant.
+func Schema(id string) string {
+	return "tenant_" + id
+}
+
+type tenantKey struct{}
+
+// FromContext returns the tenant of a request, "" outside of a tenant.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
+
+// WithTenant returns a copy of ctx in which database work happens in the schema of id.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// Key scopes a cache key to the tenant of ctx, so tenants never see each other's entries.
+func Key(ctx context.Context, key string) string {
+	if id := FromContext(ctx); id != "" {
+		return id + "\x1f" + key
+	}
+	return key
+}
+
+// Resolver determines the tenant of requests.
+type Resolver struct {
+	header  string
+	claim   string
+	tenants map[string]bool
+}
+
+// New validates cfg for a deployment that authenticates its requests if authenticated is set.
+// It returns nil if multi-tenancy is disabled.
+func New(cfg Config, authenticated bool) (*Resolver, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if len(cfg.Tenants) == 0 {
+		return nil, fmt.Errorf("tenancy.tenants is required if multi-tenancy is enabled")
+	}
+	// A header would let every authenticated caller pick any tenant, and a claim of an
+	// unverified token could not be trusted.
+	if authenticated && cfg.Claim == "" {
+		return nil, errors.New("tenancy.claim is required with auth.enabled: the tenant must be bound to the access token")
+	}
+	if !authenticated && cfg.Claim != "" {
+		return nil, errors.New("tenancy.claim requires auth.enabled: without verified tokens the claim could not be trusted")
+	}
+	r := &Resolver{header: cfg.Header, claim: cfg.Claim, tenants: make(map[string]bool, len(cfg.Tenants))}
+	if r.header == "" && r.claim == "" {
+		r.header = DefaultHeader
+	}
+	for _, id := range cfg.Tenants {
+		if err := Validate(id); err != nil {
+			return nil, err
+		}
+		r.tenants[id] = true
+	}
+	return r, nil
+}
+
+// Middleware rejects requests without a tenant with 400 and requests for unknown tenants with
+// 403, and stores the tenant of the others in the request context. It must run after the
+// authentication middleware if the tenant is read from a claim. A nil Resolver lets all
+// requests pass.
+func (t *Resolver) Middleware(next http.Handler) http.Handler {
+	if t == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := t.resolve(r)
+		if id == "" {
+			common.HandleError(w, r, common.NewErrBadRequest("The request does not name a tenant"), nil)
+			return
+		}
+		if !t.tenants[id] {
+			common.HandleError(w, r, common.NewError(common.ErrCodeForbidden, fmt.Sprintf("Unknown tenant '%s'", id), nil), nil)
+			return
+		}
+		metrics.TenantRequests.WithLabelValues(id).Inc()
+		next.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), id)))
+	})
+}
+
+func (t *Resolver) resolve(r *http.Request) string {
+	if t.claim != "" {
+		claims, _ := auth.ClaimsFromContext(r.Context())
+		if values := claims.Strings(t.claim); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return r.Header.Get(t.header)
+}
+
+// ConfigurePool makes every connection acquired from a pool created with cfg use the schema of
+// the tenant of the acquiring context, and the public schema outside of a tenant. This costs a
+// round trip per acquired connection, so it is only set up if multi-tenancy is enabled.
+func ConfigurePool(cfg *pgxpool.Config) {
+	cfg.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		_, err := conn.Exec(ctx, `SELECT set_config('search_path', $1, false)`, searchPath(FromContext(ctx)))
+		return err == nil, err
+	}
+}
+
+// searchPath only holds the schema of the tenant, so a table that is missing there is an error
+// instead of the one of public. Extensions live in public; queries and migrations qualify
+// their functions and operator classes with it.
+func searchPath(id string) string {
+	if id == "" {
+		return "public"
+	}
+	return Schema(id)
+}
+
+// ForEachSchema calls fn for the public schema and then for the schema of every tenant, which
+// is created if it does not exist yet, with a context in which pool works in that schema.
+func ForEachSchema(ctx context.Context, pool *pgxpool.Pool, tenants []string, fn func(ctx context.Context, schema string) error) error {
+	if err := fn(ctx, "public"); err != nil {
+		return err
+	}
+	for _, id := range tenants {
+		if err := Validate(id); err != nil {
+			return err
+		}
+		if _, err := pool.Exec(ctx, `CREATE SCHEMA IF NOT EXISTS `+Schema(id)); err != nil {
+			return fmt.Errorf("failed to create schema of tenant %s: %w", id, err)
+		}
+		if err := fn(WithTenant(ctx, id), Schema(id)); err != nil {
+			return fmt.Errorf("tenant %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ForEachSchemaReversed calls fn for the schema of every tenant, last tenant first, and then
+// for the public schema, the reverse order of ForEachSchema for rolling migrations back.
+// Tenants whose schema does not exist are skipped, they have nothing to roll back.
+func ForEachSchemaReversed(ctx context.Context, pool *pgxpool.Pool, tenants []string, fn func(ctx context.Context, schema string) error) error {
+	for i := len(tenants) - 1; i >= 0; i-- {
+		id := tenants[i]
+		if err := Validate(id); err != nil {
+			return err
+		}
+		var exists bool
+		if err := pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = $1)`, Schema(id)).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to look up schema of tenant %s: %w", id, err)
+		}
+		if !exists {
+			continue
+		}
+		if err := fn(WithTenant(ctx, id), Schema(id)); err != nil {
+			return fmt.Errorf("tenant %s: %w", id, err)
+		}
+	}
+	return fn(ctx, "public")
+}
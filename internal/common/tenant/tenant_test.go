@@ -0,0 +1,104 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+)
+
+func TestMiddleware(t *testing.T) {
+	header, err := New(Config{Enabled: true, Tenants: []string{"acme", "globex"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := New(Config{Enabled: true, Claim: "tenant", Tenants: []string{"acme"}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		resolver *Resolver
+		header   string
+		claims   auth.Claims
+		status   int
+		tenant   string
+	}{
+		{"header", header, "globex", nil, http.StatusOK, "globex"},
+		{"missing header", header, "", nil, http.StatusBadRequest, ""},
+		{"unknown tenant", header, "initech", nil, http.StatusForbidden, ""},
+		{"claim", claim, "", auth.Claims{"tenant": "acme"}, http.StatusOK, "acme"},
+		{"claim ignores the header", claim, "acme", nil, http.StatusBadRequest, ""},
+		{"disabled", nil, "", nil, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := tt.resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/submodels", nil)
+			if tt.header != "" {
+				req.Header.Set(DefaultHeader, tt.header)
+			}
+			if tt.claims != nil {
+				req = req.WithContext(auth.WithClaims(req.Context(), tt.claims))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got != tt.tenant {
+				t.Errorf("expected tenant %q in the context, got %q", tt.tenant, got)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidTenant(t *testing.T) {
+	for _, id := range []string{"Acme", "a-b", "x; DROP SCHEMA public", ""} {
+		if _, err := New(Config{Enabled: true, Tenants: []string{id}}, false); err == nil {
+			t.Errorf("expected an error for tenant %q", id)
+		}
+	}
+	if _, err := New(Config{Enabled: true}, false); err == nil {
+		t.Error("expected an error without tenants")
+	}
+}
+
+func TestNewBindsTheTenantToTheToken(t *testing.T) {
+	header := Config{Enabled: true, Header: DefaultHeader, Tenants: []string{"acme"}}
+	claim := Config{Enabled: true, Header: DefaultHeader, Claim: "tenant", Tenants: []string{"acme"}}
+	if _, err := New(header, true); err == nil || !strings.Contains(err.Error(), "tenancy.claim is required") {
+		t.Errorf("expected the header to be rejected with authentication, got %v", err)
+	}
+	if _, err := New(claim, false); err == nil || !strings.Contains(err.Error(), "requires auth.enabled") {
+		t.Errorf("expected the claim to be rejected without authentication, got %v", err)
+	}
+	if _, err := New(header, false); err != nil {
+		t.Error(err)
+	}
+	if _, err := New(claim, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestKeyAndSearchPath(t *testing.T) {
+	ctx := context.Background()
+	if Key(ctx, "urn:sm") != "urn:sm" || searchPath("") != "public" {
+		t.Error("expected keys and schema to be unchanged outside of a tenant")
+	}
+	ctx = WithTenant(ctx, "acme")
+	if Key(ctx, "urn:sm") == Key(WithTenant(ctx, "globex"), "urn:sm") {
+		t.Error("expected cache keys to differ between tenants")
+	}
+	if got := searchPath(FromContext(ctx)); got != "tenant_acme" {
+		t.Errorf("unexpected search_path %q", got)
+	}
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StatementTimeouts limits how long the database work of a request may take. Routes are
+// addressed by the operation names of the generated controllers, e.g. GetAllSubmodels,
+// case-insensitively. Zero disables the limit.
+type StatementTimeouts struct {
+	Default time.Duration            `yaml:"default"`
+	Routes  map[string]time.Duration `yaml:"routes"`
+}
+
+// For returns the timeout of the route with the given operation name.
+func (t StatementTimeouts) For(route string) time.Duration {
+	for name, timeout := range t.Routes {
+		if strings.EqualFold(name, route) {
+			return timeout
+		}
+	}
+	return t.Default
+}
+
+// WithTimeout cancels the request context after timeout. The backends pass the context to
+// pgx, which cancels the running statement and rolls back the transaction once it is done.
+func WithTimeout(handler http.HandlerFunc, timeout time.Duration) http.HandlerFunc {
+	if timeout <= 0 {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		handler(w, r.WithContext(ctx))
+	}
+}
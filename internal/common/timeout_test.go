@@ -0,0 +1,29 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStatementTimeoutsFor(t *testing.T) {
+	timeouts := StatementTimeouts{Default: 30 * time.Second, Routes: map[string]time.Duration{"importassetlinks": 0}}
+	if got := timeouts.For("ImportAssetLinks"); got != 0 {
+		t.Errorf("expected the route override, got %v", got)
+	}
+	if got := timeouts.For("GetAllAssetLinksById"); got != 30*time.Second {
+		t.Errorf("expected the default, got %v", got)
+	}
+}
+
+func TestWithTimeoutSetsDeadline(t *testing.T) {
+	var hasDeadline bool
+	handler := WithTimeout(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	}, time.Second)
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !hasDeadline {
+		t.Fatal("expected the request context to have a deadline")
+	}
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddlewareContinuesTraceparent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/submodels/{submodelIdentifier}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "test", "service")
+		span.End()
+	})
+	req := httptest.NewRequest(http.MethodGet, "/submodels/abc", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	service, server := spans[0], spans[1]
+	if server.Name() != "GET /submodels/{submodelIdentifier}" {
+		t.Errorf("unexpected server span name %q", server.Name())
+	}
+	if server.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the trace of the traceparent header, got %s", server.SpanContext().TraceID())
+	}
+	if service.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("expected the service span to be a child of the server span")
+	}
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}, "test"); err == nil {
+		t.Fatal("expected an error for an unknown exporter")
+	}
+}
+
+func TestShutdownClosesTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Setup(context.Background(), Config{Exporter: "file", File: path}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := otel.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path); err != nil || len(b) == 0 {
+		t.Fatalf("expected the span in %s: %v", path, err)
+	}
+}
+
+func TestSpanName(t *testing.T) {
+	if got := spanName("\n\t\twith recursive tree AS (...) SELECT 1"); got != "WITH" {
+		t.Errorf("expected WITH, got %q", got)
+	}
+}
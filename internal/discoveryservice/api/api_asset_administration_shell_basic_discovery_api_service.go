@@ -19,22 +19,24 @@ import (
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	persistence_postgresql "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/persistence"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
 )
 
 const (
 	componentName = "DISC"
+	tracerName    = "github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/api"
 )
 
 // AssetAdministrationShellBasicDiscoveryAPIAPIService is a service that implements the logic for the AssetAdministrationShellBasicDiscoveryAPIAPIServicer
 // This service should implement the business logic for every endpoint for the AssetAdministrationShellBasicDiscoveryAPIAPI API.
 // Include any external packages or services that will be required by this service.
 type AssetAdministrationShellBasicDiscoveryAPIAPIService struct {
-	disoveryBackend persistence_postgresql.PostgreSQLDiscoveryDatabase
+	disoveryBackend DiscoveryBackend
 }
 
 // NewAssetAdministrationShellBasicDiscoveryAPIAPIService creates a default api service
-func NewAssetAdministrationShellBasicDiscoveryAPIAPIService(databaseBackend persistence_postgresql.PostgreSQLDiscoveryDatabase) *AssetAdministrationShellBasicDiscoveryAPIAPIService {
+func NewAssetAdministrationShellBasicDiscoveryAPIAPIService(databaseBackend DiscoveryBackend) *AssetAdministrationShellBasicDiscoveryAPIAPIService {
 	return &AssetAdministrationShellBasicDiscoveryAPIAPIService{
 		disoveryBackend: databaseBackend,
 	}
@@ -45,6 +47,7 @@ func NewAssetAdministrationShellBasicDiscoveryAPIAPIService(databaseBackend pers
 func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) GetAllAssetAdministrationShellIdsByAssetLink(ctx context.Context, assetIds []string, limit int32, cursor string) (model.ImplResponse, error) {
 	// Not implemented in this service; keep a proper structured error.
 	return common.NewErrorResponse(
+		ctx,
 		errors.New("GetAllAssetAdministrationShellIdsByAssetLink is deprecated and therefore not implemented"),
 		http.StatusGone,
 		componentName,
@@ -59,7 +62,10 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) SearchAllAssetAdmi
 	limit int32,
 	cursor string,
 	assetLink []model.AssetLink,
+	options model.AssetLinkSearchOptions,
 ) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.SearchAllAssetAdministrationShellIdsByAssetLink")
+	defer span.End()
 
 	// Decode the incoming cursor only if it’s non-empty; empty means "start from the beginning".
 	var internalCursor string
@@ -67,15 +73,17 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) SearchAllAssetAdmi
 		dec, decErr := common.DecodeString(cursor)
 		if decErr != nil {
 			return common.NewErrorResponse(
+				ctx,
 				decErr, http.StatusBadRequest, componentName, "SearchAllAssetAdministrationShellIdsByAssetLink", "BadCursor",
 			), nil
 		}
 		internalCursor = dec
 	}
 
-	ids, nextCursor, err := s.disoveryBackend.SearchAASIDsByAssetLinks(ctx, assetLink, limit, internalCursor)
+	ids, nextCursor, err := s.disoveryBackend.SearchAASIDsByAssetLinks(ctx, assetLink, options, limit, internalCursor)
 	if err != nil {
 		return common.NewErrorResponse(
+			ctx,
 			err, http.StatusInternalServerError, componentName, "SearchAllAssetAdministrationShellIdsByAssetLink", "InternalServerError",
 		), err
 	}
@@ -99,27 +107,33 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) GetAllAssetLinksBy
 	ctx context.Context,
 	aasIdentifier string,
 ) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.GetAllAssetLinksById")
+	defer span.End()
 
 	decoded, decodeErr := common.DecodeString(aasIdentifier)
 	if decodeErr != nil {
 		return common.NewErrorResponse(
+			ctx,
 			decodeErr, http.StatusBadRequest, componentName, "GetAllAssetLinksById", "BadRequest-Decode",
 		), nil
 	}
 
-	links, err := s.disoveryBackend.GetAllAssetLinks(string(decoded))
+	links, err := s.disoveryBackend.GetAllAssetLinks(ctx, string(decoded))
 	if err != nil {
 		switch {
 		case common.IsErrNotFound(err):
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusNotFound, componentName, "GetAllAssetLinksById", "NotFound",
 			), nil
 		case common.IsErrBadRequest(err):
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusBadRequest, componentName, "GetAllAssetLinksById", "BadRequest",
 			), nil
 		default:
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusInternalServerError, componentName, "GetAllAssetLinksById", "Unhandled",
 			), err
 		}
@@ -134,23 +148,28 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) PostAllAssetLinksB
 	aasIdentifier string,
 	specificAssetId []model.SpecificAssetId,
 ) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.PostAllAssetLinksById")
+	defer span.End()
 
 	decodeDiscoveryIdentifier, decodeError := common.DecodeString(aasIdentifier)
 	if decodeError != nil {
 		return common.NewErrorResponse(
+			ctx,
 			decodeError, http.StatusBadRequest, componentName, "PostAllAssetLinksById", "BadRequest-Decode",
 		), nil
 	}
 
-	err := s.disoveryBackend.CreateAllAssetLinks(string(decodeDiscoveryIdentifier), specificAssetId)
+	err := s.disoveryBackend.CreateAllAssetLinks(ctx, string(decodeDiscoveryIdentifier), specificAssetId)
 	if err != nil {
 		switch {
 		case common.IsErrBadRequest(err):
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusBadRequest, componentName, "PostAllAssetLinksById", "BadRequest",
 			), nil
 		default:
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusInternalServerError, componentName, "PostAllAssetLinksById", "Unhandled",
 			), err
 		}
@@ -165,23 +184,28 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) DeleteAllAssetLink
 	ctx context.Context,
 	aasIdentifier string,
 ) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.DeleteAllAssetLinksById")
+	defer span.End()
 
 	decoded, decodeErr := common.DecodeString(aasIdentifier)
 	if decodeErr != nil {
 		return common.NewErrorResponse(
+			ctx,
 			decodeErr, http.StatusBadRequest, componentName, "DeleteAllAssetLinksById", "BadRequest-Decode",
 		), nil
 	}
 
-	err := s.disoveryBackend.DeleteAllAssetLinks(string(decoded))
+	err := s.disoveryBackend.DeleteAllAssetLinks(ctx, string(decoded))
 	if err != nil {
 		switch {
 		case common.IsErrNotFound(err):
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusNotFound, componentName, "DeleteAllAssetLinksById", "NotFound",
 			), nil
 		default:
 			return common.NewErrorResponse(
+				ctx,
 				err, http.StatusInternalServerError, componentName, "DeleteAllAssetLinksById", "InternalServerError",
 			), err
 		}
@@ -189,3 +213,50 @@ func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) DeleteAllAssetLink
 
 	return model.Response(http.StatusNoContent, nil), nil
 }
+
+// ImportAssetLinks - Creates or replaces the asset links of many Asset Administration Shells at once.
+// Not part of the specification. Malformed or rejected records are listed in the returned report.
+func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) ImportAssetLinks(
+	ctx context.Context,
+	dec bulk.Decoder,
+) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.ImportAssetLinks")
+	defer span.End()
+
+	report, err := s.disoveryBackend.ImportAssetLinks(ctx, dec)
+	if err != nil {
+		switch {
+		case common.IsErrBadRequest(err):
+			return common.NewErrorResponse(
+				ctx,
+				err, http.StatusBadRequest, componentName, "ImportAssetLinks", "BadRequest",
+			), nil
+		default:
+			return common.NewErrorResponse(
+				ctx,
+				err, http.StatusInternalServerError, componentName, "ImportAssetLinks", "Unhandled",
+			), err
+		}
+	}
+
+	return model.Response(http.StatusOK, report), nil
+}
+
+// ExportAssetLinks - Streams all Asset Administration Shell ids with their asset links to enc.
+// Not part of the specification. The response body is written by the encoder, not by the returned response.
+func (s *AssetAdministrationShellBasicDiscoveryAPIAPIService) ExportAssetLinks(
+	ctx context.Context,
+	enc bulk.Encoder,
+) (model.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "DiscoveryService.ExportAssetLinks")
+	defer span.End()
+
+	if _, err := s.disoveryBackend.ExportAssetLinks(ctx, enc); err != nil {
+		return common.NewErrorResponse(
+			ctx,
+			err, http.StatusInternalServerError, componentName, "ExportAssetLinks", "Unhandled",
+		), err
+	}
+
+	return model.Response(http.StatusOK, nil), nil
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
+)
+
+// DiscoveryBackend is the storage used by AssetAdministrationShellBasicDiscoveryAPIAPIService.
+//
+// Implementations report failures with the errors of the common package
+// (common.NewErrNotFound, common.NewErrBadRequest, common.NewInternalServerError)
+// so that the service can map them to status codes. They stop when ctx is done;
+// changes of a cancelled call are rolled back.
+type DiscoveryBackend interface {
+	// GetAllAssetLinks returns the asset links of a shell in insertion order.
+	GetAllAssetLinks(ctx context.Context, aasID string) ([]model.SpecificAssetId, error)
+
+	// CreateAllAssetLinks replaces all asset links of a shell, creating the shell if needed.
+	CreateAllAssetLinks(ctx context.Context, aasID string, specificAssetIds []model.SpecificAssetId) error
+
+	// DeleteAllAssetLinks removes a shell with all its asset links.
+	DeleteAllAssetLinks(ctx context.Context, aasID string) error
+
+	// SearchAASIDsByAssetLinks returns up to limit shell ids matching links, ordered by id,
+	// starting at cursor. The second return value is the cursor of the next page, if any.
+	SearchAASIDsByAssetLinks(ctx context.Context, links []model.AssetLink, opts model.AssetLinkSearchOptions, limit int32, cursor string) ([]string, string, error)
+
+	// ImportAssetLinks stores all records of dec, see bulk.Decoder.
+	ImportAssetLinks(ctx context.Context, dec bulk.Decoder) (bulk.Report, error)
+
+	// ExportAssetLinks writes every shell with its asset links to enc, ordered by id.
+	ExportAssetLinks(ctx context.Context, enc bulk.Encoder) (int, error)
+}
@@ -0,0 +1,156 @@
+// Package bulk contains the streaming formats used to import and export asset
+// links of the Discovery Service in large quantities.
+//
+// Two formats are supported:
+//
+//   - NDJSON: one JSON object per line, {"aasId": "...", "specificAssetIds": [...]}
+//   - CSV: one specific asset id per row with the header
+//     aasId,name,value,externalSubjectId,semanticId,supplementalSemanticIds.
+//     Reference columns hold the JSON encoding of the reference and may be empty.
+//     Consecutive rows with the same aasId form one record.
+//
+// Both formats describe the complete set of asset links of a shell, i.e. importing
+// a record replaces the links stored for that aasId.
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// Format identifies a bulk serialization format.
+type Format string
+
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+// ContentType returns the media type used for the format on the HTTP API.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// ParseFormat resolves a format name. An empty name selects NDJSON.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported bulk format '%s': valid values are [ndjson csv]", name)
+	}
+}
+
+// FormatFromContentType maps a Content-Type or Accept header value to a format.
+// Unknown or empty values select NDJSON.
+func FormatFromContentType(contentType string) Format {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/csv" {
+		return FormatCSV
+	}
+	return FormatNDJSON
+}
+
+// Record is the complete set of asset links of one Asset Administration Shell.
+type Record struct {
+	// Line is the 1-based line in the input where the record starts. It is zero for exported records.
+	Line             int                     `json:"-"`
+	AASID            string                  `json:"aasId"`
+	SpecificAssetIds []model.SpecificAssetId `json:"specificAssetIds"`
+}
+
+// RowError describes why a record of an import was rejected.
+type RowError struct {
+	Line    int    `json:"line"`
+	AASID   string `json:"aasId,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *RowError) Error() string {
+	if e.AASID != "" {
+		return fmt.Sprintf("line %d (%s): %s", e.Line, e.AASID, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Report summarizes an import. Imported counts records, not single asset links.
+type Report struct {
+	Imported int        `json:"imported"`
+	Failed   int        `json:"failed"`
+	Errors   []RowError `json:"errors,omitempty"`
+}
+
+// Fail records a rejected record.
+func (r *Report) Fail(err RowError) {
+	r.Failed++
+	r.Errors = append(r.Errors, err)
+}
+
+// Decoder reads records from a bulk stream.
+//
+// Next returns io.EOF once the input is exhausted. A *RowError signals a
+// malformed record; the decoder has skipped it and Next may be called again.
+// Any other error is fatal.
+type Decoder interface {
+	Next() (Record, error)
+}
+
+// Encoder writes records to a bulk stream. Flush must be called after the last record.
+type Encoder interface {
+	Encode(Record) error
+	Flush() error
+}
+
+// NewDecoder creates a decoder for the given format.
+func NewDecoder(format Format, r io.Reader) (Decoder, error) {
+	switch format {
+	case FormatNDJSON:
+		return NewNDJSONDecoder(r), nil
+	case FormatCSV:
+		return NewCSVDecoder(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported bulk format '%s'", format)
+	}
+}
+
+// NewEncoder creates an encoder for the given format.
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatNDJSON:
+		return NewNDJSONEncoder(w), nil
+	case FormatCSV:
+		return NewCSVEncoder(w)
+	default:
+		return nil, fmt.Errorf("unsupported bulk format '%s'", format)
+	}
+}
+
+// Validate checks a record the same way the single-shell endpoint checks its body.
+func Validate(rec Record) error {
+	if strings.TrimSpace(rec.AASID) == "" {
+		return errors.New("missing aasId")
+	}
+	for i, said := range rec.SpecificAssetIds {
+		if err := model.AssertSpecificAssetIdRequired(said); err != nil {
+			return fmt.Errorf("specificAssetIds[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AsRowError reports whether err is a recoverable row error.
+func AsRowError(err error) (*RowError, bool) {
+	var rowErr *RowError
+	ok := errors.As(err, &rowErr)
+	return rowErr, ok
+}
@@ -0,0 +1,177 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func collect(t *testing.T, dec Decoder) ([]Record, []*RowError) {
+	t.Helper()
+	var recs []Record
+	var rowErrs []*RowError
+	for {
+		rec, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			return recs, rowErrs
+		}
+		if rowErr, ok := AsRowError(err); ok {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected fatal error: %v", err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func sampleRecords() []Record {
+	esid := &model.Reference{
+		Type: model.REFERENCETYPES_EXTERNAL_REFERENCE,
+		Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:company:acme"}},
+	}
+	return []Record{
+		{AASID: "urn:aas:1", SpecificAssetIds: []model.SpecificAssetId{
+			{Name: "serialNumber", Value: "SN, with \"quotes\"", ExternalSubjectId: esid},
+			{Name: "plant", Value: "P1", SupplementalSemanticIds: []model.Reference{*esid}},
+		}},
+		{AASID: "urn:aas:2", SpecificAssetIds: []model.SpecificAssetId{
+			{Name: "globalAssetId", Value: "urn:ga:2", SemanticId: esid},
+		}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatNDJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			enc, err := NewEncoder(format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := sampleRecords()
+			for _, rec := range want {
+				if err := enc.Encode(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := enc.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			dec, err := NewDecoder(format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, rowErrs := collect(t, dec)
+			if len(rowErrs) != 0 {
+				t.Fatalf("unexpected row errors: %v", rowErrs)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("expected %d records, got %d", len(want), len(got))
+			}
+			for i := range want {
+				if got[i].AASID != want[i].AASID || len(got[i].SpecificAssetIds) != len(want[i].SpecificAssetIds) {
+					t.Fatalf("record %d mismatch: got %+v want %+v", i, got[i], want[i])
+				}
+				for j := range want[i].SpecificAssetIds {
+					g, w := got[i].SpecificAssetIds[j], want[i].SpecificAssetIds[j]
+					if g.Name != w.Name || g.Value != w.Value ||
+						(g.ExternalSubjectId == nil) != (w.ExternalSubjectId == nil) ||
+						(g.SemanticId == nil) != (w.SemanticId == nil) ||
+						len(g.SupplementalSemanticIds) != len(w.SupplementalSemanticIds) {
+						t.Fatalf("record %d link %d mismatch: got %+v want %+v", i, j, g, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONDecoderReportsBadLinesAndContinues(t *testing.T) {
+	input := strings.Join([]string{
+		`{"aasId":"urn:aas:1","specificAssetIds":[{"name":"a","value":"1"}]}`,
+		`not json`,
+		``,
+		`{"aasId":"","specificAssetIds":[]}`,
+		`{"aasId":"urn:aas:4","specificAssetIds":[{"name":"","value":"x"}]}`,
+		`{"aasId":"urn:aas:5","specificAssetIds":[]}`,
+	}, "\n")
+
+	recs, rowErrs := collect(t, NewNDJSONDecoder(strings.NewReader(input)))
+	if len(recs) != 2 || recs[0].AASID != "urn:aas:1" || recs[1].AASID != "urn:aas:5" {
+		t.Fatalf("unexpected records: %+v", recs)
+	}
+	if recs[1].Line != 6 {
+		t.Fatalf("expected line 6, got %d", recs[1].Line)
+	}
+	wantLines := []int{2, 4, 5}
+	if len(rowErrs) != len(wantLines) {
+		t.Fatalf("expected %d row errors, got %v", len(wantLines), rowErrs)
+	}
+	for i, line := range wantLines {
+		if rowErrs[i].Line != line {
+			t.Errorf("row error %d: expected line %d, got %d", i, line, rowErrs[i].Line)
+		}
+	}
+}
+
+func TestCSVDecoderGroupsRowsAndRejectsWholeGroup(t *testing.T) {
+	input := strings.Join([]string{
+		strings.Join(CSVHeader, ","),
+		`urn:aas:1,a,1,,,`,
+		`urn:aas:1,b,2,,,`,
+		`urn:aas:2,a,1,{broken,,`,
+		`urn:aas:2,b,2,,,`,
+		`urn:aas:3,a,1,,,`,
+		`urn:aas:3,too,few`,
+		`urn:aas:4,a,1,,,`,
+	}, "\n")
+
+	recs, rowErrs := collect(t, NewCSVDecoder(strings.NewReader(input)))
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records, got %+v", recs)
+	}
+	if recs[0].AASID != "urn:aas:1" || len(recs[0].SpecificAssetIds) != 2 || recs[0].Line != 2 {
+		t.Fatalf("unexpected first record: %+v", recs[0])
+	}
+	if recs[1].AASID != "urn:aas:4" || len(recs[1].SpecificAssetIds) != 1 {
+		t.Fatalf("unexpected second record: %+v", recs[1])
+	}
+	if len(rowErrs) != 2 {
+		t.Fatalf("expected 2 row errors, got %v", rowErrs)
+	}
+	if rowErrs[0].AASID != "urn:aas:2" || rowErrs[0].Line != 4 {
+		t.Errorf("unexpected first row error: %+v", rowErrs[0])
+	}
+	if rowErrs[1].AASID != "urn:aas:3" || rowErrs[1].Line != 7 {
+		t.Errorf("unexpected second row error: %+v", rowErrs[1])
+	}
+}
+
+func TestCSVDecoderRejectsUnknownHeader(t *testing.T) {
+	dec := NewCSVDecoder(strings.NewReader("id,name,value\n"))
+	if _, err := dec.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Fatalf("expected header error, got %v", err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": FormatNDJSON, "NDJSON": FormatNDJSON, "csv": FormatCSV} {
+		got, err := ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if FormatFromContentType("text/csv; charset=utf-8") != FormatCSV {
+		t.Error("expected csv for text/csv content type")
+	}
+}
@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// CSVHeader is the column layout of the CSV format.
+var CSVHeader = []string{"aasId", "name", "value", "externalSubjectId", "semanticId", "supplementalSemanticIds"}
+
+// CSVDecoder groups consecutive rows with the same aasId into one record.
+type CSVDecoder struct {
+	r *csv.Reader
+
+	headerRead bool
+	// pending is the first row of the next record, read while looking ahead.
+	pending     []string
+	pendingLine int
+	eof         bool
+}
+
+func NewCSVDecoder(r io.Reader) *CSVDecoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	return &CSVDecoder{r: cr}
+}
+
+// Next returns the next group of rows. If any row of a group is malformed the whole
+// group is rejected, since importing the remaining rows would silently drop links.
+// A malformed row between two groups is attributed to the preceding one.
+func (d *CSVDecoder) Next() (Record, error) {
+	if !d.headerRead {
+		if err := d.readHeader(); err != nil {
+			return Record{}, err
+		}
+	}
+
+	row, line, err := d.nextRow()
+	if err != nil {
+		return Record{}, err
+	}
+
+	rec := Record{Line: line, AASID: row[0]}
+	var groupErr *RowError
+	for {
+		if groupErr == nil {
+			said, err := parseCSVRow(row)
+			if err != nil {
+				groupErr = &RowError{Line: line, AASID: rec.AASID, Message: err.Error()}
+			} else {
+				rec.SpecificAssetIds = append(rec.SpecificAssetIds, said)
+			}
+		}
+
+		row, line, err = d.nextRow()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if rowErr, ok := AsRowError(err); ok {
+			if groupErr == nil {
+				groupErr = rowErr
+				groupErr.AASID = rec.AASID
+			}
+			continue
+		}
+		if err != nil {
+			return Record{}, err
+		}
+		if row[0] != rec.AASID {
+			d.pending, d.pendingLine = row, line
+			break
+		}
+	}
+
+	if groupErr != nil {
+		return Record{}, groupErr
+	}
+	if err := Validate(rec); err != nil {
+		return Record{}, &RowError{Line: rec.Line, AASID: rec.AASID, Message: err.Error()}
+	}
+	return rec, nil
+}
+
+func (d *CSVDecoder) readHeader() error {
+	d.headerRead = true
+	header, err := d.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			d.eof = true
+			return io.EOF
+		}
+		return err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	if len(header) != len(CSVHeader) {
+		return fmt.Errorf("unexpected CSV header %v: expected %v", header, CSVHeader)
+	}
+	for i, col := range CSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), col) {
+			return fmt.Errorf("unexpected CSV header %v: expected %v", header, CSVHeader)
+		}
+	}
+	return nil
+}
+
+// nextRow returns the pending row if any, otherwise the next row of the input.
+// Rows that cannot be parsed or have a wrong column count are returned as *RowError.
+func (d *CSVDecoder) nextRow() ([]string, int, error) {
+	if d.pending != nil {
+		row, line := d.pending, d.pendingLine
+		d.pending = nil
+		return row, line, nil
+	}
+	if d.eof {
+		return nil, 0, io.EOF
+	}
+
+	row, err := d.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			d.eof = true
+			return nil, 0, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The reader resumes after the offending record.
+			return nil, parseErr.StartLine, &RowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()}
+		}
+		return nil, 0, err
+	}
+	line, _ := d.r.FieldPos(0)
+	if len(row) != len(CSVHeader) {
+		return nil, line, &RowError{Line: line, Message: fmt.Sprintf("expected %d columns, got %d", len(CSVHeader), len(row))}
+	}
+	return row, line, nil
+}
+
+func parseCSVRow(row []string) (model.SpecificAssetId, error) {
+	said := model.SpecificAssetId{Name: row[1], Value: row[2]}
+	if err := unmarshalOptional(row[3], &said.ExternalSubjectId); err != nil {
+		return said, fmt.Errorf("invalid externalSubjectId: %w", err)
+	}
+	if err := unmarshalOptional(row[4], &said.SemanticId); err != nil {
+		return said, fmt.Errorf("invalid semanticId: %w", err)
+	}
+	if err := unmarshalOptional(row[5], &said.SupplementalSemanticIds); err != nil {
+		return said, fmt.Errorf("invalid supplementalSemanticIds: %w", err)
+	}
+	return said, nil
+}
+
+func unmarshalOptional(cell string, target any) error {
+	if strings.TrimSpace(cell) == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(cell), target)
+}
+
+// CSVEncoder writes one row per specific asset id. Shells without any links have no
+// rows and are therefore not part of a CSV export; use NDJSON to keep them.
+type CSVEncoder struct {
+	w *csv.Writer
+}
+
+func NewCSVEncoder(w io.Writer) (*CSVEncoder, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVHeader); err != nil {
+		return nil, err
+	}
+	return &CSVEncoder{w: cw}, nil
+}
+
+func (e *CSVEncoder) Encode(rec Record) error {
+	for _, said := range rec.SpecificAssetIds {
+		row := []string{rec.AASID, said.Name, said.Value, "", "", ""}
+		var err error
+		if row[3], err = marshalOptional(said.ExternalSubjectId, said.ExternalSubjectId == nil); err != nil {
+			return err
+		}
+		if row[4], err = marshalOptional(said.SemanticId, said.SemanticId == nil); err != nil {
+			return err
+		}
+		if row[5], err = marshalOptional(said.SupplementalSemanticIds, len(said.SupplementalSemanticIds) == 0); err != nil {
+			return err
+		}
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return e.w.Error()
+}
+
+func (e *CSVEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func marshalOptional(v any, empty bool) (string, error) {
+	if empty {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
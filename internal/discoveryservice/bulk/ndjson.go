@@ -0,0 +1,71 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// maxLineSize bounds a single NDJSON line. Shells with very many links still fit comfortably.
+const maxLineSize = 16 * 1024 * 1024
+
+// NDJSONDecoder reads one record per line. Empty lines are skipped.
+type NDJSONDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewNDJSONDecoder(r io.Reader) *NDJSONDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &NDJSONDecoder{scanner: scanner}
+}
+
+func (d *NDJSONDecoder) Next() (Record, error) {
+	for d.scanner.Scan() {
+		d.line++
+		raw := bytes.TrimSpace(d.scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var rec Record
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rec); err != nil {
+			return Record{}, &RowError{Line: d.line, Message: "invalid JSON: " + err.Error()}
+		}
+		rec.Line = d.line
+		if err := Validate(rec); err != nil {
+			return Record{}, &RowError{Line: d.line, AASID: rec.AASID, Message: err.Error()}
+		}
+		return rec, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return Record{}, err
+	}
+	return Record{}, io.EOF
+}
+
+// NDJSONEncoder writes one record per line.
+type NDJSONEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+	return &NDJSONEncoder{w: bw, enc: enc}
+}
+
+func (e *NDJSONEncoder) Encode(rec Record) error {
+	// json.Encoder terminates every value with a newline.
+	return e.enc.Encode(rec)
+}
+
+func (e *NDJSONEncoder) Flush() error {
+	return e.w.Flush()
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/testenv"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,6 +181,165 @@ func TestDiscovery_Suite_Sophisticated(t *testing.T) {
 		_ = rc.GetLookupShells(t, aasX, http.StatusNotFound)
 	})
 
+	t.Run("LookupShells/POST_full_SpecificAssetId_roundtrip_and_externalSubjectId_search", func(t *testing.T) {
+		aasF := "urn:aas:test:flying-robot-frame"
+		aasG := "urn:aas:test:low-density-structure"
+
+		issuer := func(company string) *model.Reference {
+			return &model.Reference{
+				Type: model.REFERENCETYPES_EXTERNAL_REFERENCE,
+				Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:company:" + company}},
+			}
+		}
+		longSerial := "SN-" + strings.Repeat("9", 200)
+
+		fullF := model.SpecificAssetId{
+			Name:              "serialNumber",
+			Value:             longSerial,
+			ExternalSubjectId: issuer("acme"),
+			SemanticId: &model.Reference{
+				Type: model.REFERENCETYPES_EXTERNAL_REFERENCE,
+				Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "0173-1#02-AAM556#002"}},
+			},
+			SupplementalSemanticIds: []model.Reference{{
+				Type: model.REFERENCETYPES_EXTERNAL_REFERENCE,
+				Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:supplemental:serial"}},
+			}},
+		}
+		fullG := model.SpecificAssetId{Name: "serialNumber", Value: longSerial, ExternalSubjectId: issuer("globex")}
+
+		rc.PostLookupShells(t, aasF, []model.SpecificAssetId{fullF})
+		rc.PostLookupShells(t, aasG, []model.SpecificAssetId{fullG})
+
+		got := rc.GetLookupShells(t, aasF, http.StatusOK)
+		require.Len(t, got, 1)
+		assert.Equal(t, fullF, got[0])
+
+		res := rc.LookupShellsByAssetLink(t, []model.SpecificAssetId{{Name: "serialNumber", Value: longSerial}}, 10, "", http.StatusOK)
+		assert.Equal(t, sortedStrings(aasF, aasG), res.Result)
+
+		res = rc.LookupShellsByAssetLink(t, []model.SpecificAssetId{{Name: "serialNumber", Value: longSerial, ExternalSubjectId: issuer("globex")}}, 10, "", http.StatusOK)
+		assert.Equal(t, []string{aasG}, res.Result)
+
+		res = rc.LookupShellsByAssetLink(t, []model.SpecificAssetId{{Name: "serialNumber", Value: longSerial, ExternalSubjectId: issuer("initech")}}, 10, "", http.StatusOK)
+		assert.Empty(t, res.Result)
+
+		rc.DeleteLookupShells(t, aasF)
+		rc.DeleteLookupShells(t, aasG)
+	})
+
+	t.Run("LookupShellsByAssetLink/Search_modes_prefix_contains_case_insensitive_any", func(t *testing.T) {
+		aasM1 := "urn:aas:test:modes-assembler"
+		aasM2 := "urn:aas:test:modes-furnace"
+		aasM3 := "urn:aas:test:modes-inserter"
+
+		rc.PostLookupShells(t, aasM1, []model.SpecificAssetId{{Name: "partNumber", Value: "PN-Alpha_100%"}})
+		rc.PostLookupShells(t, aasM2, []model.SpecificAssetId{{Name: "partNumber", Value: "PN-alpha-200"}})
+		rc.PostLookupShells(t, aasM3, []model.SpecificAssetId{{Name: "partNumber", Value: "XX-BETA-300"}, {Name: "modesLine", Value: "L7"}})
+
+		pn := func(v string) []model.SpecificAssetId { return []model.SpecificAssetId{{Name: "partNumber", Value: v}} }
+
+		res := rc.LookupShellsByAssetLinkWithOptions(t, pn("PN-alpha"), "matchMode=prefix", 10, "", http.StatusOK)
+		assert.Equal(t, []string{aasM2}, res.Result)
+
+		res = rc.LookupShellsByAssetLinkWithOptions(t, pn("PN-alpha"), "matchMode=prefix&caseInsensitive=true", 10, "", http.StatusOK)
+		assert.Equal(t, sortedStrings(aasM1, aasM2), res.Result)
+
+		res = rc.LookupShellsByAssetLinkWithOptions(t, pn("beta"), "matchMode=contains&caseInsensitive=true", 10, "", http.StatusOK)
+		assert.Equal(t, []string{aasM3}, res.Result)
+
+		res = rc.LookupShellsByAssetLinkWithOptions(t, pn("pn-alpha-200"), "caseInsensitive=true", 10, "", http.StatusOK)
+		assert.Equal(t, []string{aasM2}, res.Result)
+
+		// LIKE wildcards in the searched value are matched literally.
+		res = rc.LookupShellsByAssetLinkWithOptions(t, pn("_100%"), "matchMode=contains", 10, "", http.StatusOK)
+		assert.Equal(t, []string{aasM1}, res.Result)
+		res = rc.LookupShellsByAssetLinkWithOptions(t, pn("PN_"), "matchMode=prefix", 10, "", http.StatusOK)
+		assert.Empty(t, res.Result)
+
+		anyOf := []model.SpecificAssetId{{Name: "partNumber", Value: "PN-alpha-200"}, {Name: "modesLine", Value: "L7"}}
+		res = rc.LookupShellsByAssetLink(t, anyOf, 10, "", http.StatusOK)
+		assert.Empty(t, res.Result)
+		res = rc.LookupShellsByAssetLinkWithOptions(t, anyOf, "combine=any", 10, "", http.StatusOK)
+		assert.Equal(t, sortedStrings(aasM2, aasM3), res.Result)
+
+		// Paging keeps working in relaxed modes.
+		expected := sortedStrings(aasM1, aasM2)
+		page1 := rc.LookupShellsByAssetLinkWithOptions(t, pn("pn-"), "matchMode=prefix&caseInsensitive=true", 1, "", http.StatusOK)
+		require.Equal(t, expected[:1], page1.Result)
+		require.NotEmpty(t, page1.PagingMetadata.Cursor)
+		page2 := rc.LookupShellsByAssetLinkWithOptions(t, pn("pn-"), "matchMode=prefix&caseInsensitive=true", 1, page1.PagingMetadata.Cursor, http.StatusOK)
+		assert.Equal(t, expected[1:], page2.Result)
+		assert.Empty(t, page2.PagingMetadata.Cursor)
+
+		_ = rc.LookupShellsByAssetLinkWithOptions(t, pn("x"), "matchMode=fuzzy", 10, "", http.StatusBadRequest)
+		_ = rc.LookupShellsByAssetLinkWithOptions(t, pn("x"), "combine=either", 10, "", http.StatusBadRequest)
+		_ = rc.LookupShellsByAssetLinkWithOptions(t, pn("x"), "caseInsensitive=maybe", 10, "", http.StatusBadRequest)
+
+		rc.DeleteLookupShells(t, aasM1)
+		rc.DeleteLookupShells(t, aasM2)
+		rc.DeleteLookupShells(t, aasM3)
+	})
+
+	t.Run("LookupShells/Bulk_import_report_and_export_roundtrip", func(t *testing.T) {
+		aasI1 := "urn:aas:test:bulk-1"
+		aasI2 := "urn:aas:test:bulk-2"
+		aasI3 := "urn:aas:test:bulk-3"
+
+		ndjson := strings.Join([]string{
+			`{"aasId":"` + aasI1 + `","specificAssetIds":[{"name":"bulkSerial","value":"B-1"},{"name":"bulkPlant","value":"P"}]}`,
+			`{"aasId":"` + aasI2 + `","specificAssetIds":[{"name":"bulkSerial","value":""}]}`,
+			`this is not json`,
+		}, "\n")
+		report := rc.ImportAssetLinks(t, "ndjson", ndjson, http.StatusOK)
+		assert.Equal(t, 1, report.Imported)
+		assert.Equal(t, 2, report.Failed)
+		require.Len(t, report.Errors, 2)
+		assert.Equal(t, 2, report.Errors[0].Line)
+		assert.Equal(t, aasI2, report.Errors[0].AASID)
+		assert.Equal(t, 3, report.Errors[1].Line)
+
+		csvPayload := strings.Join([]string{
+			"aasId,name,value,externalSubjectId,semanticId,supplementalSemanticIds",
+			aasI2 + ",bulkSerial,B-2,,,",
+			aasI3 + ",bulkSerial,B-3,,,",
+			aasI3 + `,bulkLine,L9,"{""type"":""ExternalReference"",""keys"":[{""type"":""GlobalReference"",""value"":""urn:company:acme""}]}",,`,
+		}, "\n")
+		report = rc.ImportAssetLinks(t, "csv", csvPayload, http.StatusOK)
+		assert.Equal(t, 2, report.Imported)
+		assert.Equal(t, 0, report.Failed)
+
+		ensureContainsAll(t, rc.GetLookupShells(t, aasI1, http.StatusOK), map[string][]string{
+			"bulkSerial": {"B-1"},
+			"bulkPlant":  {"P"},
+		})
+		gotI3 := rc.GetLookupShells(t, aasI3, http.StatusOK)
+		require.Len(t, gotI3, 2)
+		require.NotNil(t, gotI3[1].ExternalSubjectId)
+		assert.Equal(t, "urn:company:acme", gotI3[1].ExternalSubjectId.Keys[0].Value)
+
+		// Re-importing a shell replaces its links.
+		report = rc.ImportAssetLinks(t, "ndjson", `{"aasId":"`+aasI1+`","specificAssetIds":[{"name":"bulkSerial","value":"B-1b"}]}`, http.StatusOK)
+		assert.Equal(t, 1, report.Imported)
+		ensureContainsAll(t, rc.GetLookupShells(t, aasI1, http.StatusOK), map[string][]string{"bulkSerial": {"B-1b"}})
+
+		for _, format := range []string{"ndjson", "csv"} {
+			exported := map[string]bulk.Record{}
+			for _, rec := range rc.ExportAssetLinks(t, format) {
+				exported[rec.AASID] = rec
+			}
+			require.Contains(t, exported, aasI3, "format %s", format)
+			assert.Len(t, exported[aasI3].SpecificAssetIds, 2, "format %s", format)
+			assert.Equal(t, gotI3, exported[aasI3].SpecificAssetIds, "format %s", format)
+		}
+
+		_ = rc.ImportAssetLinks(t, "xml", "", http.StatusBadRequest)
+
+		rc.DeleteLookupShells(t, aasI1)
+		rc.DeleteLookupShells(t, aasI2)
+		rc.DeleteLookupShells(t, aasI3)
+	})
+
 	t.Run("LookupShells/BadRequest_when_aas_not_base64_encoded", func(t *testing.T) {
 		rawAAS := "urn:aas:not-encoded:crude-oil"
 		url := fmt.Sprintf("%s/lookup/shells/%s", testenv.BaseURL, rawAAS)
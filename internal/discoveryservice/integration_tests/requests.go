@@ -3,14 +3,18 @@ package bench
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/testenv"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,15 +79,32 @@ func (c *RequestClient) LookupShellsByAssetLink(
 	limit int,
 	cursor string,
 	expect int,
+) model.GetAllAssetAdministrationShellIdsByAssetLink200Response {
+	t.Helper()
+	return c.LookupShellsByAssetLinkWithOptions(t, pairs, "", limit, cursor, expect)
+}
+
+// POST /lookup/shellsByAssetLink?limit=&cursor=&<options>
+// options is appended verbatim, e.g. "matchMode=prefix&caseInsensitive=true&combine=any".
+func (c *RequestClient) LookupShellsByAssetLinkWithOptions(
+	t testing.TB,
+	pairs []model.SpecificAssetId,
+	options string,
+	limit int,
+	cursor string,
+	expect int,
 ) model.GetAllAssetAdministrationShellIdsByAssetLink200Response {
 	t.Helper()
 	url := fmt.Sprintf("%s/lookup/shellsByAssetLink?limit=%d", c.BaseURL, limit)
 	if cursor != "" {
 		url += "&cursor=" + cursor
 	}
-	body := make([]map[string]string, 0, len(pairs))
+	if options != "" {
+		url += "&" + options
+	}
+	body := make([]model.AssetLink, 0, len(pairs))
 	for _, p := range pairs {
-		body = append(body, map[string]string{"name": p.Name, "value": p.Value})
+		body = append(body, model.AssetLink{Name: p.Name, Value: p.Value, ExternalSubjectId: p.ExternalSubjectId})
 	}
 	raw := testenv.PostJSONExpect(t, url, body, expect)
 	var out model.GetAllAssetAdministrationShellIdsByAssetLink200Response
@@ -145,3 +166,47 @@ func sortedStrings(in ...string) []string {
 	sort.Strings(out)
 	return out
 }
+
+// POST /lookup/shells/$import?format=
+func (c *RequestClient) ImportAssetLinks(t testing.TB, format string, payload string, expect int) bulk.Report {
+	t.Helper()
+	url := fmt.Sprintf("%s/lookup/shells/$import?format=%s", c.BaseURL, format)
+	resp, err := testenv.HTTPClient().Post(url, "application/octet-stream", strings.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equalf(t, expect, resp.StatusCode, "import got %d body=%s", resp.StatusCode, string(raw))
+
+	var report bulk.Report
+	if expect == http.StatusOK {
+		require.NoError(t, json.Unmarshal(raw, &report))
+	}
+	return report
+}
+
+// GET /lookup/shells/$export?format=
+func (c *RequestClient) ExportAssetLinks(t testing.TB, format string) []bulk.Record {
+	t.Helper()
+	url := fmt.Sprintf("%s/lookup/shells/$export?format=%s", c.BaseURL, format)
+	resp, err := testenv.HTTPClient().Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	f, err := bulk.ParseFormat(format)
+	require.NoError(t, err)
+	assert.Equal(t, f.ContentType(), resp.Header.Get("Content-Type"))
+	dec, err := bulk.NewDecoder(f, resp.Body)
+	require.NoError(t, err)
+
+	var out []bulk.Record
+	for {
+		rec, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			return out
+		}
+		require.NoError(t, err)
+		out = append(out, rec)
+	}
+}
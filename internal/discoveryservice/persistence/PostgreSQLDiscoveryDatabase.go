@@ -2,52 +2,90 @@ package persistence_postgresql
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/health"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/migrate"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type PostgreSQLDiscoveryDatabase struct {
 	pool *pgxpool.Pool
+	// db is the database/sql handle on top of pool that runs the migrations.
+	db       *sql.DB
+	migrator *migrate.Migrator
+	// tenants have schemas of their own, see package tenant.
+	tenants []string
 }
 
-func NewPostgreSQLDiscoveryBackend(dsn string, maxConns int) (*PostgreSQLDiscoveryDatabase, error) {
+// NewPostgreSQLDiscoveryBackend connects to the database and migrates its schema. If tenants is
+// not empty, every tenant gets its own schema and queries run in the schema of the tenant of
+// their context, see package tenant.
+func NewPostgreSQLDiscoveryBackend(dsn string, maxConns int, tenants []string) (*PostgreSQLDiscoveryDatabase, error) {
 	cfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
 	cfg.MaxConns = int32(maxConns)
 	cfg.MaxConnLifetime = 5 * time.Minute
+	cfg.ConnConfig.Tracer = tracing.NewQueryTracer()
+	if len(tenants) > 0 {
+		tenant.ConfigurePool(cfg)
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	dir, _ := os.Getwd()
-	schema, err := os.ReadFile(dir + "/resources/sql/discoveryschema.sql")
+	db := stdlib.OpenDBFromPool(pool)
+	migrator, err := NewMigrator(db)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := pool.Exec(context.Background(), string(schema)); err != nil {
+	err = tenant.ForEachSchema(context.Background(), pool, tenants, func(ctx context.Context, _ string) error {
+		_, err := migrator.Up(ctx)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &PostgreSQLDiscoveryDatabase{pool: pool}, nil
+	return &PostgreSQLDiscoveryDatabase{pool: pool, db: db, migrator: migrator, tenants: tenants}, nil
+}
+
+// RegisterMetrics registers the statistics of the connection pool.
+func (p *PostgreSQLDiscoveryDatabase) RegisterMetrics(reg prometheus.Registerer) error {
+	return metrics.RegisterPool(reg, "discovery", p.pool, p.db)
 }
 
-func (p *PostgreSQLDiscoveryDatabase) GetAllAssetLinks(aasID string) ([]model.SpecificAssetId, error) {
-	ctx := context.Background()
+// HealthChecks reports the connectivity, the schema versions and the pool saturation.
+func (p *PostgreSQLDiscoveryDatabase) HealthChecks(cfg health.Config) map[string]health.Check {
+	return health.PostgresChecks(p.pool, p.migrator, p.tenants, cfg.MaxPoolSaturation)
+}
+
+func (p *PostgreSQLDiscoveryDatabase) GetAllAssetLinks(ctx context.Context, aasID string) ([]model.SpecificAssetId, error) {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return nil, common.NewInternalServerError("Failed to start postgres transaction. See console for information.")
+		slog.ErrorContext(ctx, "Failed to start postgres transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to start postgres transaction.", err)
 	}
 	defer tx.Rollback(ctx)
 
@@ -56,102 +94,126 @@ func (p *PostgreSQLDiscoveryDatabase) GetAllAssetLinks(aasID string) ([]model.Sp
 		if err == pgx.ErrNoRows {
 			return nil, common.NewErrNotFound("AAS identifier '" + aasID + "'")
 		}
-		fmt.Println(err)
-		return nil, common.NewInternalServerError("Failed to fetch aas identifier. See console for information.")
+		slog.ErrorContext(ctx, "Failed to fetch aas identifier", "error", err)
+		return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to fetch aas identifier.", err)
 	}
 
-	rows, err := tx.Query(ctx, `SELECT name, value FROM asset_link WHERE aasRef = $1 ORDER BY id`, referenceID)
+	rows, err := tx.Query(ctx, `
+		SELECT name, value, external_subject_id, semantic_id, supplemental_semantic_ids
+		FROM asset_link
+		WHERE aasRef = $1
+		ORDER BY id`, referenceID)
 	if err != nil {
-		fmt.Println(err)
-		return nil, common.NewInternalServerError("Failed to query asset links. See console for information.")
+		slog.ErrorContext(ctx, "Failed to query asset links", "error", err)
+		return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to query asset links.", err)
 	}
 	defer rows.Close()
 
 	var result []model.SpecificAssetId
 	for rows.Next() {
-		var name, value string
-		if err := rows.Scan(&name, &value); err != nil {
-			fmt.Println(err)
-			return nil, common.NewInternalServerError("Failed to scan asset link. See console for information.")
+		var (
+			said                                        model.SpecificAssetId
+			externalSubjectID, semanticID, supplemental []byte
+		)
+		if err := rows.Scan(&said.Name, &said.Value, &externalSubjectID, &semanticID, &supplemental); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan asset link", "error", err)
+			return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to scan asset link.", err)
+		}
+		if err := unmarshalNullableJSON(externalSubjectID, &said.ExternalSubjectId); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode externalSubjectId of asset link", "error", err)
+			return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to decode externalSubjectId of asset link.", err)
+		}
+		if err := unmarshalNullableJSON(semanticID, &said.SemanticId); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode semanticId of asset link", "error", err)
+			return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to decode semanticId of asset link.", err)
 		}
-		result = append(result, model.SpecificAssetId{
-			Name:  name,
-			Value: value,
-		})
+		if err := unmarshalNullableJSON(supplemental, &said.SupplementalSemanticIds); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode supplementalSemanticIds of asset link", "error", err)
+			return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to decode supplementalSemanticIds of asset link.", err)
+		}
+		result = append(result, said)
 	}
 	if rows.Err() != nil {
-		fmt.Println(rows.Err())
-		return nil, common.NewInternalServerError("Failed to iterate asset links. See console for information.")
+		slog.ErrorContext(ctx, "Failed to iterate asset links", "error", rows.Err())
+		return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to iterate asset links.", rows.Err())
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		fmt.Println(err)
-		return nil, common.NewInternalServerError("Failed to commit postgres transaction. See console for information.")
+		slog.ErrorContext(ctx, "Failed to commit postgres transaction", "error", err)
+		return nil, common.NewError(common.ErrCodeInternalServerError, "Failed to commit postgres transaction.", err)
 	}
 
 	return result, nil
 }
 
-func (p *PostgreSQLDiscoveryDatabase) DeleteAllAssetLinks(aasID string) error {
-	ctx := context.Background()
+func (p *PostgreSQLDiscoveryDatabase) DeleteAllAssetLinks(ctx context.Context, aasID string) error {
 
 	tag, err := p.pool.Exec(ctx, `DELETE FROM aas_identifier WHERE aasId = $1`, aasID)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to delete AAS identifier. See console for information.")
+		slog.ErrorContext(ctx, "Failed to delete AAS identifier", "error", err)
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to delete AAS identifier.", err)
 	}
 	if tag.RowsAffected() == 0 {
-		return common.NewErrNotFound(fmt.Sprintf("AAS identifier %s not found. See console for information.", aasID))
+		return common.NewErrNotFound(fmt.Sprintf("AAS identifier %s not found", aasID))
 	}
 	return nil
 }
 
-func (p *PostgreSQLDiscoveryDatabase) CreateAllAssetLinks(aas_id string, specific_asset_ids []model.SpecificAssetId) error {
-	ctx := context.Background()
+func (p *PostgreSQLDiscoveryDatabase) CreateAllAssetLinks(ctx context.Context, aas_id string, specific_asset_ids []model.SpecificAssetId) error {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to start postgres transaction. See console for information.")
+		slog.ErrorContext(ctx, "Failed to start postgres transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to start postgres transaction.", err)
 	}
 	defer tx.Rollback(ctx)
 
 	var referenceID int64
 	err = tx.QueryRow(ctx, "INSERT INTO aas_identifier (aasId) VALUES ($1) ON CONFLICT (aasId) DO UPDATE SET aasId = EXCLUDED.aasId RETURNING id", aas_id).Scan(&referenceID)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to insert aas identifier. See console for information.")
+		slog.ErrorContext(ctx, "Failed to insert aas identifier", "error", err)
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to insert aas identifier.", err)
 	}
 
 	if _, err := tx.Exec(ctx, `DELETE FROM asset_link WHERE aasRef = $1`, referenceID); err != nil {
-		return common.NewInternalServerError("Failed to remove old asset links.")
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to remove old asset links.", err)
 	}
 
 	rows := make([][]any, len(specific_asset_ids))
 	for i, v := range specific_asset_ids {
-		rows[i] = []any{v.Name, v.Value, referenceID}
+		row, err := assetLinkRow(v, referenceID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to encode asset link", "error", err)
+			return common.NewError(common.ErrCodeInternalServerError, "Failed to encode asset link.", err)
+		}
+		rows[i] = row
 	}
 
 	_, err = tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"asset_link"},
-		[]string{"name", "value", "aasref"},
+		assetLinkColumns,
 		pgx.CopyFromRows(rows),
 	)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to insert asset link. See console for information.")
+		slog.ErrorContext(ctx, "Failed to insert asset link", "error", err)
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to insert asset link.", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to commit postgres transaction. See console for information.")
+		slog.ErrorContext(ctx, "Failed to commit postgres transaction", "error", err)
+		return common.NewError(common.ErrCodeInternalServerError, "Failed to commit postgres transaction.", err)
 	}
 	return nil
 }
 
+// SearchAASIDsByAssetLinks returns the AAS identifiers whose asset links match the given links,
+// ordered by identifier. By default every link must match exactly; opts relaxes the value
+// comparison and allows any-of matching. Paging is keyed on the identifier in all modes.
 func (p *PostgreSQLDiscoveryDatabase) SearchAASIDsByAssetLinks(
 	ctx context.Context,
 	links []model.AssetLink,
+	opts model.AssetLinkSearchOptions,
 	limit int32,
 	cursor string,
 ) ([]string, string, error) {
@@ -184,30 +246,42 @@ func (p *PostgreSQLDiscoveryDatabase) SearchAASIDsByAssetLinks(
 			if i > 0 {
 				valuesSQL.WriteString(", ")
 			}
-			valuesSQL.WriteString(fmt.Sprintf("($%d, $%d)", argPos, argPos+1))
-			args = append(args, l.Name, l.Value)
-			argPos += 2
+			externalSubjectID, err := marshalNullableJSON(l.ExternalSubjectId)
+			if err != nil {
+				return nil, "", common.NewErrBadRequest("Invalid externalSubjectId in asset link.")
+			}
+			valuesSQL.WriteString(fmt.Sprintf("(%d, $%d::text, $%d::text, $%d::jsonb)", i, argPos, argPos+1, argPos+2))
+			args = append(args, l.Name, valuePattern(l.Value, opts), externalSubjectID)
+			argPos += 3
 		}
 
+		having := "HAVING COUNT(DISTINCT v.idx) = (SELECT COUNT(*) FROM v)"
+		if opts.MatchAny {
+			having = ""
+		}
+
+		// A link without externalSubjectId matches regardless of the stored
+		// one; otherwise the keys of both references have to be identical.
 		sqlStr = fmt.Sprintf(`
-			WITH v(name, value) AS (VALUES %s)
+			WITH v(idx, name, value, esid) AS (VALUES %s)
 			SELECT ai.aasId
 			FROM aas_identifier ai
 			JOIN asset_link al ON al.aasRef = ai.id
-			JOIN v ON v.name = al.name AND v.value = al.value
+			JOIN v ON v.name = al.name AND %s
+				AND (v.esid IS NULL OR al.external_subject_id->'keys' = v.esid->'keys')
 			WHERE %s
 			GROUP BY ai.aasId
-			HAVING COUNT(DISTINCT (al.name, al.value)) = (SELECT COUNT(*) FROM v)
+			%s
 			ORDER BY ai.aasId ASC
 			LIMIT $%d
-		`, valuesSQL.String(), whereCursor, argPos)
+		`, valuesSQL.String(), valuePredicate(opts), whereCursor, having, argPos)
 		args = append(args, peekLimit)
 	}
 
 	rows, err := p.pool.Query(ctx, sqlStr, args...)
 	if err != nil {
-		fmt.Println("SearchAASIDsByAssetLinks: query error:", err)
-		return nil, "", common.NewInternalServerError("Failed to query AAS IDs. See server logs for details.")
+		slog.ErrorContext(ctx, "SearchAASIDsByAssetLinks: query error", "error", err)
+		return nil, "", common.NewError(common.ErrCodeInternalServerError, "Failed to query AAS IDs.", err)
 	}
 	defer rows.Close()
 
@@ -215,14 +289,14 @@ func (p *PostgreSQLDiscoveryDatabase) SearchAASIDsByAssetLinks(
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
-			fmt.Println("SearchAASIDsByAssetLinks: scan error:", err)
-			return nil, "", common.NewInternalServerError("Failed to scan AAS ID. See server logs for details.")
+			slog.ErrorContext(ctx, "SearchAASIDsByAssetLinks: scan error", "error", err)
+			return nil, "", common.NewError(common.ErrCodeInternalServerError, "Failed to scan AAS ID.", err)
 		}
 		buf = append(buf, id)
 	}
 	if rows.Err() != nil {
-		fmt.Println("SearchAASIDsByAssetLinks: rows error:", rows.Err())
-		return nil, "", common.NewInternalServerError("Failed to iterate AAS IDs. See server logs for details.")
+		slog.ErrorContext(ctx, "SearchAASIDsByAssetLinks: rows error", "error", rows.Err())
+		return nil, "", common.NewError(common.ErrCodeInternalServerError, "Failed to iterate AAS IDs.", rows.Err())
 	}
 
 	if len(buf) > int(limit) {
@@ -233,3 +307,295 @@ func (p *PostgreSQLDiscoveryDatabase) SearchAASIDsByAssetLinks(
 
 	return buf, "", nil
 }
+
+var assetLinkColumns = []string{"name", "value", "external_subject_id", "semantic_id", "supplemental_semantic_ids", "aasref"}
+
+// assetLinkRow converts a SpecificAssetId into a COPY row matching assetLinkColumns.
+// Reference fields are stored as JSONB so that they round-trip without loss.
+func assetLinkRow(said model.SpecificAssetId, referenceID int64) ([]any, error) {
+	externalSubjectID, err := marshalNullableJSON(said.ExternalSubjectId)
+	if err != nil {
+		return nil, err
+	}
+	semanticID, err := marshalNullableJSON(said.SemanticId)
+	if err != nil {
+		return nil, err
+	}
+	var supplemental []byte
+	if len(said.SupplementalSemanticIds) > 0 {
+		if supplemental, err = json.Marshal(said.SupplementalSemanticIds); err != nil {
+			return nil, err
+		}
+	}
+	// nil byte slices are written as SQL NULL.
+	return []any{said.Name, said.Value, externalSubjectID, semanticID, supplemental, referenceID}, nil
+}
+
+func marshalNullableJSON(ref *model.Reference) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	return json.Marshal(ref)
+}
+
+func unmarshalNullableJSON(data []byte, target any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// valuePredicate returns the SQL condition comparing al.value with v.value. Everything
+// but exact case-sensitive matching goes through (I)LIKE so the trigram index is used.
+func valuePredicate(opts model.AssetLinkSearchOptions) string {
+	switch {
+	case isPlainEquality(opts):
+		return "v.value = al.value"
+	case opts.CaseInsensitive:
+		return `al.value ILIKE v.value ESCAPE '\'`
+	default:
+		return `al.value LIKE v.value ESCAPE '\'`
+	}
+}
+
+// valuePattern turns the searched value into the operand expected by valuePredicate.
+func valuePattern(value string, opts model.AssetLinkSearchOptions) string {
+	if isPlainEquality(opts) {
+		return value
+	}
+	pattern := likeEscaper.Replace(value)
+	switch opts.MatchMode {
+	case model.ASSETLINKMATCHMODE_PREFIX:
+		return pattern + "%"
+	case model.ASSETLINKMATCHMODE_CONTAINS:
+		return "%" + pattern + "%"
+	default:
+		return pattern
+	}
+}
+
+func isPlainEquality(opts model.AssetLinkSearchOptions) bool {
+	exact := opts.MatchMode == "" || opts.MatchMode == model.ASSETLINKMATCHMODE_EXACT
+	return exact && !opts.CaseInsensitive
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// importBatchSize is the number of records written per transaction during a bulk import.
+const importBatchSize = 1000
+
+// ImportAssetLinks streams records from dec into the database. Each record replaces the
+// asset links stored for its aasId, like CreateAllAssetLinks does for a single shell. If an
+// aasId occurs more than once in the stream, later occurrences extend the earlier ones.
+//
+// Records are written in batches with COPY. When a batch fails, its records are retried
+// one by one so that a single bad record only shows up in the report. The returned error
+// is set only if the import could not continue at all.
+func (p *PostgreSQLDiscoveryDatabase) ImportAssetLinks(ctx context.Context, dec bulk.Decoder) (bulk.Report, error) {
+	var report bulk.Report
+	seen := make(map[string]struct{})
+	batch := make([]bulk.Record, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch = batch[:0] }()
+
+		err := p.importBatch(ctx, batch, seen)
+		if err == nil {
+			report.Imported += len(batch)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		for _, rec := range batch {
+			if err := p.importBatch(ctx, []bulk.Record{rec}, seen); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				slog.ErrorContext(ctx, "Failed to import asset links", "aasId", rec.AASID, "error", err)
+				report.Fail(bulk.RowError{Line: rec.Line, AASID: rec.AASID, Message: "failed to store asset links: " + err.Error()})
+				continue
+			}
+			report.Imported++
+		}
+		return nil
+	}
+
+	for {
+		rec, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if rowErr, ok := bulk.AsRowError(err); ok {
+			report.Fail(*rowErr)
+			continue
+		}
+		if err != nil {
+			return report, common.NewErrBadRequest("Failed to read bulk input: " + err.Error())
+		}
+
+		batch = append(batch, rec)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// importBatch writes the records in one transaction. Links of aasIds not yet in seen are
+// replaced; seen is only updated once the transaction has been committed.
+func (p *PostgreSQLDiscoveryDatabase) importBatch(ctx context.Context, batch []bulk.Record, seen map[string]struct{}) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		metrics.TransactionBeginFailures.Inc()
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]string, 0, len(batch))
+	for _, rec := range batch {
+		ids = append(ids, rec.AASID)
+	}
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO aas_identifier (aasId)
+		SELECT DISTINCT unnest($1::text[])
+		ON CONFLICT (aasId) DO UPDATE SET aasId = EXCLUDED.aasId
+		RETURNING id, aasId`, ids)
+	if err != nil {
+		return err
+	}
+	refs := make(map[string]int64, len(batch))
+	for rows.Next() {
+		var (
+			id    int64
+			aasID string
+		)
+		if err := rows.Scan(&id, &aasID); err != nil {
+			rows.Close()
+			return err
+		}
+		refs[aasID] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var replace []int64
+	for aasID, id := range refs {
+		if _, ok := seen[aasID]; !ok {
+			replace = append(replace, id)
+		}
+	}
+	if len(replace) > 0 {
+		if _, err := tx.Exec(ctx, `DELETE FROM asset_link WHERE aasRef = ANY($1)`, replace); err != nil {
+			return err
+		}
+	}
+
+	var copyRows [][]any
+	for _, rec := range batch {
+		for _, said := range rec.SpecificAssetIds {
+			row, err := assetLinkRow(said, refs[rec.AASID])
+			if err != nil {
+				return err
+			}
+			copyRows = append(copyRows, row)
+		}
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"asset_link"}, assetLinkColumns, pgx.CopyFromRows(copyRows)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	for aasID := range refs {
+		seen[aasID] = struct{}{}
+	}
+	return nil
+}
+
+// ExportAssetLinks writes all shells with their asset links to enc, ordered by aasId.
+// It returns the number of exported shells.
+func (p *PostgreSQLDiscoveryDatabase) ExportAssetLinks(ctx context.Context, enc bulk.Encoder) (int, error) {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to start postgres transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return 0, common.NewError(common.ErrCodeInternalServerError, "Failed to start postgres transaction.", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT ai.aasId, al.name, al.value, al.external_subject_id, al.semantic_id, al.supplemental_semantic_ids
+		FROM aas_identifier ai
+		LEFT JOIN asset_link al ON al.aasRef = ai.id
+		ORDER BY ai.aasId, al.id`)
+	if err != nil {
+		slog.ErrorContext(ctx, "ExportAssetLinks: query error", "error", err)
+		return 0, common.NewError(common.ErrCodeInternalServerError, "Failed to query asset links.", err)
+	}
+	defer rows.Close()
+
+	exported := 0
+	var current *bulk.Record
+	emit := func() error {
+		if current == nil {
+			return nil
+		}
+		exported++
+		return enc.Encode(*current)
+	}
+
+	for rows.Next() {
+		var (
+			aasID                                       string
+			name, value                                 *string
+			externalSubjectID, semanticID, supplemental []byte
+		)
+		if err := rows.Scan(&aasID, &name, &value, &externalSubjectID, &semanticID, &supplemental); err != nil {
+			slog.ErrorContext(ctx, "ExportAssetLinks: scan error", "error", err)
+			return exported, common.NewError(common.ErrCodeInternalServerError, "Failed to scan asset link.", err)
+		}
+		if current == nil || current.AASID != aasID {
+			if err := emit(); err != nil {
+				return exported, err
+			}
+			current = &bulk.Record{AASID: aasID, SpecificAssetIds: []model.SpecificAssetId{}}
+		}
+		if name == nil {
+			// shell without asset links
+			continue
+		}
+
+		said := model.SpecificAssetId{Name: *name, Value: *value}
+		if err := unmarshalNullableJSON(externalSubjectID, &said.ExternalSubjectId); err != nil {
+			return exported, common.NewError(common.ErrCodeInternalServerError, "Failed to decode externalSubjectId of asset link.", err)
+		}
+		if err := unmarshalNullableJSON(semanticID, &said.SemanticId); err != nil {
+			return exported, common.NewError(common.ErrCodeInternalServerError, "Failed to decode semanticId of asset link.", err)
+		}
+		if err := unmarshalNullableJSON(supplemental, &said.SupplementalSemanticIds); err != nil {
+			return exported, common.NewError(common.ErrCodeInternalServerError, "Failed to decode supplementalSemanticIds of asset link.", err)
+		}
+		current.SpecificAssetIds = append(current.SpecificAssetIds, said)
+	}
+	if rows.Err() != nil {
+		slog.ErrorContext(ctx, "ExportAssetLinks: rows error", "error", rows.Err())
+		return exported, common.NewError(common.ErrCodeInternalServerError, "Failed to iterate asset links.", rows.Err())
+	}
+	if err := emit(); err != nil {
+		return exported, err
+	}
+	return exported, enc.Flush()
+}
@@ -0,0 +1,225 @@
+// Package persistence_inmemory provides a non-persistent Discovery Service backend for
+// demos and tests. It mirrors the behaviour of the PostgreSQL backend, including
+// search modes and cursor paging, but all data is lost when the process exits.
+package persistence_inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
+)
+
+type InMemoryDiscoveryDatabase struct {
+	mu    sync.RWMutex
+	links map[string][]model.SpecificAssetId
+}
+
+func NewInMemoryDiscoveryBackend() *InMemoryDiscoveryDatabase {
+	return &InMemoryDiscoveryDatabase{links: make(map[string][]model.SpecificAssetId)}
+}
+
+func (m *InMemoryDiscoveryDatabase) GetAllAssetLinks(ctx context.Context, aasID string) ([]model.SpecificAssetId, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	links, ok := m.links[aasID]
+	if !ok {
+		return nil, common.NewErrNotFound("AAS identifier '" + aasID + "'")
+	}
+	return copyLinks(links)
+}
+
+func (m *InMemoryDiscoveryDatabase) DeleteAllAssetLinks(ctx context.Context, aasID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.links[aasID]; !ok {
+		return common.NewErrNotFound(fmt.Sprintf("AAS identifier %s not found", aasID))
+	}
+	delete(m.links, aasID)
+	return nil
+}
+
+func (m *InMemoryDiscoveryDatabase) CreateAllAssetLinks(ctx context.Context, aasID string, specificAssetIds []model.SpecificAssetId) error {
+	links, err := copyLinks(specificAssetIds)
+	if err != nil {
+		return common.NewInternalServerError("Failed to store asset links: " + err.Error())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.links[aasID] = links
+	return nil
+}
+
+func (m *InMemoryDiscoveryDatabase) SearchAASIDsByAssetLinks(
+	ctx context.Context,
+	links []model.AssetLink,
+	opts model.AssetLinkSearchOptions,
+	limit int32,
+	cursor string,
+) ([]string, string, error) {
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	m.mu.RLock()
+	ids := make([]string, 0, len(m.links))
+	for aasID, stored := range m.links {
+		if cursor != "" && aasID < cursor {
+			continue
+		}
+		if len(links) == 0 || matches(stored, links, opts) {
+			ids = append(ids, aasID)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(ids)
+	if len(ids) > int(limit) {
+		return ids[:limit], ids[limit], nil
+	}
+	return ids, "", nil
+}
+
+func (m *InMemoryDiscoveryDatabase) ImportAssetLinks(ctx context.Context, dec bulk.Decoder) (bulk.Report, error) {
+	var report bulk.Report
+	seen := make(map[string]struct{})
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		rec, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		if rowErr, ok := bulk.AsRowError(err); ok {
+			report.Fail(*rowErr)
+			continue
+		}
+		if err != nil {
+			return report, common.NewErrBadRequest("Failed to read bulk input: " + err.Error())
+		}
+
+		links, err := copyLinks(rec.SpecificAssetIds)
+		if err != nil {
+			report.Fail(bulk.RowError{Line: rec.Line, AASID: rec.AASID, Message: "failed to store asset links: " + err.Error()})
+			continue
+		}
+
+		m.mu.Lock()
+		if _, ok := seen[rec.AASID]; ok {
+			m.links[rec.AASID] = append(m.links[rec.AASID], links...)
+		} else {
+			m.links[rec.AASID] = links
+			seen[rec.AASID] = struct{}{}
+		}
+		m.mu.Unlock()
+		report.Imported++
+	}
+}
+
+func (m *InMemoryDiscoveryDatabase) ExportAssetLinks(ctx context.Context, enc bulk.Encoder) (int, error) {
+	// Writers are blocked for the whole export so that it is a consistent snapshot.
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make([]bulk.Record, 0, len(m.links))
+	for aasID, links := range m.links {
+		records = append(records, bulk.Record{AASID: aasID, SpecificAssetIds: links})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].AASID < records[j].AASID })
+
+	for i, rec := range records {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := enc.Encode(rec); err != nil {
+			return i, err
+		}
+	}
+	return len(records), enc.Flush()
+}
+
+// matches reports whether the stored links of a shell satisfy the searched links.
+func matches(stored []model.SpecificAssetId, searched []model.AssetLink, opts model.AssetLinkSearchOptions) bool {
+	for _, want := range searched {
+		found := false
+		for _, have := range stored {
+			if linkMatches(have, want, opts) {
+				found = true
+				break
+			}
+		}
+		if found && opts.MatchAny {
+			return true
+		}
+		if !found && !opts.MatchAny {
+			return false
+		}
+	}
+	return !opts.MatchAny
+}
+
+func linkMatches(have model.SpecificAssetId, want model.AssetLink, opts model.AssetLinkSearchOptions) bool {
+	if have.Name != want.Name || !valueMatches(have.Value, want.Value, opts) {
+		return false
+	}
+	if want.ExternalSubjectId == nil {
+		return true
+	}
+	return have.ExternalSubjectId != nil && keysEqual(have.ExternalSubjectId.Keys, want.ExternalSubjectId.Keys)
+}
+
+func valueMatches(have string, want string, opts model.AssetLinkSearchOptions) bool {
+	if opts.CaseInsensitive {
+		have, want = strings.ToLower(have), strings.ToLower(want)
+	}
+	switch opts.MatchMode {
+	case model.ASSETLINKMATCHMODE_PREFIX:
+		return strings.HasPrefix(have, want)
+	case model.ASSETLINKMATCHMODE_CONTAINS:
+		return strings.Contains(have, want)
+	default:
+		return have == want
+	}
+}
+
+func keysEqual(a []model.Key, b []model.Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyLinks deep-copies links so callers cannot modify stored references.
+func copyLinks(links []model.SpecificAssetId) ([]model.SpecificAssetId, error) {
+	if links == nil {
+		return []model.SpecificAssetId{}, nil
+	}
+	raw, err := json.Marshal(links)
+	if err != nil {
+		return nil, err
+	}
+	var out []model.SpecificAssetId
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package persistence_inmemory
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
+)
+
+func search(t *testing.T, db *InMemoryDiscoveryDatabase, links []model.AssetLink, opts model.AssetLinkSearchOptions, limit int32, cursor string) ([]string, string) {
+	t.Helper()
+	ids, next, err := db.SearchAASIDsByAssetLinks(context.Background(), links, opts, limit, cursor)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	return ids, next
+}
+
+func TestCreateGetDelete(t *testing.T) {
+	db := NewInMemoryDiscoveryBackend()
+	esid := &model.Reference{Type: model.REFERENCETYPES_EXTERNAL_REFERENCE, Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:company:acme"}}}
+
+	in := []model.SpecificAssetId{{Name: "serialNumber", Value: "SN-1", ExternalSubjectId: esid}}
+	if err := db.CreateAllAssetLinks(context.Background(), "urn:aas:1", in); err != nil {
+		t.Fatal(err)
+	}
+	// stored data must not alias the caller's slice
+	in[0].ExternalSubjectId.Keys[0].Value = "changed"
+
+	got, err := db.GetAllAssetLinks(context.Background(), "urn:aas:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ExternalSubjectId.Keys[0].Value != "urn:company:acme" {
+		t.Fatalf("unexpected links: %+v", got)
+	}
+
+	if err := db.DeleteAllAssetLinks(context.Background(), "urn:aas:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.GetAllAssetLinks(context.Background(), "urn:aas:1"); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if err := db.DeleteAllAssetLinks(context.Background(), "urn:aas:1"); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found on second delete, got %v", err)
+	}
+}
+
+func TestSearchModesAndPaging(t *testing.T) {
+	db := NewInMemoryDiscoveryBackend()
+	acme := &model.Reference{Type: model.REFERENCETYPES_EXTERNAL_REFERENCE, Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:company:acme"}}}
+	globex := &model.Reference{Type: model.REFERENCETYPES_EXTERNAL_REFERENCE, Keys: []model.Key{{Type: model.KEYTYPES_GLOBAL_REFERENCE, Value: "urn:company:globex"}}}
+
+	_ = db.CreateAllAssetLinks(context.Background(), "urn:aas:a", []model.SpecificAssetId{{Name: "pn", Value: "PN-Alpha", ExternalSubjectId: acme}, {Name: "line", Value: "L1"}})
+	_ = db.CreateAllAssetLinks(context.Background(), "urn:aas:b", []model.SpecificAssetId{{Name: "pn", Value: "PN-alpha-2", ExternalSubjectId: globex}})
+	_ = db.CreateAllAssetLinks(context.Background(), "urn:aas:c", []model.SpecificAssetId{{Name: "pn", Value: "XX-BETA"}, {Name: "line", Value: "L1"}})
+
+	tests := []struct {
+		name  string
+		links []model.AssetLink
+		opts  model.AssetLinkSearchOptions
+		want  []string
+	}{
+		{"exact", []model.AssetLink{{Name: "pn", Value: "PN-Alpha"}}, model.AssetLinkSearchOptions{}, []string{"urn:aas:a"}},
+		{"prefix", []model.AssetLink{{Name: "pn", Value: "PN-alpha"}}, model.AssetLinkSearchOptions{MatchMode: model.ASSETLINKMATCHMODE_PREFIX}, []string{"urn:aas:b"}},
+		{"prefix case-insensitive", []model.AssetLink{{Name: "pn", Value: "pn-"}}, model.AssetLinkSearchOptions{MatchMode: model.ASSETLINKMATCHMODE_PREFIX, CaseInsensitive: true}, []string{"urn:aas:a", "urn:aas:b"}},
+		{"contains", []model.AssetLink{{Name: "pn", Value: "beta"}}, model.AssetLinkSearchOptions{MatchMode: model.ASSETLINKMATCHMODE_CONTAINS, CaseInsensitive: true}, []string{"urn:aas:c"}},
+		{"all-of", []model.AssetLink{{Name: "pn", Value: "PN-Alpha"}, {Name: "line", Value: "L1"}}, model.AssetLinkSearchOptions{}, []string{"urn:aas:a"}},
+		{"any-of", []model.AssetLink{{Name: "pn", Value: "PN-alpha-2"}, {Name: "line", Value: "L1"}}, model.AssetLinkSearchOptions{MatchAny: true}, []string{"urn:aas:a", "urn:aas:b", "urn:aas:c"}},
+		{"externalSubjectId", []model.AssetLink{{Name: "pn", Value: "pn-alpha", ExternalSubjectId: globex}}, model.AssetLinkSearchOptions{MatchMode: model.ASSETLINKMATCHMODE_PREFIX, CaseInsensitive: true}, []string{"urn:aas:b"}},
+		{"no links lists all", nil, model.AssetLinkSearchOptions{}, []string{"urn:aas:a", "urn:aas:b", "urn:aas:c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next := search(t, db, tt.links, tt.opts, 10, "")
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || next != "" {
+				t.Fatalf("got %v (next %q), want %v", got, next, tt.want)
+			}
+		})
+	}
+
+	page1, next := search(t, db, nil, model.AssetLinkSearchOptions{}, 2, "")
+	if strings.Join(page1, ",") != "urn:aas:a,urn:aas:b" || next != "urn:aas:c" {
+		t.Fatalf("unexpected first page %v next %q", page1, next)
+	}
+	page2, next := search(t, db, nil, model.AssetLinkSearchOptions{}, 2, next)
+	if strings.Join(page2, ",") != "urn:aas:c" || next != "" {
+		t.Fatalf("unexpected second page %v next %q", page2, next)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	db := NewInMemoryDiscoveryBackend()
+	_ = db.CreateAllAssetLinks(context.Background(), "urn:aas:1", []model.SpecificAssetId{{Name: "old", Value: "x"}})
+
+	input := strings.Join([]string{
+		`{"aasId":"urn:aas:1","specificAssetIds":[{"name":"a","value":"1"}]}`,
+		`{"aasId":"urn:aas:2","specificAssetIds":[{"name":"","value":"1"}]}`,
+		`{"aasId":"urn:aas:1","specificAssetIds":[{"name":"b","value":"2"}]}`,
+	}, "\n")
+	report, err := db.ImportAssetLinks(context.Background(), bulk.NewNDJSONDecoder(strings.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 2 || report.Failed != 1 || report.Errors[0].Line != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	links, _ := db.GetAllAssetLinks(context.Background(), "urn:aas:1")
+	if len(links) != 2 || links[0].Name != "a" || links[1].Name != "b" {
+		t.Fatalf("import should replace once and then extend, got %+v", links)
+	}
+
+	var buf bytes.Buffer
+	n, err := db.ExportAssetLinks(context.Background(), bulk.NewNDJSONEncoder(&buf))
+	if err != nil || n != 1 {
+		t.Fatalf("export returned %d, %v", n, err)
+	}
+	if !strings.Contains(buf.String(), `"aasId":"urn:aas:1"`) {
+		t.Fatalf("unexpected export: %s", buf.String())
+	}
+}
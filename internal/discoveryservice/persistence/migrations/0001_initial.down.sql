@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS asset_link;
+DROP TABLE IF EXISTS aas_identifier;
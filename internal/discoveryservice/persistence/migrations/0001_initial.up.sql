@@ -7,7 +7,7 @@ CREATE TABLE IF NOT EXISTS asset_link (
     id          BIGSERIAL PRIMARY KEY,
     name        VARCHAR(64) NOT NULL,
     value       VARCHAR(64) NOT NULL,
-    aasRef      BIGSERIAL REFERENCES aas_identifier(id) ON DELETE CASCADE       
+    aasRef      BIGSERIAL REFERENCES aas_identifier(id) ON DELETE CASCADE
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_aas_identifier_aasid
@@ -0,0 +1,6 @@
+-- Fails if a stored name or value is longer than the former limit of 64 characters.
+ALTER TABLE asset_link DROP COLUMN IF EXISTS supplemental_semantic_ids;
+ALTER TABLE asset_link DROP COLUMN IF EXISTS semantic_id;
+ALTER TABLE asset_link DROP COLUMN IF EXISTS external_subject_id;
+ALTER TABLE asset_link ALTER COLUMN value TYPE VARCHAR(64);
+ALTER TABLE asset_link ALTER COLUMN name TYPE VARCHAR(64);
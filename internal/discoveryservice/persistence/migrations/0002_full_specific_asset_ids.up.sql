@@ -0,0 +1,6 @@
+-- Store complete SpecificAssetIds instead of name/value pairs only.
+ALTER TABLE asset_link ALTER COLUMN name TYPE TEXT;
+ALTER TABLE asset_link ALTER COLUMN value TYPE TEXT;
+ALTER TABLE asset_link ADD COLUMN IF NOT EXISTS external_subject_id JSONB;
+ALTER TABLE asset_link ADD COLUMN IF NOT EXISTS semantic_id JSONB;
+ALTER TABLE asset_link ADD COLUMN IF NOT EXISTS supplemental_semantic_ids JSONB;
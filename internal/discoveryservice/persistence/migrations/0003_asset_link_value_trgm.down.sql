@@ -0,0 +1,2 @@
+-- pg_trgm is kept, other schemas in the same database may use it.
+DROP INDEX IF EXISTS idx_asset_link_value_trgm;
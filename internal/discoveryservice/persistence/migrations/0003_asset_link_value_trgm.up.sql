@@ -0,0 +1,5 @@
+-- Trigram index backing prefix, substring and case-insensitive value search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm SCHEMA public;
+
+CREATE INDEX IF NOT EXISTS idx_asset_link_value_trgm
+    ON asset_link USING GIN (value public.gin_trgm_ops);
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// submodelResource is the access control object of a submodel.
+func submodelResource(sm gen.Submodel) abac.Resource {
+	res := abac.Resource{SubmodelID: sm.Id}
+	if sm.SemanticId != nil && len(sm.SemanticId.Keys) > 0 {
+		res.SemanticID = sm.SemanticId.Keys[0].Value
+	}
+	return res
+}
+
+// resource returns the access control object of the stored submodel with the given id. The
+// submodel is only read if rules refer to semanticIds.
+func (s *SubmodelRepositoryAPIAPIService) resource(ctx context.Context, submodelId string) (abac.Resource, error) {
+	if !s.enforcer.NeedsSemanticID() {
+		return abac.Resource{SubmodelID: submodelId}, nil
+	}
+	sm, err := s.submodelBackend.GetSubmodel(ctx, submodelId)
+	if err != nil {
+		return abac.Resource{}, err
+	}
+	return submodelResource(sm), nil
+}
+
+// authorize checks right on the stored submodel with the given id, or on the element at
+// idShortPath if it is not empty.
+func (s *SubmodelRepositoryAPIAPIService) authorize(ctx context.Context, right abac.Right, submodelId string, idShortPath string) error {
+	if s.enforcer == nil {
+		return nil
+	}
+	res, err := s.resource(ctx, submodelId)
+	if err != nil {
+		return err
+	}
+	res.IdShortPath = idShortPath
+	return s.enforcer.Check(ctx, right, res)
+}
+
+// childPath is the idShortPath of a new element with idShort below parent.
+func childPath(parent string, idShort string) string {
+	switch {
+	case parent == "":
+		return idShort
+	case idShort == "":
+		return parent
+	}
+	return parent + "." + idShort
+}
+
+// readable returns sm without the elements the subject of ctx may not read, and false if it
+// may not read the submodel at all. sm is not modified, it may be shared with the cache.
+func (s *SubmodelRepositoryAPIAPIService) readable(ctx context.Context, sm gen.Submodel) (gen.Submodel, bool) {
+	if s.enforcer == nil {
+		return sm, true
+	}
+	res := submodelResource(sm)
+	if !s.enforcer.AllowedFor(ctx, abac.RightRead, res) {
+		return sm, false
+	}
+	sm.SubmodelElements = s.readableElements(ctx, res, sm.SubmodelElements, "")
+	return sm, true
+}
+
+// readableElements filters the children of the element at the idShortPath prefix, "" for
+// the submodel, and their descendants.
+func (s *SubmodelRepositoryAPIAPIService) readableElements(ctx context.Context, res abac.Resource, elements []gen.SubmodelElement, prefix string) []gen.SubmodelElement {
+	if elements == nil {
+		return nil
+	}
+	filtered := make([]gen.SubmodelElement, 0, len(elements))
+	for _, el := range elements {
+		path := el.GetIdShort()
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if el, ok := s.readableElement(ctx, res, el, path); ok {
+			filtered = append(filtered, el)
+		}
+	}
+	return filtered
+}
+
+// readableListElements is readableElements for the values of a list, which are addressed by
+// their index.
+func (s *SubmodelRepositoryAPIAPIService) readableListElements(ctx context.Context, res abac.Resource, elements []gen.SubmodelElement, prefix string) []gen.SubmodelElement {
+	if elements == nil {
+		return nil
+	}
+	filtered := make([]gen.SubmodelElement, 0, len(elements))
+	for i, el := range elements {
+		if el, ok := s.readableElement(ctx, res, el, prefix+"["+strconv.Itoa(i)+"]"); ok {
+			filtered = append(filtered, el)
+		}
+	}
+	return filtered
+}
+
+// readableElement returns a copy of el at path with the readable values, false if el itself
+// may not be read.
+func (s *SubmodelRepositoryAPIAPIService) readableElement(ctx context.Context, res abac.Resource, el gen.SubmodelElement, path string) (gen.SubmodelElement, bool) {
+	res.IdShortPath = path
+	if !s.enforcer.AllowedFor(ctx, abac.RightRead, res) {
+		return nil, false
+	}
+	switch v := el.(type) {
+	case *gen.SubmodelElementCollection:
+		c := *v
+		c.Value = s.readableElements(ctx, res, v.Value, path)
+		return &c, true
+	case *gen.SubmodelElementList:
+		l := *v
+		l.Value = s.readableListElements(ctx, res, v.Value, path)
+		return &l, true
+	case *gen.Entity:
+		e := *v
+		e.Statements = s.readableElements(ctx, res, v.Statements, path)
+		return &e, true
+	case *gen.AnnotatedRelationshipElement:
+		a := *v
+		a.Annotations = s.readableElements(ctx, res, v.Annotations, path)
+		return &a, true
+	case *gen.Operation:
+		o := *v
+		o.InputVariables = s.readableVariables(ctx, res, v.InputVariables, path)
+		o.OutputVariables = s.readableVariables(ctx, res, v.OutputVariables, path)
+		o.InoutputVariables = s.readableVariables(ctx, res, v.InoutputVariables, path)
+		return &o, true
+	}
+	return el, true
+}
+
+// readableVariables is readableElements for the variables of an operation, which are
+// addressed by the idShort of their value.
+func (s *SubmodelRepositoryAPIAPIService) readableVariables(ctx context.Context, res abac.Resource, variables []gen.OperationVariable, prefix string) []gen.OperationVariable {
+	if variables == nil {
+		return nil
+	}
+	filtered := make([]gen.OperationVariable, 0, len(variables))
+	for _, v := range variables {
+		if v.Value == nil {
+			filtered = append(filtered, v)
+			continue
+		}
+		if el, ok := s.readableElement(ctx, res, v.Value, childPath(prefix, v.Value.GetIdShort())); ok {
+			filtered = append(filtered, gen.OperationVariable{Value: el})
+		}
+	}
+	return filtered
+}
+
+// checkRead returns a Forbidden error unless the subject of ctx may read the submodel of res
+// and the element at idShortPath with all its ancestors, as readable would return it.
+func (s *SubmodelRepositoryAPIAPIService) checkRead(ctx context.Context, res abac.Resource, idShortPath string) error {
+	for _, path := range append([]string{""}, pathPrefixes(idShortPath)...) {
+		res.IdShortPath = path
+		if err := s.enforcer.Check(ctx, abac.RightRead, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pathPrefixes returns the idShortPaths of the ancestors of the element at idShortPath and
+// idShortPath itself, the outermost first, e.g. a, a.b and a.b[0] for a.b[0].
+func pathPrefixes(idShortPath string) []string {
+	var paths []string
+	for i := 1; i < len(idShortPath); i++ {
+		if idShortPath[i] == '.' || idShortPath[i] == '[' {
+			paths = append(paths, idShortPath[:i])
+		}
+	}
+	if idShortPath != "" {
+		paths = append(paths, idShortPath)
+	}
+	return paths
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// accessBackend serves a single submodel.
+type accessBackend struct {
+	SubmodelBackend
+	sm gen.Submodel
+}
+
+func (b accessBackend) GetSubmodel(ctx context.Context, id string) (gen.Submodel, error) {
+	return b.sm, nil
+}
+
+func (b accessBackend) GetSubmodelElements(ctx context.Context, submodelId string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
+	return b.sm.SubmodelElements, "", nil
+}
+
+func TestReadableRemovesDeniedStatementsAnnotationsAndVariables(t *testing.T) {
+	enforcer, err := abac.New([]abac.Rule{
+		{Rights: []abac.Right{abac.RightRead}},
+		{Objects: []abac.Object{{IdShortPath: "entity.secret"}, {IdShortPath: "relationship.secret"}, {IdShortPath: "operation.secret"}},
+			Rights: []abac.Right{abac.RightRead}, Effect: abac.EffectDeny},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	property := func(idShort string) *gen.Property {
+		return &gen.Property{IdShort: idShort, ModelType: "Property", ValueType: gen.DATATYPEDEFXSD_XS_STRING}
+	}
+	sm := gen.Submodel{Id: "urn:sm", SubmodelElements: []gen.SubmodelElement{
+		&gen.Entity{IdShort: "entity", ModelType: "Entity", Statements: []gen.SubmodelElement{property("public"), property("secret")}},
+		&gen.AnnotatedRelationshipElement{IdShort: "relationship", ModelType: "AnnotatedRelationshipElement", Annotations: []gen.SubmodelElement{property("public"), property("secret")}},
+		&gen.Operation{IdShort: "operation", ModelType: "Operation",
+			InputVariables:  []gen.OperationVariable{{Value: property("public")}, {Value: property("secret")}},
+			OutputVariables: []gen.OperationVariable{{Value: property("secret")}}},
+	}}
+	svc := NewSubmodelRepositoryAPIAPIService(accessBackend{sm: sm}, enforcer)
+	ctx := auth.WithClaims(context.Background(), auth.Claims{"sub": "analyst"})
+
+	res, err := svc.GetSubmodelById(ctx, base64.RawStdEncoding.EncodeToString([]byte(sm.Id)), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := res.Body.(gen.Submodel).SubmodelElements
+	public := []gen.SubmodelElement{property("public")}
+	if statements := got[0].(*gen.Entity).Statements; !reflect.DeepEqual(statements, public) {
+		t.Errorf("statements = %+v, want only the public one", statements)
+	}
+	if annotations := got[1].(*gen.AnnotatedRelationshipElement).Annotations; !reflect.DeepEqual(annotations, public) {
+		t.Errorf("annotations = %+v, want only the public one", annotations)
+	}
+	op := got[2].(*gen.Operation)
+	if !reflect.DeepEqual(op.InputVariables, []gen.OperationVariable{{Value: property("public")}}) || len(op.OutputVariables) != 0 {
+		t.Errorf("variables = %+v / %+v, want only the public input", op.InputVariables, op.OutputVariables)
+	}
+	// the stored submodel is not modified
+	if len(sm.SubmodelElements[0].(*gen.Entity).Statements) != 2 {
+		t.Error("the filter modified the stored submodel")
+	}
+}
+
+func TestGetAllSubmodelElementsChecksTheSubmodel(t *testing.T) {
+	enforcer, err := abac.New([]abac.Rule{
+		{Objects: []abac.Object{{IdShortPath: "public"}}, Rights: []abac.Right{abac.RightRead}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := gen.Submodel{Id: "urn:sm", SubmodelElements: []gen.SubmodelElement{&gen.Property{IdShort: "public", ModelType: "Property"}}}
+	svc := NewSubmodelRepositoryAPIAPIService(accessBackend{sm: sm}, enforcer)
+	ctx := auth.WithClaims(context.Background(), auth.Claims{"sub": "analyst"})
+
+	res, err := svc.GetAllSubmodelElements(ctx, base64.RawStdEncoding.EncodeToString([]byte(sm.Id)), 10, "", "", "")
+	if e, ok := common.AsError(err); res.Code != http.StatusForbidden || !ok || e.Code != common.ErrCodeForbidden {
+		t.Errorf("expected 403 without READ on the submodel, got %d: %v", res.Code, err)
+	}
+}
+
+func TestPathPrefixes(t *testing.T) {
+	for path, want := range map[string][]string{
+		"":           nil,
+		"a":          {"a"},
+		"a.b[0].c":   {"a", "a.b", "a.b[0]", "a.b[0].c"},
+		"list[1][2]": {"list", "list[1]", "list[1][2]"},
+	} {
+		if got := pathPrefixes(path); !reflect.DeepEqual(got, want) {
+			t.Errorf("pathPrefixes(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// AggregateSubmodels - Computes aggregates of a property over all submodels with a semanticId
+func (s *SubmodelRepositoryAPIAPIService) AggregateSubmodels(ctx context.Context, req aggregate.Request) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.AggregateSubmodels")
+	defer span.End()
+
+	aggregator, ok := s.submodelBackend.(SubmodelAggregator)
+	if !ok {
+		return gen.Response(http.StatusNotImplemented, nil), common.NewError(common.ErrCodeNotImplemented, "Aggregations require the PostgreSQL backend", nil)
+	}
+	if err := req.Validate(); err != nil {
+		return gen.Response(http.StatusBadRequest, nil), err
+	}
+
+	// With access rules only the submodels whose aggregated and grouping properties the
+	// caller may read count.
+	var ids []string
+	if s.enforcer != nil {
+		all, err := aggregator.AggregatedSubmodelIDs(ctx, req)
+		if err != nil {
+			return gen.Response(http.StatusInternalServerError, nil), err
+		}
+		ids = make([]string, 0, len(all))
+		for _, id := range all {
+			if s.aggregationReadable(ctx, req, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	result, err := aggregator.Aggregate(ctx, req, ids)
+	if err != nil {
+		return gen.Response(http.StatusInternalServerError, nil), err
+	}
+	return gen.Response(200, result), nil
+}
+
+// aggregationReadable reports whether the subject of ctx may read the properties of the
+// submodel with id that req aggregates and groups by. The aggregated submodels have the
+// semanticId of req, so their access control objects are known without reading them.
+func (s *SubmodelRepositoryAPIAPIService) aggregationReadable(ctx context.Context, req aggregate.Request, id string) bool {
+	res := abac.Resource{SubmodelID: id, SemanticID: req.SemanticID}
+	paths := []string{"", req.IdShortPath}
+	if req.GroupBy != "" {
+		paths = append(paths, req.GroupBy)
+	}
+	for _, path := range paths {
+		res.IdShortPath = path
+		if !s.enforcer.AllowedFor(ctx, abac.RightRead, res) {
+			return false
+		}
+	}
+	return true
+}
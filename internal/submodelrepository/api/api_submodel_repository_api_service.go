@@ -13,30 +13,35 @@ package api
 
 import (
 	"context"
-	"database/sql"
 	"encoding/base64"
 	"errors"
-	"fmt"
 	"net/http"
 	"os"
 
-	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	persistence "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
 )
 
+const tracerName = "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/api"
+
 // SubmodelRepositoryAPIAPIService is a service that implements the logic for the SubmodelRepositoryAPIAPIServicer
 // This service should implement the business logic for every endpoint for the SubmodelRepositoryAPIAPI API.
 // Include any external packages or services that will be required by this service.
+// Errors of the backend are returned unchanged, common.HandleError reports them to the client.
 
 type SubmodelRepositoryAPIAPIService struct {
-	submodelBackend persistence.PostgreSQLSubmodelDatabase
+	submodelBackend SubmodelBackend
+	// enforcer checks the access rules; nil disables access control.
+	enforcer *abac.Enforcer
 }
 
-// NewSubmodelRepositoryAPIAPIService creates a default api service
-func NewSubmodelRepositoryAPIAPIService(databaseBackend persistence.PostgreSQLSubmodelDatabase) *SubmodelRepositoryAPIAPIService {
+// NewSubmodelRepositoryAPIAPIService creates a default api service. Requests are checked
+// against the access rules of enforcer, which may be nil.
+func NewSubmodelRepositoryAPIAPIService(databaseBackend SubmodelBackend, enforcer *abac.Enforcer) *SubmodelRepositoryAPIAPIService {
 	return &SubmodelRepositoryAPIAPIService{
 		submodelBackend: databaseBackend,
+		enforcer:        enforcer,
 	}
 }
 
@@ -50,9 +55,20 @@ func (s *SubmodelRepositoryAPIAPIService) GetAllSubmodels(
 	level string,
 	extent string,
 ) (gen.ImplResponse, error) {
-	sms, nextCursor, err := s.submodelBackend.GetAllSubmodels(limit, cursor, idShort)
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.GetAllSubmodels")
+	defer span.End()
+
+	sms, nextCursor, err := s.submodelBackend.GetAllSubmodels(ctx, limit, cursor, idShort)
 	if err != nil {
-		return gen.Response(500, nil), err
+		return gen.Response(http.StatusInternalServerError, nil), err
+	}
+
+	// Submodels the caller may not read are left out, the cursor still continues after them.
+	readable := make([]gen.Submodel, 0, len(sms))
+	for _, sm := range sms {
+		if sm, ok := s.readable(ctx, sm); ok {
+			readable = append(readable, sm)
+		}
 	}
 
 	// using the openAPI provided response struct to include paging metadata
@@ -60,7 +76,7 @@ func (s *SubmodelRepositoryAPIAPIService) GetAllSubmodels(
 		PagingMetadata: gen.PagedResultPagingMetadata{
 			Cursor: nextCursor,
 		},
-		Result: sms,
+		Result: readable,
 	}
 	return gen.Response(200, res), nil
 }
@@ -72,20 +88,21 @@ func (s *SubmodelRepositoryAPIAPIService) GetSubmodelById(
 	level string,
 	extent string,
 ) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.GetSubmodelById")
+	defer span.End()
+
 	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(id)
 	if decodeErr != nil {
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
-	sm, err := s.submodelBackend.GetSubmodel(string(decodedSubmodelIdentifier))
+	sm, err := s.submodelBackend.GetSubmodel(ctx, string(decodedSubmodelIdentifier))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return gen.Response(404, nil), nil
-		}
-		if common.IsErrNotFound(err) {
-			return gen.Response(404, nil), nil
-		}
-		return gen.Response(500, nil), err
+		return gen.Response(http.StatusInternalServerError, nil), err
 	}
+	if err := s.enforcer.Check(ctx, abac.RightRead, submodelResource(sm)); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	sm, _ = s.readable(ctx, sm)
 	return gen.Response(200, sm), nil
 }
 
@@ -94,16 +111,19 @@ func (s *SubmodelRepositoryAPIAPIService) DeleteSubmodelById(
 	ctx context.Context,
 	id string,
 ) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.DeleteSubmodelById")
+	defer span.End()
+
 	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(id)
 	if decodeErr != nil {
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
-	err := s.submodelBackend.DeleteSubmodel(string(decodedSubmodelIdentifier))
+	if err := s.authorize(ctx, abac.RightDelete, string(decodedSubmodelIdentifier), ""); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	err := s.submodelBackend.DeleteSubmodel(ctx, string(decodedSubmodelIdentifier))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return gen.Response(404, nil), nil
-		}
-		return gen.Response(500, nil), err
+		return gen.Response(http.StatusInternalServerError, nil), err
 	}
 	return gen.Response(204, nil), nil
 }
@@ -113,10 +133,15 @@ func (s *SubmodelRepositoryAPIAPIService) PostSubmodel(
 	ctx context.Context,
 	submodel gen.Submodel,
 ) (gen.ImplResponse, error) {
-	err := s.submodelBackend.CreateSubmodel(submodel)
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.PostSubmodel")
+	defer span.End()
+
+	if err := s.enforcer.Check(ctx, abac.RightCreate, submodelResource(submodel)); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	err := s.submodelBackend.CreateSubmodel(ctx, submodel)
 	if err != nil {
-		fmt.Println("Error creating submodel: " + err.Error())
-		return gen.Response(500, nil), err
+		return gen.Response(http.StatusInternalServerError, nil), err
 	}
 	// According to REST convention, return 201 Created + the created resource
 	return gen.Response(201, submodel), nil
@@ -492,35 +517,32 @@ func (s *SubmodelRepositoryAPIAPIService) GetSubmodelByIdPath(ctx context.Contex
 
 // GetAllSubmodelElements - Returns all submodel elements including their hierarchy
 func (s *SubmodelRepositoryAPIAPIService) GetAllSubmodelElements(ctx context.Context, submodelIdentifier string, limit int32, cursor string, level string, extent string) (gen.ImplResponse, error) {
-	// TODO: Authorization logic to be implemented
-	// return gen.Response(401, Result{}), nil
-	// return gen.Response(403, Result{}), nil
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.GetAllSubmodelElements")
+	defer span.End()
 
 	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
 	if decodeErr != nil {
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
 
-	sme, cursor, err := s.submodelBackend.GetSubmodelElements(string(decodedSubmodelIdentifier), int(limit), cursor)
-	if err != nil {
-		if common.IsErrNotFound(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusNotFound, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "404", "SMREPO-GetAllSubmodelElements-404-NotFound", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-GetAllSubmodelElements-400-BadRequest", string(timestamp))}), nil
-		}
-		if common.IsInternalServerError(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusInternalServerError, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "500", "SMREPO-GetAllSubmodelElements-500-InternalServerError", string(timestamp))}), nil
+	var sub abac.Resource
+	if s.enforcer != nil {
+		var err error
+		sub, err = s.resource(ctx, string(decodedSubmodelIdentifier))
+		if err != nil {
+			return gen.Response(http.StatusInternalServerError, nil), err
 		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-GetAllSubmodelElements-400-BadRequest", string(timestamp))}), nil
+		if err := s.enforcer.Check(ctx, abac.RightRead, sub); err != nil {
+			return gen.Response(http.StatusForbidden, nil), err
 		}
+	}
+	sme, cursor, err := s.submodelBackend.GetSubmodelElements(ctx, string(decodedSubmodelIdentifier), int(limit), cursor)
+	if err != nil {
 		return gen.Response(http.StatusInternalServerError, nil), err
 	}
+	if s.enforcer != nil {
+		sme = s.readableElements(ctx, sub, sme, "")
+	}
 	res := gen.GetSubmodelElementsResult{
 		PagingMetadata: gen.PagedResultPagingMetadata{
 			Cursor: cursor,
@@ -533,32 +555,18 @@ func (s *SubmodelRepositoryAPIAPIService) GetAllSubmodelElements(ctx context.Con
 
 // PostSubmodelElementSubmodelRepo - Creates a new submodel element
 func (s *SubmodelRepositoryAPIAPIService) PostSubmodelElementSubmodelRepo(ctx context.Context, submodelIdentifier string, submodelElement gen.SubmodelElement) (gen.ImplResponse, error) {
-	// TODO: Authorization logic to be implemented
-	// return gen.Response(401, Result{}), nil
-	// return gen.Response(403, Result{}), nil
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.PostSubmodelElementSubmodelRepo")
+	defer span.End()
 
 	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
 	if decodeErr != nil {
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
 
-	if err := s.submodelBackend.AddSubmodelElement(string(decodedSubmodelIdentifier), submodelElement); err != nil {
-		if common.IsErrNotFound(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusNotFound, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "404", "SMREPO-PostSubmodelElementSubmodelRepo-404-NotFound", string(timestamp))}), nil
-		}
-		if common.IsErrConflict(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusConflict, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "409", "SMREPO-PostSubmodelElementSubmodelRepo-409-Conflict", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-PostSubmodelElementSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
-		if common.IsInternalServerError(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusInternalServerError, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "500", "SMREPO-PostSubmodelElementSubmodelRepo-500-InternalServerError", string(timestamp))}), nil
-		}
+	if err := s.authorize(ctx, abac.RightCreate, string(decodedSubmodelIdentifier), submodelElement.GetIdShort()); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	if err := s.submodelBackend.AddSubmodelElement(ctx, string(decodedSubmodelIdentifier), submodelElement); err != nil {
 		return gen.Response(http.StatusInternalServerError, nil), err
 	}
 
@@ -683,6 +691,9 @@ func (s *SubmodelRepositoryAPIAPIService) GetAllSubmodelElementsPathSubmodelRepo
 
 // GetSubmodelElementByPathSubmodelRepo - Returns a specific submodel element from the Submodel at a specified path
 func (s *SubmodelRepositoryAPIAPIService) GetSubmodelElementByPathSubmodelRepo(ctx context.Context, submodelIdentifier string, idShortPath string, level string, extent string) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.GetSubmodelElementByPathSubmodelRepo")
+	defer span.End()
+
 	// TODO - update GetSubmodelElementByPathSubmodelRepo with the required logic for this service method.
 	// Add api_submodel_repository_api_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
@@ -712,26 +723,21 @@ func (s *SubmodelRepositoryAPIAPIService) GetSubmodelElementByPathSubmodelRepo(c
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
 
-	sme, err := s.submodelBackend.GetSubmodelElement(string(decodedSubmodelIdentifier), idShortPath, 1, "")
+	sub, err := s.resource(ctx, string(decodedSubmodelIdentifier))
+	if err != nil {
+		return gen.Response(http.StatusInternalServerError, nil), err
+	}
+	if err := s.checkRead(ctx, sub, idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+
+	sme, err := s.submodelBackend.GetSubmodelElement(ctx, string(decodedSubmodelIdentifier), idShortPath, 1, "")
 	if err != nil {
-		if common.IsErrNotFound(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusNotFound, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "404", "SMREPO-GetSubmodelElementByPathSubmodelRepo-404-NotFound", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-GetSubmodelElementByPathSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
-		if common.IsInternalServerError(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusInternalServerError, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "500", "SMREPO-GetSubmodelElementByPathSubmodelRepo-500-InternalServerError", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-GetSubmodelElementByPathSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
 		return gen.Response(http.StatusInternalServerError, nil), err
 	}
+	if s.enforcer != nil {
+		sme, _ = s.readableElement(ctx, sub, sme, idShortPath)
+	}
 
 	return gen.Response(http.StatusOK, sme), nil
 }
@@ -767,6 +773,9 @@ func (s *SubmodelRepositoryAPIAPIService) PutSubmodelElementByPathSubmodelRepo(c
 
 // PostSubmodelElementByPathSubmodelRepo - Creates a new submodel element at a specified path within submodel elements hierarchy
 func (s *SubmodelRepositoryAPIAPIService) PostSubmodelElementByPathSubmodelRepo(ctx context.Context, submodelIdentifier string, idShortPath string, submodelElement gen.SubmodelElement) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.PostSubmodelElementByPathSubmodelRepo")
+	defer span.End()
+
 	// TODO: Uncomment the next line to return response Response(401, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(401, Result{}), nil
 	// TODO: Uncomment the next line to return response Response(403, Result{}) or use other options such as http.Ok ...
@@ -777,23 +786,10 @@ func (s *SubmodelRepositoryAPIAPIService) PostSubmodelElementByPathSubmodelRepo(
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
 
-	if err := s.submodelBackend.AddSubmodelElementWithPath(string(decodedSubmodelIdentifier), idShortPath, submodelElement); err != nil {
-		if common.IsErrNotFound(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusNotFound, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "404", "SMREPO-PostSubmodelElementByPathSubmodelRepo-404-NotFound", string(timestamp))}), nil
-		}
-		if common.IsErrConflict(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusConflict, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "409", "SMREPO-PostSubmodelElementByPathSubmodelRepo-409-Conflict", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-PostSubmodelElementByPathSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
-		if common.IsInternalServerError(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusInternalServerError, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "500", "SMREPO-PostSubmodelElementByPathSubmodelRepo-500-InternalServerError", string(timestamp))}), nil
-		}
+	if err := s.authorize(ctx, abac.RightCreate, string(decodedSubmodelIdentifier), childPath(idShortPath, submodelElement.GetIdShort())); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	if err := s.submodelBackend.AddSubmodelElementWithPath(ctx, string(decodedSubmodelIdentifier), idShortPath, submodelElement); err != nil {
 		return gen.Response(http.StatusInternalServerError, nil), err
 	}
 
@@ -802,6 +798,9 @@ func (s *SubmodelRepositoryAPIAPIService) PostSubmodelElementByPathSubmodelRepo(
 
 // DeleteSubmodelElementByPathSubmodelRepo - Deletes a submodel element at a specified path within the submodel elements hierarchy
 func (s *SubmodelRepositoryAPIAPIService) DeleteSubmodelElementByPathSubmodelRepo(ctx context.Context, submodelIdentifier string, idShortPath string) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.DeleteSubmodelElementByPathSubmodelRepo")
+	defer span.End()
+
 	// TODO: Uncomment the next line to return response Response(401, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(401, Result{}), nil
 
@@ -813,23 +812,10 @@ func (s *SubmodelRepositoryAPIAPIService) DeleteSubmodelElementByPathSubmodelRep
 		return gen.Response(http.StatusBadRequest, nil), decodeErr
 	}
 
-	if err := s.submodelBackend.DeleteSubmodelElementByPath(string(decodedSubmodelIdentifier), idShortPath); err != nil {
-		if common.IsErrNotFound(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusNotFound, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "404", "SMREPO-DeleteSubmodelElementByPathSubmodelRepo-404-NotFound", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-DeleteSubmodelElementByPathSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
-		if common.IsInternalServerError(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusInternalServerError, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "500", "SMREPO-DeleteSubmodelElementByPathSubmodelRepo-500-InternalServerError", string(timestamp))}), nil
-		}
-		if common.IsErrBadRequest(err) {
-			timestamp := common.GetCurrentTimestamp()
-			return gen.Response(http.StatusBadRequest, []common.ErrorHandler{*common.NewErrorHandler("Error", err, "400", "SMREPO-DeleteSubmodelElementByPathSubmodelRepo-400-BadRequest", string(timestamp))}), nil
-		}
+	if err := s.authorize(ctx, abac.RightDelete, string(decodedSubmodelIdentifier), idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+	if err := s.submodelBackend.DeleteSubmodelElementByPath(ctx, string(decodedSubmodelIdentifier), idShortPath); err != nil {
 		return gen.Response(http.StatusInternalServerError, nil), err
 	}
 
@@ -1161,6 +1147,14 @@ func (s *SubmodelRepositoryAPIAPIService) InvokeOperationSubmodelRepo(ctx contex
 	// TODO: Uncomment the next line to return response Response(0, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(0, Result{}), nil
 
+	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
+	if decodeErr != nil {
+		return gen.Response(http.StatusBadRequest, nil), decodeErr
+	}
+	if err := s.authorize(ctx, abac.RightExecute, string(decodedSubmodelIdentifier), idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+
 	return gen.Response(http.StatusNotImplemented, nil), errors.New("InvokeOperationSubmodelRepo method not implemented")
 }
 
@@ -1190,6 +1184,14 @@ func (s *SubmodelRepositoryAPIAPIService) InvokeOperationValueOnly(ctx context.C
 	// TODO: Uncomment the next line to return response Response(0, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(0, Result{}), nil
 
+	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
+	if decodeErr != nil {
+		return gen.Response(http.StatusBadRequest, nil), decodeErr
+	}
+	if err := s.authorize(ctx, abac.RightExecute, string(decodedSubmodelIdentifier), idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+
 	return gen.Response(http.StatusNotImplemented, nil), errors.New("InvokeOperationValueOnly method not implemented")
 }
 
@@ -1222,6 +1224,14 @@ func (s *SubmodelRepositoryAPIAPIService) InvokeOperationAsync(ctx context.Conte
 	// TODO: Uncomment the next line to return response Response(0, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(0, Result{}), nil
 
+	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
+	if decodeErr != nil {
+		return gen.Response(http.StatusBadRequest, nil), decodeErr
+	}
+	if err := s.authorize(ctx, abac.RightExecute, string(decodedSubmodelIdentifier), idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+
 	return gen.Response(http.StatusNotImplemented, nil), errors.New("InvokeOperationAsync method not implemented")
 }
 
@@ -1251,6 +1261,14 @@ func (s *SubmodelRepositoryAPIAPIService) InvokeOperationAsyncValueOnly(ctx cont
 	// TODO: Uncomment the next line to return response Response(0, Result{}) or use other options such as http.Ok ...
 	// return gen.Response(0, Result{}), nil
 
+	decodedSubmodelIdentifier, decodeErr := base64.RawStdEncoding.DecodeString(submodelIdentifier)
+	if decodeErr != nil {
+		return gen.Response(http.StatusBadRequest, nil), decodeErr
+	}
+	if err := s.authorize(ctx, abac.RightExecute, string(decodedSubmodelIdentifier), idShortPath); err != nil {
+		return gen.Response(http.StatusForbidden, nil), err
+	}
+
 	return gen.Response(http.StatusNotImplemented, nil), errors.New("InvokeOperationAsyncValueOnly method not implemented")
 }
 
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/lookup"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// LookupElement is an element found by a lookup. Element is set by LookupSubmodelElements,
+// Value by LookupSubmodelElementsValueOnly.
+type LookupElement struct {
+	SubmodelID  string              `json:"submodelId"`
+	IdShortPath string              `json:"idShortPath"`
+	Element     gen.SubmodelElement `json:"element,omitempty"`
+	Value       any                 `json:"value,omitempty"`
+}
+
+// LookupResult is a page of elements found by a lookup.
+type LookupResult struct {
+	PagingMetadata gen.PagedResultPagingMetadata `json:"paging_metadata,omitempty"`
+	Result         []LookupElement               `json:"result"`
+}
+
+// LookupSubmodelElements - Returns the submodel elements with the semanticId across all submodels
+func (s *SubmodelRepositoryAPIAPIService) LookupSubmodelElements(ctx context.Context, semanticId string, match string, supplemental bool, limit int32, cursor string) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.LookupSubmodelElements")
+	defer span.End()
+
+	found, nextCursor, res, err := s.lookupElements(ctx, semanticId, match, supplemental, limit, cursor)
+	if err != nil {
+		return res, err
+	}
+	return gen.Response(200, LookupResult{
+		PagingMetadata: gen.PagedResultPagingMetadata{Cursor: nextCursor},
+		Result:         found,
+	}), nil
+}
+
+// LookupSubmodelElementsValueOnly - Returns the values of the submodel elements with the semanticId across all submodels
+func (s *SubmodelRepositoryAPIAPIService) LookupSubmodelElementsValueOnly(ctx context.Context, semanticId string, match string, supplemental bool, limit int32, cursor string) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.LookupSubmodelElementsValueOnly")
+	defer span.End()
+
+	found, nextCursor, res, err := s.lookupElements(ctx, semanticId, match, supplemental, limit, cursor)
+	if err != nil {
+		return res, err
+	}
+	// capabilities and operations have no value and are left out
+	values := make([]LookupElement, 0, len(found))
+	for _, el := range found {
+		if value, ok := lookup.ValueOnly(el.Element); ok {
+			values = append(values, LookupElement{SubmodelID: el.SubmodelID, IdShortPath: el.IdShortPath, Value: value})
+		}
+	}
+	return gen.Response(200, LookupResult{
+		PagingMetadata: gen.PagedResultPagingMetadata{Cursor: nextCursor},
+		Result:         values,
+	}), nil
+}
+
+// LookupSubmodelElementsReference - Returns the references of the submodel elements with the semanticId across all submodels
+func (s *SubmodelRepositoryAPIAPIService) LookupSubmodelElementsReference(ctx context.Context, semanticId string, match string, supplemental bool, limit int32, cursor string) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.LookupSubmodelElementsReference")
+	defer span.End()
+
+	found, nextCursor, res, err := s.lookupElements(ctx, semanticId, match, supplemental, limit, cursor)
+	if err != nil {
+		return res, err
+	}
+	refs := make([]gen.Reference, 0, len(found))
+	for _, el := range found {
+		refs = append(refs, lookup.ElementReference(el.SubmodelID, el.IdShortPath, el.Element))
+	}
+	return gen.Response(200, gen.GetReferencesResult{
+		PagingMetadata: gen.PagedResultPagingMetadata{Cursor: nextCursor},
+		Result:         refs,
+	}), nil
+}
+
+// lookupElements finds a page of the elements with semanticId and loads the ones the
+// subject of ctx may read. On failure it returns the response to answer with.
+func (s *SubmodelRepositoryAPIAPIService) lookupElements(ctx context.Context, semanticId string, match string, supplemental bool, limit int32, cursor string) ([]LookupElement, string, gen.ImplResponse, error) {
+	lookuper, ok := s.submodelBackend.(SubmodelElementLookup)
+	if !ok {
+		return nil, "", gen.Response(http.StatusNotImplemented, nil), common.NewError(common.ErrCodeNotImplemented, "The lookup by semanticId requires the PostgreSQL backend", nil)
+	}
+	if match != "" && match != "exact" && match != "prefix" {
+		return nil, "", gen.Response(http.StatusBadRequest, nil), common.NewErrBadRequest("match must be 'exact' or 'prefix', not '" + match + "'")
+	}
+	req := lookup.Request{SemanticID: semanticId, Prefix: match == "prefix", Supplemental: supplemental, Limit: limit, Cursor: cursor}
+	if err := req.Validate(); err != nil {
+		return nil, "", gen.Response(http.StatusBadRequest, nil), err
+	}
+	matches, nextCursor, err := lookuper.LookupBySemanticID(ctx, req)
+	if err != nil {
+		return nil, "", gen.Response(http.StatusInternalServerError, nil), err
+	}
+
+	// Elements the caller may not read are left out, the cursor still continues after them.
+	found := make([]LookupElement, 0, len(matches))
+	resources := map[string]abac.Resource{}
+	for _, m := range matches {
+		el, ok, err := s.lookupElement(ctx, m, resources)
+		if err != nil {
+			return nil, "", gen.Response(http.StatusInternalServerError, nil), err
+		}
+		if ok {
+			found = append(found, LookupElement{SubmodelID: m.SubmodelID, IdShortPath: m.IdShortPath, Element: el})
+		}
+	}
+	return found, nextCursor, gen.ImplResponse{}, nil
+}
+
+// lookupElement loads the element of m with the children the subject of ctx may read, false
+// if it may not read the element or the element was deleted since the lookup. resources
+// caches the access control objects of the submodels of a page.
+func (s *SubmodelRepositoryAPIAPIService) lookupElement(ctx context.Context, m lookup.Match, resources map[string]abac.Resource) (gen.SubmodelElement, bool, error) {
+	if ok, err := s.pathReadable(ctx, m.SubmodelID, m.IdShortPath, resources); err != nil || !ok {
+		return nil, false, err
+	}
+	el, err := s.submodelBackend.GetSubmodelElement(ctx, m.SubmodelID, m.IdShortPath, 1, "")
+	if common.IsErrNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if s.enforcer == nil {
+		return el, true, nil
+	}
+	el, ok := s.readableElement(ctx, resources[m.SubmodelID], el, m.IdShortPath)
+	return el, ok, nil
+}
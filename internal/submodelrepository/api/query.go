@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// QuerySubmodels - Returns all Submodels that conform to the input query
+func (s *SubmodelRepositoryAPIAPIService) QuerySubmodels(
+	ctx context.Context,
+	q query.Query,
+	limit int32,
+	cursor string,
+) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.QuerySubmodels")
+	defer span.End()
+
+	querier, ok := s.submodelBackend.(SubmodelQuerier)
+	if !ok {
+		return gen.Response(http.StatusNotImplemented, nil), common.NewError(common.ErrCodeNotImplemented, "Queries require the PostgreSQL backend", nil)
+	}
+	sms, nextCursor, err := querier.QuerySubmodels(ctx, *q.Condition, limit, cursor)
+	if err != nil {
+		return gen.Response(http.StatusInternalServerError, nil), err
+	}
+
+	paths, anyElement := conditionPaths(*q.Condition)
+	readable := make([]gen.Submodel, 0, len(sms))
+	for _, sm := range sms {
+		if !s.conditionReadable(ctx, sm, paths, anyElement) {
+			continue
+		}
+		if sm, ok := s.readable(ctx, sm); ok {
+			readable = append(readable, sm)
+		}
+	}
+
+	res := gen.GetSubmodelsResult{
+		PagingMetadata: gen.PagedResultPagingMetadata{
+			Cursor: nextCursor,
+		},
+		Result: readable,
+	}
+	return gen.Response(200, res), nil
+}
+
+// conditionPaths returns the idShortPaths of the elements that cond refers to. anyElement
+// is set if cond has $sme fields without a path, which match any element.
+func conditionPaths(cond query.LogicalExpression) (paths []string, anyElement bool) {
+	for _, f := range cond.Fields() {
+		switch {
+		case f.Scope != query.ScopeElement:
+		case f.Path == "":
+			anyElement = true
+		default:
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths, anyElement
+}
+
+// conditionReadable reports whether the subject of ctx may read every element at paths of sm,
+// and with anyElement every element of sm. Otherwise the match would reveal values that
+// readable removes from the response.
+func (s *SubmodelRepositoryAPIAPIService) conditionReadable(ctx context.Context, sm gen.Submodel, paths []string, anyElement bool) bool {
+	if s.enforcer == nil {
+		return true
+	}
+	res := submodelResource(sm)
+	for _, path := range paths {
+		res.IdShortPath = path
+		if !s.enforcer.AllowedFor(ctx, abac.RightRead, res) {
+			return false
+		}
+	}
+	return !anyElement || s.allReadable(ctx, res, sm.SubmodelElements, "", false)
+}
+
+// allReadable reports whether the subject of ctx may read elements, which are below the
+// idShortPath prefix, and all their descendants. indexed elements are the values of a list.
+func (s *SubmodelRepositoryAPIAPIService) allReadable(ctx context.Context, res abac.Resource, elements []gen.SubmodelElement, prefix string, indexed bool) bool {
+	for i, el := range elements {
+		path := childPath(prefix, el.GetIdShort())
+		if indexed {
+			path = prefix + "[" + strconv.Itoa(i) + "]"
+		}
+		res.IdShortPath = path
+		if !s.enforcer.AllowedFor(ctx, abac.RightRead, res) {
+			return false
+		}
+		var children []gen.SubmodelElement
+		_, list := el.(*gen.SubmodelElementList)
+		switch v := el.(type) {
+		case *gen.SubmodelElementCollection:
+			children = v.Value
+		case *gen.SubmodelElementList:
+			children = v.Value
+		case *gen.Entity:
+			children = v.Statements
+		case *gen.AnnotatedRelationshipElement:
+			children = v.Annotations
+		case *gen.Operation:
+			for _, variables := range [][]gen.OperationVariable{v.InputVariables, v.OutputVariables, v.InoutputVariables} {
+				for _, variable := range variables {
+					children = append(children, variable.Value)
+				}
+			}
+		}
+		if !s.allReadable(ctx, res, children, path, list) {
+			return false
+		}
+	}
+	return true
+}
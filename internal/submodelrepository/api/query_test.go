@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/auth"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+)
+
+// queryBackend answers every query with its submodels, as if they matched.
+type queryBackend struct {
+	SubmodelBackend
+	submodels []gen.Submodel
+}
+
+func (b queryBackend) QuerySubmodels(ctx context.Context, cond query.LogicalExpression, limit int32, cursor string) ([]gen.Submodel, string, error) {
+	return b.submodels, "", nil
+}
+
+func TestQueryHiddenElementsDoNotDecideMatches(t *testing.T) {
+	enforcer, err := abac.New([]abac.Rule{
+		{Rights: []abac.Right{abac.RightRead}},
+		{Objects: []abac.Object{{IdShortPath: "Secret"}}, Rights: []abac.Right{abac.RightRead}, Effect: abac.EffectDeny},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := gen.Submodel{Id: "urn:sm", SubmodelElements: []gen.SubmodelElement{
+		&gen.Property{IdShort: "Public", ValueType: gen.DATATYPEDEFXSD_XS_INT, Value: "1"},
+		&gen.SubmodelElementCollection{IdShort: "Secret", Value: []gen.SubmodelElement{
+			&gen.Property{IdShort: "Salary", ValueType: gen.DATATYPEDEFXSD_XS_INT, Value: "150000"},
+		}},
+	}}
+	svc := NewSubmodelRepositoryAPIAPIService(queryBackend{submodels: []gen.Submodel{sm}}, enforcer)
+	ctx := auth.WithClaims(context.Background(), auth.Claims{"sub": "analyst"})
+
+	for _, tt := range []struct {
+		condition string
+		matches   int
+	}{
+		// the hidden salary would decide these matches
+		{`{"$gt":[{"$field":"$sme#value"},{"$numVal":100000}]}`, 0},
+		{`{"$eq":[{"$field":"$sme#idShort"},{"$strVal":"Salary"}]}`, 0},
+		{`{"$gt":[{"$field":"$sme.Secret.Salary#value"},{"$numVal":100000}]}`, 0},
+		// a readable element may decide them
+		{`{"$eq":[{"$field":"$sme.Public#value"},{"$numVal":1}]}`, 1},
+	} {
+		q, err := query.Parse(strings.NewReader(`{"$condition":` + tt.condition + `}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := svc.QuerySubmodels(ctx, q, 10, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(res.Body.(gen.GetSubmodelsResult).Result); got != tt.matches {
+			t.Errorf("%s: %d matches, want %d", tt.condition, got, tt.matches)
+		}
+	}
+}
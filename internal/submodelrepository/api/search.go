@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/abac"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/search"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
+)
+
+// SearchResult is a page of search hits.
+type SearchResult struct {
+	PagingMetadata gen.PagedResultPagingMetadata `json:"paging_metadata,omitempty"`
+	Result         []search.Hit                  `json:"result"`
+}
+
+// SearchSubmodels - Returns the texts of submodels and their elements that contain the search text
+func (s *SubmodelRepositoryAPIAPIService) SearchSubmodels(
+	ctx context.Context,
+	text string,
+	languages []string,
+	limit int32,
+	cursor string,
+) (gen.ImplResponse, error) {
+	ctx, span := tracing.Start(ctx, tracerName, "SubmodelRepositoryService.SearchSubmodels")
+	defer span.End()
+
+	searcher, ok := s.submodelBackend.(SubmodelSearcher)
+	if !ok {
+		return gen.Response(http.StatusNotImplemented, nil), common.NewError(common.ErrCodeNotImplemented, "The search requires the PostgreSQL backend", nil)
+	}
+	req := search.Request{Text: text, Languages: languages, Limit: limit, Cursor: cursor}
+	if err := req.Validate(); err != nil {
+		return gen.Response(http.StatusBadRequest, nil), err
+	}
+	hits, nextCursor, err := searcher.Search(ctx, req)
+	if err != nil {
+		return gen.Response(http.StatusInternalServerError, nil), err
+	}
+
+	// Hits the caller may not read are left out, the cursor still continues after them.
+	readable := make([]search.Hit, 0, len(hits))
+	resources := map[string]abac.Resource{}
+	for _, hit := range hits {
+		ok, err := s.pathReadable(ctx, hit.SubmodelID, hit.IdShortPath, resources)
+		if err != nil {
+			return gen.Response(http.StatusInternalServerError, nil), err
+		}
+		if ok {
+			readable = append(readable, hit)
+		}
+	}
+
+	return gen.Response(200, SearchResult{
+		PagingMetadata: gen.PagedResultPagingMetadata{Cursor: nextCursor},
+		Result:         readable,
+	}), nil
+}
+
+// pathReadable reports whether the subject of ctx may read the element at idShortPath with its
+// ancestors, or the submodel for "". resources caches the access control objects of the submodels
+// of a page.
+func (s *SubmodelRepositoryAPIAPIService) pathReadable(ctx context.Context, submodelId string, idShortPath string, resources map[string]abac.Resource) (bool, error) {
+	if s.enforcer == nil {
+		return true, nil
+	}
+	res, found := resources[submodelId]
+	if !found {
+		var err error
+		res, err = s.resource(ctx, submodelId)
+		if common.IsErrNotFound(err) {
+			// deleted since the search or lookup
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		resources[submodelId] = res
+	}
+	return s.checkRead(ctx, res, idShortPath) == nil, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/lookup"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/search"
+)
+
+// SubmodelBackend is the storage used by SubmodelRepositoryAPIAPIService.
+//
+// Implementations report failures with the errors of the common package
+// (common.NewErrNotFound, common.NewErrBadRequest, common.NewErrConflict,
+// common.NewInternalServerError) so that the service can map them to status codes.
+// They stop when ctx is done; changes of a cancelled call are rolled back.
+//
+// idShortPath follows the specification: idShorts separated by dots, list entries
+// addressed by their zero-based index in brackets, e.g. "Collection.List[2].Property".
+type SubmodelBackend interface {
+	// GetAllSubmodels returns a page of submodels and the cursor of the next page ("" if none).
+	GetAllSubmodels(ctx context.Context, limit int32, cursor string, idShort string) ([]gen.Submodel, string, error)
+	GetSubmodel(ctx context.Context, id string) (gen.Submodel, error)
+	CreateSubmodel(ctx context.Context, sm gen.Submodel) error
+	DeleteSubmodel(ctx context.Context, id string) error
+
+	// GetSubmodelElement returns the element at idShortOrPath including its children.
+	GetSubmodelElement(ctx context.Context, submodelId string, idShortOrPath string, limit int, cursor string) (gen.SubmodelElement, error)
+
+	// GetSubmodelElements returns a page of top-level elements ordered by idShort. The
+	// cursor is the idShort of the last element of the previous page.
+	GetSubmodelElements(ctx context.Context, submodelId string, limit int, cursor string) ([]gen.SubmodelElement, string, error)
+
+	// AddSubmodelElement adds a top-level element.
+	AddSubmodelElement(ctx context.Context, submodelId string, submodelElement gen.SubmodelElement) error
+
+	// AddSubmodelElementWithPath adds an element to the collection or list at idShortPath.
+	// Elements added to a list are appended and addressed by their index.
+	AddSubmodelElementWithPath(ctx context.Context, submodelId string, idShortPath string, submodelElement gen.SubmodelElement) error
+
+	// DeleteSubmodelElementByPath removes the element and its children. Later entries of a
+	// list move up by one index.
+	DeleteSubmodelElementByPath(ctx context.Context, submodelId string, idShortOrPath string) error
+}
+
+// SubmodelQuerier is implemented by backends that evaluate conditions of the AAS Query
+// Language. The service answers queries with 501 Not Implemented for other backends.
+type SubmodelQuerier interface {
+	// QuerySubmodels returns a page of the submodels matching cond, ordered by id, and the
+	// cursor of the next page ("" if none).
+	QuerySubmodels(ctx context.Context, cond query.LogicalExpression, limit int32, cursor string) ([]gen.Submodel, string, error)
+}
+
+// SubmodelSearcher is implemented by backends with a full-text search, see package search.
+// The service answers searches with 501 Not Implemented for other backends.
+type SubmodelSearcher interface {
+	// Search returns a page of hits, best matches first, and the cursor of the next page
+	// ("" if none).
+	Search(ctx context.Context, req search.Request) ([]search.Hit, string, error)
+}
+
+// SubmodelElementLookup is implemented by backends that find elements by semanticId across
+// submodels, see package lookup. The service answers lookups with 501 Not Implemented for
+// other backends.
+type SubmodelElementLookup interface {
+	// LookupBySemanticID returns a page of the elements matching req and the cursor of the
+	// next page ("" if none).
+	LookupBySemanticID(ctx context.Context, req lookup.Request) ([]lookup.Match, string, error)
+}
+
+// SubmodelAggregator is implemented by backends that aggregate property values over
+// submodels, see package aggregate. The service answers aggregations with 501 Not
+// Implemented for other backends.
+type SubmodelAggregator interface {
+	// AggregatedSubmodelIDs returns the ids of the submodels with a value that req aggregates,
+	// so that the service can leave out the ones the caller may not read.
+	AggregatedSubmodelIDs(ctx context.Context, req aggregate.Request) ([]string, error)
+	// Aggregate computes req over the submodels with ids, all submodels for nil.
+	Aggregate(ctx context.Context, req aggregate.Request, ids []string) (aggregate.Result, error)
+}
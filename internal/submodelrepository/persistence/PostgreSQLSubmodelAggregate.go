@@ -0,0 +1,127 @@
+package persistence_postgresql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
+)
+
+// AggregatedSubmodelIDs returns the ids of the submodels with a value that req aggregates.
+func (p *PostgreSQLSubmodelDatabase) AggregatedSubmodelIDs(ctx context.Context, req aggregate.Request) ([]string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	values, args := qb.AggregatedValues(req, nil).Build()
+	rows, err := p.db.Query(ctx, "SELECT submodel_id FROM ("+values+") v", args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// Aggregate computes req over the submodels with ids, all submodels for nil. The aggregates
+// and the histograms are read in one snapshot, so that their counts agree.
+func (p *PostgreSQLSubmodelDatabase) Aggregate(ctx context.Context, req aggregate.Request, ids []string) (aggregate.Result, error) {
+	result := aggregate.Result{Groups: []aggregate.Group{}}
+	if err := req.Validate(); err != nil {
+		return result, err
+	}
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q, args := qb.Aggregates(req, ids)
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return result, err
+	}
+	// groups are identified by their value, null by a key no value has
+	index := map[string]int{}
+	groupKey := func(value *string) string {
+		if value == nil {
+			return "null"
+		}
+		return "=" + *value
+	}
+	groups, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (aggregate.Group, error) {
+		var g aggregate.Group
+		var count int64
+		var least, greatest, avg, sum float64
+		if err := row.Scan(&g.Value, &count, &least, &greatest, &avg, &sum); err != nil {
+			return g, err
+		}
+		if req.Has(aggregate.FuncCount) {
+			g.Count = &count
+		}
+		if req.Has(aggregate.FuncMin) {
+			g.Min = req.Value(least)
+		}
+		if req.Has(aggregate.FuncMax) {
+			g.Max = req.Value(greatest)
+		}
+		if req.Has(aggregate.FuncAvg) {
+			g.Avg = req.Value(avg)
+		}
+		if req.Has(aggregate.FuncSum) {
+			g.Sum = sum
+		}
+		return g, nil
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(groups) > int(req.Limit) {
+		groups = groups[:req.Limit]
+		result.Truncated = true
+	}
+	for i, g := range groups {
+		index[groupKey(g.Value)] = i
+	}
+	result.Groups = groups
+	if req.Histogram == nil || len(groups) == 0 {
+		return result, nil
+	}
+
+	q, args = qb.Histogram(req, ids)
+	rows, err = tx.Query(ctx, q, args...)
+	if err != nil {
+		return result, err
+	}
+	var value *string
+	var bucket int
+	var count int64
+	var lo, hi float64
+	bounded := false
+	if from, to, _ := req.Bounds(); from != nil && to != nil {
+		lo, hi, bounded = *from, *to, true
+	}
+	_, err = pgx.ForEachRow(rows, []any{&value, &bucket, &count, &lo, &hi}, func() error {
+		bounded = true
+		i, ok := index[groupKey(value)]
+		if !ok {
+			// beyond the limit
+			return nil
+		}
+		if groups[i].Histogram == nil {
+			groups[i].Histogram = req.Buckets(lo, hi)
+		}
+		groups[i].Histogram[bucket-1].Count = count
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	// groups without values between the bounds have empty histograms; without any value
+	// between them defaulted bounds are unknown and the histograms are left out
+	for i := range groups {
+		if groups[i].Histogram == nil && bounded {
+			groups[i].Histogram = req.Buckets(lo, hi)
+		}
+	}
+	return result, nil
+}
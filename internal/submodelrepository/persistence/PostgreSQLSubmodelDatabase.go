@@ -1,179 +1,353 @@
 package persistence_postgresql
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
-	"fmt"
-	"os"
+	"log/slog"
 	"strconv"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL Treiber
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/cache"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/health"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/metrics"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/migrate"
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tenant"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
 	submodelelements "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/SubmodelElements"
+	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
 	persistence_utils "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/utils"
 )
 
 type PostgreSQLSubmodelDatabase struct {
-	db           *sql.DB
-	cacheEnabled bool
+	db *pgxpool.Pool
+	// sqlDB is the database/sql handle on top of db that runs the migrations.
+	sqlDB    *sql.DB
+	migrator *migrate.Migrator
+	// tenants have schemas of their own, see package tenant.
+	tenants []string
+	// cache holds complete submodels by id; nil if caching is disabled.
+	cache *cache.Cache[string, gen.Submodel]
+	// listener receives the invalidations of the cache from all replicas.
+	listener *cache.Listener
+	// jsonReads renders submodels and element trees as JSON inside the database.
+	jsonReads bool
 }
 
-var failedPostgresTransactionSubmodelRepo = common.NewInternalServerError("Failed to commit PostgreSQL transaction - no changes applied - see console for details")
-var beginTransactionErrorSubmodelRepo = common.NewInternalServerError("Failed to begin PostgreSQL transaction - no changes applied - see console for details")
+// cacheChannel is the LISTEN/NOTIFY channel on which replicas announce changed submodels.
+const cacheChannel = "basyx_submodel_invalidation"
 
-var maxCacheSize = 1000
+// failedPostgresTransactionSubmodelRepo reports a transaction that could not be committed
+// because of cause, an exceeded deadline as Timeout.
+func failedPostgresTransactionSubmodelRepo(cause error) error {
+	return common.NewError(common.ErrCodeInternalServerError, "Failed to commit PostgreSQL transaction - no changes applied", cause)
+}
 
-// InMemory Cache for submodels
-var submodelCache map[string]gen.Submodel = make(map[string]gen.Submodel)
+// beginTransactionErrorSubmodelRepo reports a transaction that could not be started because
+// of cause, an exceeded deadline as Timeout.
+func beginTransactionErrorSubmodelRepo(cause error) error {
+	return common.NewError(common.ErrCodeInternalServerError, "Failed to begin PostgreSQL transaction - no changes applied", cause)
+}
 
-func NewPostgreSQLSubmodelBackend(dsn string, maxOpenConns, maxIdleConns int, connMaxLifetimeMinutes int, cacheEnabled bool) (*PostgreSQLSubmodelDatabase, error) {
-	db, err := sql.Open("postgres", dsn)
-	//Set Max Connection
-	db.SetMaxOpenConns(500)
-	db.SetMaxIdleConns(500)
-	db.SetConnMaxLifetime(time.Minute * 5)
+// NewPostgreSQLSubmodelBackend connects to the database and migrates its schema. If cacheOptions
+// is not nil, submodels are cached and invalidated across replicas through LISTEN/NOTIFY until
+// ctx is done or the backend is closed. Queries use pgx's default mode, which caches prepared
+// statements and exchanges values in the binary format. If jsonReads is set, submodels and
+// submodel elements are read with a single query that builds their JSON in the database
+// instead of assembling them from rows. If tenants is not empty, every tenant gets its own
+// schema and queries run in the schema of the tenant of their context, see package tenant.
+func NewPostgreSQLSubmodelBackend(ctx context.Context, dsn string, maxOpenConns, maxIdleConns int, connMaxLifetimeMinutes int, cacheOptions *cache.Options, jsonReads bool, tenants []string) (*PostgreSQLSubmodelDatabase, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if maxOpenConns > 0 {
+		cfg.MaxConns = int32(maxOpenConns)
+	}
+	// pgxpool has no upper bound for idle connections, it keeps maxIdleConns of them warm instead.
+	cfg.MinIdleConns = min(int32(maxIdleConns), cfg.MaxConns)
+	cfg.MaxConnLifetime = time.Duration(connMaxLifetimeMinutes) * time.Minute
+	cfg.ConnConfig.Tracer = tracing.NewQueryTracer()
+	if len(tenants) > 0 {
+		tenant.ConfigurePool(cfg)
+	}
 
+	db, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		return nil, err
 	}
-	if err := db.Ping(); err != nil {
+	if err := db.Ping(context.Background()); err != nil {
 		return nil, err
 	}
 
-	dir, osErr := os.Getwd()
+	sqlDB := stdlib.OpenDBFromPool(db)
+	migrator, err := NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	err = tenant.ForEachSchema(context.Background(), db, tenants, func(ctx context.Context, _ string) error {
+		_, err := migrator.Up(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if osErr != nil {
-		return nil, osErr
+	p := &PostgreSQLSubmodelDatabase{db: db, sqlDB: sqlDB, migrator: migrator, tenants: tenants, jsonReads: jsonReads}
+	if cacheOptions != nil {
+		p.cache = cache.New[string, gen.Submodel](*cacheOptions, submodelSize)
+		p.listener, err = cache.StartListener(ctx, dsn, cacheChannel, p.cache.Delete, p.cache.Purge)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return p, nil
+}
 
-	queryString, fileError := os.ReadFile(dir + "/resources/sql/submodelrepositoryschema.sql")
+// Close stops the cache listener and closes the connection pool.
+func (p *PostgreSQLSubmodelDatabase) Close() error {
+	if p.listener != nil {
+		p.listener.Close()
+	}
+	err := p.sqlDB.Close()
+	p.db.Close()
+	return err
+}
 
-	if fileError != nil {
-		return nil, fileError
+// RegisterMetrics registers the statistics of the connection pool and of the submodel cache.
+func (p *PostgreSQLSubmodelDatabase) RegisterMetrics(reg prometheus.Registerer) error {
+	if err := metrics.RegisterPool(reg, "submodelrepository", p.db, p.sqlDB); err != nil {
+		return err
 	}
+	if p.cache != nil {
+		return metrics.RegisterCache(reg, "submodel", p.cache.Stats)
+	}
+	return nil
+}
+
+// HealthChecks reports the connectivity, the schema versions and the pool saturation.
+func (p *PostgreSQLSubmodelDatabase) HealthChecks(cfg health.Config) map[string]health.Check {
+	return health.PostgresChecks(p.db, p.migrator, p.tenants, cfg.MaxPoolSaturation)
+}
 
-	_, dbError := db.Exec(string(queryString))
+// submodelSize approximates the memory used by a cached submodel with its JSON size.
+func submodelSize(sm gen.Submodel) int64 {
+	data, err := json.Marshal(sm)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
+
+// publishInvalidation announces a change of the submodel to all replicas, including this one.
+// The notification is delivered when tx commits.
+func (p *PostgreSQLSubmodelDatabase) publishInvalidation(ctx context.Context, tx pgx.Tx, submodelId string) error {
+	if p.cache == nil {
+		return nil
+	}
+	_, err := tx.Exec(ctx, cache.NotifyQuery, cacheChannel, tenant.Key(ctx, submodelId))
+	return err
+}
 
-	if dbError != nil {
-		return nil, dbError
+// forget drops the submodel from the local cache without waiting for the notification.
+func (p *PostgreSQLSubmodelDatabase) forget(ctx context.Context, submodelId string) {
+	if p.cache != nil {
+		p.cache.Delete(tenant.Key(ctx, submodelId))
 	}
+}
 
-	return &PostgreSQLSubmodelDatabase{db: db, cacheEnabled: cacheEnabled}, nil
+// countCacheLookup records a cache lookup for the tenant of ctx, if there is one.
+func countCacheLookup(ctx context.Context, hit bool) {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	metrics.TenantCacheRequests.WithLabelValues("submodel", id, result).Inc()
 }
 
 // GetAllSubmodels and a next cursor ("" if no more pages).
-func (p *PostgreSQLSubmodelDatabase) GetAllSubmodels(limit int32, cursor string, idShort string) ([]gen.Submodel, string, error) {
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) GetAllSubmodels(ctx context.Context, limit int32, cursor string, idShort string) ([]gen.Submodel, string, error) {
+	tx, err := p.db.Begin(ctx)
 	if limit <= 0 {
 		limit = 100
 	}
 
 	if err != nil {
-		fmt.Println(err)
-		return nil, "", beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return nil, "", beginTransactionErrorSubmodelRepo(err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	sm, err := submodelelements.GetSubmodelWithSubmodelElementsOrAll(p.db, tx)
+	sm, err := submodelelements.GetSubmodelWithSubmodelElementsOrAll(ctx, p.db, tx)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return nil, "", failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return nil, "", failedPostgresTransactionSubmodelRepo(err)
 	}
 
 	return sm, "", nil
 }
 
+// QuerySubmodels returns a page of the submodels matching cond, ordered by id, and the cursor
+// of the next page ("" if none). The condition is compiled into a single query for the ids,
+// the submodels are then read like GetSubmodel, from the cache if it is enabled.
+func (p *PostgreSQLSubmodelDatabase) QuerySubmodels(ctx context.Context, cond query.LogicalExpression, limit int32, cursor string) ([]gen.Submodel, string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	b, err := qb.SubmodelIDs(cond, int(limit)+1, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	q, args := b.Build()
+	rows, err := p.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, "", queryError(err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, "", queryError(err)
+	}
+
+	nextCursor := ""
+	if len(ids) > int(limit) {
+		ids = ids[:limit]
+		nextCursor = ids[len(ids)-1]
+	}
+	sms := make([]gen.Submodel, 0, len(ids))
+	for _, id := range ids {
+		sm, err := p.GetSubmodel(ctx, id)
+		if common.IsErrNotFound(err) {
+			// deleted since the ids were read
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		sms = append(sms, sm)
+	}
+	return sms, nextCursor, nil
+}
+
+// invalidRegularExpression is the SQLSTATE of a pattern that PostgreSQL cannot compile.
+const invalidRegularExpression = "2201B"
+
+// queryError reports an invalid $regex pattern as a bad request. Patterns are only compiled by
+// PostgreSQL, whose regular expressions differ from those of Go.
+func queryError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == invalidRegularExpression {
+		return common.NewErrBadRequest("invalid $regex pattern: " + pgErr.Message)
+	}
+	return err
+}
+
 // GetSubmodel returns one Submodel by id
-func (p *PostgreSQLSubmodelDatabase) GetSubmodel(id string) (gen.Submodel, error) {
+func (p *PostgreSQLSubmodelDatabase) GetSubmodel(ctx context.Context, id string) (gen.Submodel, error) {
 	// Check cache first
-	if p.cacheEnabled {
-		if sm, found := submodelCache[id]; found {
+	var epoch uint64
+	if p.cache != nil {
+		sm, found := p.cache.Get(tenant.Key(ctx, id))
+		countCacheLookup(ctx, found)
+		if found {
 			return sm, nil
 		}
+		epoch = p.cache.Epoch()
 	}
 
 	// Not in cache, fetch from DB
-	tx, err := p.db.Begin()
+	tx, err := p.db.Begin(ctx)
 
 	if err != nil {
-		fmt.Println(err)
-		return gen.Submodel{}, beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return gen.Submodel{}, beginTransactionErrorSubmodelRepo(err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	sm, err := submodelelements.GetSubmodelWithSubmodelElements(p.db, tx, id)
+	sm, err := p.readSubmodel(ctx, tx, id)
 	if err != nil {
 		return gen.Submodel{}, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return gen.Submodel{}, failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return gen.Submodel{}, failedPostgresTransactionSubmodelRepo(err)
 	}
 
-	// Store in cache
-	if p.cacheEnabled {
-		submodelCache[id] = *sm
+	// Store in cache unless the submodel was changed in the meantime
+	if p.cache != nil {
+		p.cache.SetIfUnchanged(tenant.Key(ctx, id), *sm, epoch)
 	}
 	return *sm, nil
 }
 
-// DeleteSubmodel deletes a Submodel by id
-func (p *PostgreSQLSubmodelDatabase) DeleteSubmodel(id string) error {
-	// Check cache first
-	if p.cacheEnabled {
-		if _, found := submodelCache[id]; found {
-			delete(submodelCache, id)
-		}
+// readSubmodel loads the submodel with all its elements in the configured read mode.
+func (p *PostgreSQLSubmodelDatabase) readSubmodel(ctx context.Context, tx pgx.Tx, id string) (*gen.Submodel, error) {
+	if !p.jsonReads {
+		return submodelelements.GetSubmodelWithSubmodelElements(ctx, p.db, tx, id)
+	}
+	data, err := submodelelements.GetSubmodelJSON(ctx, tx, id)
+	if err != nil {
+		return nil, err
 	}
+	var sm gen.Submodel
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return nil, err
+	}
+	return &sm, nil
+}
 
-	tx, err := p.db.Begin()
+// DeleteSubmodel deletes a Submodel by id
+func (p *PostgreSQLSubmodelDatabase) DeleteSubmodel(ctx context.Context, id string) error {
+	tx, err := p.db.Begin(ctx)
 
 	if err != nil {
-		fmt.Println(err)
-		return beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return beginTransactionErrorSubmodelRepo(err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
 	const q = `DELETE FROM submodel WHERE id=$1`
 
-	res, err := tx.Exec(q, id)
+	res, err := tx.Exec(ctx, q, id)
 	if err != nil {
 		return err
 	}
 
 	// Check if a row was actually deleted
-	rowsAffected, err := res.RowsAffected()
-	if err != nil {
-		return err
+	if res.RowsAffected() == 0 {
+		return pgx.ErrNoRows
 	}
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+
+	if err = p.publishInvalidation(ctx, tx, id); err != nil {
+		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return failedPostgresTransactionSubmodelRepo(err)
 	}
+	p.forget(ctx, id)
 	return nil
 }
 
@@ -181,36 +355,33 @@ func (p *PostgreSQLSubmodelDatabase) DeleteSubmodel(id string) error {
 // If a Submodel with the same id already exists, it does nothing and returns nil
 // we might want ON CONFLICT DO UPDATE for upserts, but spec-wise POST usually means create new
 // model_type is hardcoded to "Submodel"
-func (p *PostgreSQLSubmodelDatabase) CreateSubmodel(sm gen.Submodel) error {
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) CreateSubmodel(ctx context.Context, sm gen.Submodel) error {
+	tx, err := p.db.Begin(ctx)
 
 	if err != nil {
-		fmt.Println(err)
-		return beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return beginTransactionErrorSubmodelRepo(err)
 	}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	referenceID, err := persistence_utils.CreateSemanticId(tx, sm.SemanticId)
+	referenceID, err := persistence_utils.CreateSemanticId(ctx, tx, sm.SemanticId)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to create SemanticId - no changes applied - see console for details")
+		slog.ErrorContext(ctx, "Failed to create SemanticId", "error", err)
+		return common.NewInternalServerError("Failed to create SemanticId - no changes applied")
 	}
 
-	displayNameId, err := persistence_utils.CreateLangStringNameTypes(tx, sm.DisplayName)
+	displayNameId, err := persistence_utils.CreateLangStringNameTypes(ctx, tx, sm.DisplayName)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to create DisplayName - no changes applied - see console for details")
+		slog.ErrorContext(ctx, "Failed to create DisplayName", "error", err)
+		return common.NewInternalServerError("Failed to create DisplayName - no changes applied")
 	}
 
-	descriptionId, err := persistence_utils.CreateLangStringTextTypes(tx, sm.Description)
+	descriptionId, err := persistence_utils.CreateLangStringTextTypes(ctx, tx, sm.Description)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to create Description - no changes applied - see console for details")
+		slog.ErrorContext(ctx, "Failed to create Description", "error", err)
+		return common.NewInternalServerError("Failed to create Description - no changes applied")
 	}
 
 	const q = `
@@ -219,46 +390,56 @@ func (p *PostgreSQLSubmodelDatabase) CreateSubmodel(sm gen.Submodel) error {
         ON CONFLICT (id) DO NOTHING
     `
 
-	_, err = tx.Exec(q, sm.Id, sm.IdShort, sm.Category, sm.Kind, referenceID, displayNameId, descriptionId)
+	_, err = tx.Exec(ctx, q, sm.Id, sm.IdShort, sm.Category, sm.Kind, referenceID, displayNameId, descriptionId)
 	if err != nil {
 		return err
 	}
 
 	if len(sm.SubmodelElements) > 0 {
-		for _, element := range sm.SubmodelElements {
-			err = p.AddSubmodelElementWithTransaction(tx, sm.Id, element)
-			if err != nil {
-				return err
-			}
+		roots := make([]submodelelements.BulkElement, len(sm.SubmodelElements))
+		for i, element := range sm.SubmodelElements {
+			roots[i] = submodelelements.BulkElement{Element: element, IdShortPath: element.GetIdShort()}
+		}
+		err = submodelelements.BulkInsertSubmodelElements(ctx, tx, p.db, sm.Id, roots)
+		if err != nil {
+			return err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return failedPostgresTransactionSubmodelRepo
+	if err = p.publishInvalidation(ctx, tx, sm.Id); err != nil {
+		return err
 	}
-	// Store in cache if enough space
-	if p.cacheEnabled {
-		if len(submodelCache) < maxCacheSize {
-			submodelCache[sm.Id] = sm
-		}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return failedPostgresTransactionSubmodelRepo(err)
 	}
+	p.forget(ctx, sm.Id)
 	return nil
 }
 
-func (p *PostgreSQLSubmodelDatabase) GetSubmodelElement(submodelId string, idShortOrPath string, limit int, cursor string) (gen.SubmodelElement, error) {
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) GetSubmodelElement(ctx context.Context, submodelId string, idShortOrPath string, limit int, cursor string) (gen.SubmodelElement, error) {
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return nil, beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return nil, beginTransactionErrorSubmodelRepo(err)
 	}
-	defer func() {
+	defer tx.Rollback(ctx)
+
+	if p.jsonReads && idShortOrPath != "" {
+		data, err := submodelelements.GetSubmodelElementJSON(ctx, tx, submodelId, idShortOrPath)
 		if err != nil {
-			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+			return nil, failedPostgresTransactionSubmodelRepo(err)
 		}
-	}()
+		return gen.UnmarshalSubmodelElement(data)
+	}
 
-	elements, _, err := submodelelements.GetSubmodelElementsWithPath(p.db, tx, submodelId, idShortOrPath, limit, cursor)
+	elements, _, err := submodelelements.GetSubmodelElementsWithPath(ctx, p.db, tx, submodelId, idShortOrPath, limit, cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -267,84 +448,67 @@ func (p *PostgreSQLSubmodelDatabase) GetSubmodelElement(submodelId string, idSho
 		return nil, common.NewErrNotFound("SubmodelElement with idShort or path '" + idShortOrPath + "' not found in submodel '" + submodelId + "'")
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return nil, failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return nil, failedPostgresTransactionSubmodelRepo(err)
 	}
 
 	return elements[0], nil
 }
 
-func (p *PostgreSQLSubmodelDatabase) GetSubmodelElements(submodelId string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) GetSubmodelElements(ctx context.Context, submodelId string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return nil, "", beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return nil, "", beginTransactionErrorSubmodelRepo(err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	elements, cursor, err := submodelelements.GetSubmodelElementsWithPath(p.db, tx, submodelId, "", limit, cursor)
+	elements, cursor, err := submodelelements.GetSubmodelElementsWithPath(ctx, p.db, tx, submodelId, "", limit, cursor)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return nil, "", failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return nil, "", failedPostgresTransactionSubmodelRepo(err)
 	}
 
 	return elements, cursor, nil
 }
 
-func (p *PostgreSQLSubmodelDatabase) AddSubmodelElementWithPath(submodelId string, idShortPath string, submodelElement gen.SubmodelElement) error {
-	// Invalidate Submodel cache if enabled
-	if p.cacheEnabled {
-		if _, found := submodelCache[submodelId]; found {
-			delete(submodelCache, submodelId)
-		}
-	}
-	handler, err := submodelelements.GetSMEHandler(submodelElement, p.db)
-	if err != nil {
-		return err
-	}
-
+func (p *PostgreSQLSubmodelDatabase) AddSubmodelElementWithPath(ctx context.Context, submodelId string, idShortPath string, submodelElement gen.SubmodelElement) error {
 	crud, err := submodelelements.NewPostgreSQLSMECrudHandler(p.db)
 	if err != nil {
 		return err
 	}
 
-	tx, err := p.db.Begin()
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return beginTransactionErrorSubmodelRepo(err)
 	}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	parentId, err := crud.GetDatabaseId(idShortPath)
+	parentId, err := crud.GetDatabaseId(ctx, idShortPath)
 	if err != nil {
-		fmt.Println(err)
-		return common.NewInternalServerError("Failed to execute PostgreSQL Query - no changes applied - see console for details.")
+		slog.ErrorContext(ctx, "Failed to execute PostgreSQL Query", "error", err)
+		return common.NewInternalServerError("Failed to execute PostgreSQL Query - no changes applied.")
 	}
-	nextPosition, err := crud.GetNextPosition(parentId)
+	nextPosition, err := crud.GetNextPosition(ctx, parentId)
 	if err != nil {
 		return err
 	}
 
-	modelType, err := crud.GetSubmodelElementType(idShortPath)
+	modelType, err := crud.GetSubmodelElementType(ctx, idShortPath)
 	if err != nil {
 		return err
 	}
 	if modelType != "SubmodelElementCollection" && modelType != "SubmodelElementList" {
-		return errors.New("cannot add nested element to non-collection/list element")
+		return common.NewErrBadRequest("cannot add nested element to non-collection/list element")
 	}
 	var newIdShortPath string
 	if modelType == "SubmodelElementList" {
@@ -352,242 +516,78 @@ func (p *PostgreSQLSubmodelDatabase) AddSubmodelElementWithPath(submodelId strin
 	} else {
 		newIdShortPath = idShortPath + "." + submodelElement.GetIdShort()
 	}
-	id, err := handler.CreateNested(tx, submodelId, parentId, newIdShortPath, submodelElement, nextPosition)
+	err = submodelelements.BulkInsertSubmodelElements(ctx, tx, p.db, submodelId, []submodelelements.BulkElement{
+		{Element: submodelElement, ParentId: parentId, IdShortPath: newIdShortPath, Position: nextPosition},
+	})
 	if err != nil {
 		return err
 	}
-	err = p.AddNestedSubmodelElementsIteratively(tx, submodelId, id, submodelElement, newIdShortPath)
-	if err != nil {
+
+	if err = p.publishInvalidation(ctx, tx, submodelId); err != nil {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return failedPostgresTransactionSubmodelRepo
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return failedPostgresTransactionSubmodelRepo(err)
 	}
+	p.forget(ctx, submodelId)
 
 	return nil
 }
-func (p *PostgreSQLSubmodelDatabase) AddSubmodelElement(submodelId string, submodelElement gen.SubmodelElement) error {
-	// Invalidate Submodel cache if enabled
-	if p.cacheEnabled {
-		if _, found := submodelCache[submodelId]; found {
-			delete(submodelCache, submodelId)
-		}
-	}
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) AddSubmodelElement(ctx context.Context, submodelId string, submodelElement gen.SubmodelElement) error {
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
-		fmt.Println(err)
-		return beginTransactionErrorSubmodelRepo
+		slog.ErrorContext(ctx, "Failed to begin PostgreSQL transaction", "error", err)
+		metrics.TransactionBeginFailures.Inc()
+		return beginTransactionErrorSubmodelRepo(err)
 	}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback(ctx)
 
-	err = p.AddSubmodelElementWithTransaction(tx, submodelId, submodelElement)
+	err = p.AddSubmodelElementWithTransaction(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		fmt.Println(err)
-		return failedPostgresTransactionSubmodelRepo
-	}
-
-	return nil
-}
-
-func (p *PostgreSQLSubmodelDatabase) AddSubmodelElementWithTransaction(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) error {
-	// Invalidate Submodel cache if enabled
-	if p.cacheEnabled {
-		if _, found := submodelCache[submodelId]; found {
-			delete(submodelCache, submodelId)
-		}
-	}
-	handler, err := submodelelements.GetSMEHandler(submodelElement, p.db)
-	if err != nil {
-		return err
-	}
-	parentId, err := handler.Create(tx, submodelId, submodelElement)
-	if err != nil {
+	if err = p.publishInvalidation(ctx, tx, submodelId); err != nil {
 		return err
 	}
 
-	err = p.AddNestedSubmodelElementsIteratively(tx, submodelId, parentId, submodelElement, "")
-	if err != nil {
-		return err
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit PostgreSQL transaction", "error", err)
+		return failedPostgresTransactionSubmodelRepo(err)
 	}
-	return nil
-}
+	p.forget(ctx, submodelId)
 
-type ElementToProcess struct {
-	element                   gen.SubmodelElement
-	parentId                  int
-	currentIdShortPath        string
-	isFromSubmodelElementList bool // Indicates if the current element is from a SubmodelElementList
-	position                  int  // Position/index within the parent collection or list
+	return nil
 }
 
-func (p *PostgreSQLSubmodelDatabase) AddNestedSubmodelElementsIteratively(tx *sql.Tx, submodelId string, topLevelParentId int, topLevelElement gen.SubmodelElement, startPath string) error {
-	// Invalidate Submodel cache if enabled
-	if p.cacheEnabled {
-		if _, found := submodelCache[submodelId]; found {
-			delete(submodelCache, submodelId)
-		}
-	}
-	stack := []ElementToProcess{}
-
-	switch string(topLevelElement.GetModelType()) {
-	case "SubmodelElementCollection":
-		submodelElementCollection, ok := topLevelElement.(*gen.SubmodelElementCollection)
-		if !ok {
-			return common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementCollection' is not of type SubmodelElementCollection")
-		}
-		for index, nestedElement := range submodelElementCollection.Value {
-			var currentPath string
-			if startPath == "" {
-				currentPath = submodelElementCollection.IdShort
-			} else {
-				currentPath = startPath
-			}
-			stack = append(stack, ElementToProcess{
-				element:                   nestedElement,
-				parentId:                  topLevelParentId,
-				currentIdShortPath:        currentPath,
-				isFromSubmodelElementList: false,
-				position:                  index,
-			})
-		}
-	case "SubmodelElementList":
-		submodelElementList, ok := topLevelElement.(*gen.SubmodelElementList)
-		if !ok {
-			return common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementList' is not of type SubmodelElementList")
-		}
-		// Add nested elements to stack with index-based paths
-		for index, nestedElement := range submodelElementList.Value {
-			var idShortPath string
-			if startPath == "" {
-				idShortPath = submodelElementList.IdShort + "[" + strconv.Itoa(index) + "]"
-			} else {
-				idShortPath = startPath
-			}
-			stack = append(stack, ElementToProcess{
-				element:                   nestedElement,
-				parentId:                  topLevelParentId,
-				currentIdShortPath:        idShortPath,
-				isFromSubmodelElementList: true,
-				position:                  index,
-			})
-		}
-	}
-
-	for len(stack) > 0 {
-		// LIFO Stack
-		current := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		handler, err := submodelelements.GetSMEHandler(current.element, p.db)
-		if err != nil {
-			return err
-		}
-
-		// Build the idShortPath for current element
-		idShortPath := buildCurrentIdShortPath(current)
-
-		newParentId, err := handler.CreateNested(tx, submodelId, current.parentId, idShortPath, current.element, current.position)
-		if err != nil {
-			return err
-		}
-
-		switch string(current.element.GetModelType()) {
-		case "SubmodelElementCollection":
-			submodelElementCollection, ok := current.element.(*gen.SubmodelElementCollection)
-			if !ok {
-				return common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementCollection' is not of type SubmodelElementCollection")
-			}
-			for i := len(submodelElementCollection.Value) - 1; i >= 0; i-- {
-				stack = addNestedElementToStackWithNormalPath(submodelElementCollection, i, stack, newParentId, idShortPath)
-			}
-		case "SubmodelElementList":
-			submodelElementList, ok := current.element.(*gen.SubmodelElementList)
-			if !ok {
-				return common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementList' is not of type SubmodelElementList")
-			}
-			for index := len(submodelElementList.Value) - 1; index >= 0; index-- {
-				stack = addNestedElementToStackWithIndexPath(submodelElementList, index, idShortPath, stack, newParentId)
-			}
-		}
-	}
-
-	return nil
+func (p *PostgreSQLSubmodelDatabase) AddSubmodelElementWithTransaction(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) error {
+	return submodelelements.BulkInsertSubmodelElements(ctx, tx, p.db, submodelId, []submodelelements.BulkElement{
+		{Element: submodelElement, IdShortPath: submodelElement.GetIdShort()},
+	})
 }
 
 // This method removes a SubmodelElement by its idShort or path and all its nested elements
 // If the deleted Element is in a SubmodelElementList, the indices of the remaining elements are adjusted accordingly
-func (p *PostgreSQLSubmodelDatabase) DeleteSubmodelElementByPath(submodelId string, idShortOrPath string) error {
-	// Invalidate Submodel cache if enabled
-	if p.cacheEnabled {
-		if _, found := submodelCache[submodelId]; found {
-			delete(submodelCache, submodelId)
-		}
-	}
-	tx, err := p.db.Begin()
+func (p *PostgreSQLSubmodelDatabase) DeleteSubmodelElementByPath(ctx context.Context, submodelId string, idShortOrPath string) error {
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-	err = submodelelements.DeleteSubmodelElementByPath(tx, submodelId, idShortOrPath)
+	defer tx.Rollback(ctx)
+	err = submodelelements.DeleteSubmodelElementByPath(ctx, tx, submodelId, idShortOrPath)
 	if err != nil {
 		return err
 	}
-	return tx.Commit()
-}
-
-func buildCurrentIdShortPath(current ElementToProcess) string {
-	var idShortPath string
-	if current.currentIdShortPath == "" {
-		idShortPath = current.element.GetIdShort()
-	} else {
-		// If element comes from a SubmodelElementList, use the path as-is (includes [index])
-		if current.isFromSubmodelElementList {
-			idShortPath = current.currentIdShortPath
-		} else {
-			// For SubmodelElementCollection, append element's idShort with dot notation
-			idShortPath = current.currentIdShortPath + "." + current.element.GetIdShort()
-		}
+	if err = p.publishInvalidation(ctx, tx, submodelId); err != nil {
+		return err
 	}
-	return idShortPath
-}
-
-func addNestedElementToStackWithNormalPath(submodelElementCollection *gen.SubmodelElementCollection, i int, stack []ElementToProcess, newParentId int, idShortPath string) []ElementToProcess {
-	nestedElement := submodelElementCollection.Value[i]
-	stack = append(stack, ElementToProcess{
-		element:                   nestedElement,
-		parentId:                  newParentId,
-		currentIdShortPath:        idShortPath,
-		isFromSubmodelElementList: false, // Children of collection are not from list
-		position:                  i,
-	})
-	return stack
-}
-
-func addNestedElementToStackWithIndexPath(submodelElementList *gen.SubmodelElementList, index int, idShortPath string, stack []ElementToProcess, newParentId int) []ElementToProcess {
-	nestedElement := submodelElementList.Value[index]
-	nestedIdShortPath := idShortPath + "[" + strconv.Itoa(index) + "]"
-	stack = append(stack, ElementToProcess{
-		element:                   nestedElement,
-		parentId:                  newParentId,
-		currentIdShortPath:        nestedIdShortPath,
-		isFromSubmodelElementList: true,  // Children of list are from list
-		position:                  index, // For lists, position is the actual index
-	})
-	return stack
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	p.forget(ctx, submodelId)
+	return nil
 }
@@ -0,0 +1,55 @@
+package persistence_postgresql
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// BenchmarkGetSubmodel compares the read modes on a submodel of 20 collections nested 20
+// levels deep, each holding a few properties. It needs a database, see testBackend:
+//
+//	SUBMODEL_TEST_DSN=... go test -run '^$' -bench GetSubmodel ./internal/submodelrepository/persistence
+func BenchmarkGetSubmodel(b *testing.B) {
+	ctx := context.Background()
+
+	for _, mode := range []struct {
+		name      string
+		jsonReads bool
+	}{{"go", false}, {"json", true}} {
+		b.Run(mode.name, func(b *testing.B) {
+			p := testBackend(b, mode.jsonReads)
+			sm := benchmarkSubmodel("bench-read-" + mode.name)
+			createTestSubmodel(b, p, sm)
+
+			b.ResetTimer()
+			for b.Loop() {
+				if _, err := p.GetSubmodel(ctx, sm.Id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkSubmodel(id string) gen.Submodel {
+	sm := gen.Submodel{Id: id, IdShort: "bench", ModelType: "Submodel", Kind: "Instance"}
+	for i := 0; i < 20; i++ {
+		var inner gen.SubmodelElement
+		for depth := 19; depth >= 0; depth-- {
+			smc := &gen.SubmodelElementCollection{IdShort: "c" + strconv.Itoa(depth), ModelType: "SubmodelElementCollection"}
+			for j := 0; j < 3; j++ {
+				smc.Value = append(smc.Value, &gen.Property{IdShort: "p" + strconv.Itoa(j), ModelType: "Property", ValueType: "xs:string", Value: "value"})
+			}
+			if inner != nil {
+				smc.Value = append(smc.Value, inner)
+			}
+			inner = smc
+		}
+		inner.(*gen.SubmodelElementCollection).IdShort = "root" + strconv.Itoa(i)
+		sm.SubmodelElements = append(sm.SubmodelElements, inner)
+	}
+	return sm
+}
@@ -0,0 +1,69 @@
+package persistence_postgresql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/lookup"
+	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
+)
+
+// lookupQuery selects the elements after the element id $3 that have a semanticId key
+// matching $1, and with $2 a supplementalSemanticId key. match compares rk.value with $1,
+// with = or LIKE; ix_refkey_val_pattern answers both.
+const lookupQuery = `
+SELECT e.id, e.submodel_id, e.idshort_path
+FROM submodel_element e
+WHERE e.id > $3 AND e.id IN (
+  SELECT se.id
+  FROM reference_key rk JOIN submodel_element se ON se.semantic_id = rk.reference_id
+  WHERE {match}
+  UNION
+  SELECT ss.sme_id
+  FROM reference_key rk JOIN sme_supplemental_semantic ss ON ss.reference_id = rk.reference_id
+  WHERE $2 AND {match}
+)
+ORDER BY e.id
+LIMIT $4`
+
+// LookupBySemanticID returns a page of the elements whose semanticId matches req, ordered by
+// their database id, and the cursor of the next page ("" if none).
+func (p *PostgreSQLSubmodelDatabase) LookupBySemanticID(ctx context.Context, req lookup.Request) ([]lookup.Match, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", err
+	}
+	after, _ := req.After()
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	value, match := strings.TrimSpace(req.SemanticID), "rk.value = $1"
+	if req.Prefix {
+		value, match = qb.EscapeLike(value)+"%", "rk.value LIKE $1"
+	}
+
+	rows, err := p.db.Query(ctx, strings.ReplaceAll(lookupQuery, "{match}", match), value, req.Supplemental, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	var ids []int64
+	matches, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (lookup.Match, error) {
+		var id int64
+		var m lookup.Match
+		err := row.Scan(&id, &m.SubmodelID, &m.IdShortPath)
+		ids = append(ids, id)
+		return m, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(matches) > int(limit) {
+		matches = matches[:limit]
+		nextCursor = lookup.Cursor(ids[limit-1])
+	}
+	return matches, nextCursor, nil
+}
@@ -0,0 +1,99 @@
+package persistence_postgresql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/search"
+	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
+)
+
+// searchQuery finds the texts that contain $2, a LIKE pattern, in every searchable column and
+// ranks them by their word similarity with the search text $1, so that whole-word matches come
+// first. Each branch filters on an indexed column: the trigram indexes answer ILIKE. $3 are
+// the languages of lang strings, NULL for all.
+const searchQuery = `
+SELECT submodel_id, idshort_path, field, language, text, public.word_similarity($1, text) AS score
+FROM (
+  SELECT e.submodel_id, e.idshort_path, 'value' AS field, NULL::text AS language, p.value_text AS text
+  FROM property_element p JOIN submodel_element e ON e.id = p.id
+  WHERE p.value_type = 'xs:string' AND p.value_text ILIKE $2
+  UNION ALL
+  SELECT e.submodel_id, e.idshort_path, 'value', v.language, v.text
+  FROM multilanguage_property_value v JOIN submodel_element e ON e.id = v.mlp_id
+  WHERE v.text ILIKE $2 AND ($3::text[] IS NULL OR v.language = ANY($3))
+  UNION ALL
+  SELECT e.submodel_id, e.idshort_path, 'file', NULL, f.value
+  FROM file_element f JOIN submodel_element e ON e.id = f.id
+  WHERE f.value ILIKE $2
+  UNION ALL
+  SELECT e.submodel_id, e.idshort_path, 'idShortPath', NULL, e.idshort_path
+  FROM submodel_element e
+  WHERE e.idshort_path ILIKE $2
+  UNION ALL
+  SELECT e.submodel_id, e.idshort_path, 'description', d.language, d.text
+  FROM lang_string_text_type d JOIN submodel_element e ON e.description_id = d.lang_string_text_type_reference_id
+  WHERE d.text ILIKE $2 AND ($3::text[] IS NULL OR d.language = ANY($3))
+  UNION ALL
+  SELECT s.id, '', 'description', d.language, d.text
+  FROM lang_string_text_type d JOIN submodel s ON s.description_id = d.lang_string_text_type_reference_id
+  WHERE d.text ILIKE $2 AND ($3::text[] IS NULL OR d.language = ANY($3))
+  UNION ALL
+  SELECT e.submodel_id, e.idshort_path, 'displayName', n.language, n.text
+  FROM lang_string_name_type n JOIN submodel_element e ON e.displayname_id = n.lang_string_name_type_reference_id
+  WHERE n.text ILIKE $2 AND ($3::text[] IS NULL OR n.language = ANY($3))
+  UNION ALL
+  SELECT s.id, '', 'displayName', n.language, n.text
+  FROM lang_string_name_type n JOIN submodel s ON s.displayname_id = n.lang_string_name_type_reference_id
+  WHERE n.text ILIKE $2 AND ($3::text[] IS NULL OR n.language = ANY($3))
+) hits
+ORDER BY score DESC, public.similarity($1, text) DESC, submodel_id, idshort_path, field, language NULLS FIRST
+LIMIT $4 OFFSET $5`
+
+// Search returns a page of the texts that contain req.Text, best matches first, and the cursor
+// of the next page ("" if none).
+func (p *PostgreSQLSubmodelDatabase) Search(ctx context.Context, req search.Request) ([]search.Hit, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", err
+	}
+	offset, _ := req.Offset()
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	text := strings.TrimSpace(req.Text)
+	var languages []string
+	if len(req.Languages) > 0 {
+		languages = req.Languages
+	}
+
+	rows, err := p.db.Query(ctx, searchQuery, text, "%"+qb.EscapeLike(text)+"%", languages, limit+1, offset)
+	if err != nil {
+		return nil, "", err
+	}
+	hits, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (search.Hit, error) {
+		var hit search.Hit
+		var language *string
+		var value string
+		if err := row.Scan(&hit.SubmodelID, &hit.IdShortPath, &hit.Field, &language, &value, &hit.Score); err != nil {
+			return hit, err
+		}
+		if language != nil {
+			hit.Language = *language
+		}
+		hit.Snippet = search.Snippet(value, text)
+		return hit, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(hits) > int(limit) {
+		hits = hits[:limit]
+		nextCursor = search.NextCursor(offset, limit)
+	}
+	return hits, nextCursor, nil
+}
@@ -0,0 +1,46 @@
+package persistence_postgresql
+
+import (
+	"context"
+	"testing"
+
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/search"
+)
+
+func TestSearchFindsElementsByDescriptionAndDisplayName(t *testing.T) {
+	ctx := context.Background()
+	p := testBackend(t, false)
+
+	createTestSubmodel(t, p, gen.Submodel{Id: "urn:test:search-descriptions", ModelType: "Submodel", Kind: "Instance",
+		SubmodelElements: []gen.SubmodelElement{
+			&gen.SubmodelElementCollection{IdShort: "motor", ModelType: "SubmodelElementCollection", Value: []gen.SubmodelElement{
+				&gen.Property{IdShort: "speed", ModelType: "Property", ValueType: "xs:int", Value: "1500",
+					Description: []gen.LangStringTextType{{Language: "en", Text: "Rotational quokkaspeed of the shaft"}},
+					DisplayName: []gen.LangStringNameType{{Language: "de", Text: "Quokkadrehzahl"}}},
+			}},
+		}})
+
+	for _, c := range []struct {
+		text  string
+		field search.Field
+		lang  string
+	}{
+		{"quokkaspeed", search.FieldDescription, "en"},
+		{"quokkadrehzahl", search.FieldDisplayName, "de"},
+	} {
+		hits, _, err := p.Search(ctx, search.Request{Text: c.text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, hit := range hits {
+			if hit.SubmodelID == "urn:test:search-descriptions" && hit.IdShortPath == "motor.speed" && hit.Field == c.field && hit.Language == c.lang {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a %s hit for %q on motor.speed, got %+v", c.field, c.text, hits)
+		}
+	}
+}
@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLAnnotatedRelationshipElementHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLAnnotatedRelationshipElementHandler(db *sql.DB) (*PostgreSQLAnnotatedRelationshipElementHandler, error) {
+func NewPostgreSQLAnnotatedRelationshipElementHandler(db *pgxpool.Pool) (*PostgreSQLAnnotatedRelationshipElementHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +24,20 @@ func NewPostgreSQLAnnotatedRelationshipElementHandler(db *sql.DB) (*PostgreSQLAn
 	return &PostgreSQLAnnotatedRelationshipElementHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLAnnotatedRelationshipElementHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLAnnotatedRelationshipElementHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	areElem, ok := submodelElement.(*gen.AnnotatedRelationshipElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type AnnotatedRelationshipElement")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// AnnotatedRelationshipElement-specific database insertion
-	err = insertAnnotatedRelationshipElement(areElem, tx, id, submodelId, p.db)
+	err = insertAnnotatedRelationshipElement(ctx, areElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +45,20 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) Create(tx *sql.Tx, submod
 	return id, nil
 }
 
-func (p PostgreSQLAnnotatedRelationshipElementHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLAnnotatedRelationshipElementHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	areElem, ok := submodelElement.(*gen.AnnotatedRelationshipElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type AnnotatedRelationshipElement")
 	}
 
 	// Create the nested areElem with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// AnnotatedRelationshipElement-specific database insertion for nested element
-	err = insertAnnotatedRelationshipElement(areElem, tx, id, submodelId, p.db)
+	err = insertAnnotatedRelationshipElement(ctx, areElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,27 +66,27 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) CreateNested(tx *sql.Tx,
 	return id, nil
 }
 
-func (p PostgreSQLAnnotatedRelationshipElementHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLAnnotatedRelationshipElementHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.AnnotatedRelationshipElement{}
 	var firstRef, secondRef sql.NullInt64
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`SELECT first_ref, second_ref FROM relationship_element WHERE id = $1`, id).Scan(&firstRef, &secondRef)
+	err = tx.QueryRow(ctx, `SELECT first_ref, second_ref FROM relationship_element WHERE id = $1`, id).Scan(&firstRef, &secondRef)
 	if err != nil {
 		return sme, nil
 	}
 	areElem := sme.(*gen.AnnotatedRelationshipElement)
 	if firstRef.Valid {
-		ref, err := readReference(tx, firstRef.Int64)
+		ref, err := readReference(ctx, tx, firstRef.Int64)
 		if err != nil {
 			return nil, err
 		}
 		areElem.First = ref
 	}
 	if secondRef.Valid {
-		ref, err := readReference(tx, secondRef.Int64)
+		ref, err := readReference(ctx, tx, secondRef.Int64)
 		if err != nil {
 			return nil, err
 		}
@@ -91,7 +94,7 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) Read(tx *sql.Tx, submodel
 	}
 
 	// Read annotations
-	rows, err := tx.Query(`SELECT annotation_sme FROM annotated_rel_annotation WHERE rel_id = $1`, id)
+	rows, err := tx.Query(ctx, `SELECT annotation_sme FROM annotated_rel_annotation WHERE rel_id = $1`, id)
 	if err != nil {
 		return sme, nil
 	}
@@ -105,7 +108,7 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) Read(tx *sql.Tx, submodel
 		// Read the annotation element
 		// But need to know the type. Perhaps query the model_type from submodel_element
 		var modelType string
-		err = tx.QueryRow(`SELECT model_type FROM submodel_element WHERE id = $1`, annId).Scan(&modelType)
+		err = tx.QueryRow(ctx, `SELECT model_type FROM submodel_element WHERE id = $1`, annId).Scan(&modelType)
 		if err != nil {
 			return nil, err
 		}
@@ -116,11 +119,11 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) Read(tx *sql.Tx, submodel
 		// But Read needs idShortOrPath, but we have id.
 		// Need to get idShortPath
 		var idShortPath string
-		err = tx.QueryRow(`SELECT idshort_path FROM submodel_element WHERE id = $1`, annId).Scan(&idShortPath)
+		err = tx.QueryRow(ctx, `SELECT idshort_path FROM submodel_element WHERE id = $1`, annId).Scan(&idShortPath)
 		if err != nil {
 			return nil, err
 		}
-		ann, err := annHandler.Read(tx, submodelId, idShortPath)
+		ann, err := annHandler.Read(ctx, tx, submodelId, idShortPath)
 		if err != nil {
 			return nil, err
 		}
@@ -142,50 +145,9 @@ func (p PostgreSQLAnnotatedRelationshipElementHandler) Delete(idShortOrPath stri
 	return nil
 }
 
-func insertAnnotatedRelationshipElement(areElem *gen.AnnotatedRelationshipElement, tx *sql.Tx, id int, submodelId string, db *sql.DB) error {
-	// Insert into relationship_element
-	var firstRefId, secondRefId sql.NullInt64
-
-	if !isEmptyReference(areElem.First) {
-		refId, err := insertReference(tx, *areElem.First)
-		if err != nil {
-			return err
-		}
-		firstRefId = sql.NullInt64{Int64: int64(refId), Valid: true}
-	}
-
-	if !isEmptyReference(areElem.Second) {
-		refId, err := insertReference(tx, *areElem.Second)
-		if err != nil {
-			return err
-		}
-		secondRefId = sql.NullInt64{Int64: int64(refId), Valid: true}
-	}
-
-	_, err := tx.Exec(`INSERT INTO relationship_element (id, first_ref, second_ref) VALUES ($1, $2, $3)`,
-		id, firstRefId, secondRefId)
-	if err != nil {
-		return err
-	}
-
-	// Create annotations as separate submodel elements
-	for _, annotation := range areElem.Annotations {
-		annHandler, err := GetSMEHandler(annotation, db)
-		if err != nil {
-			return err
-		}
-
-		annId, err := annHandler.Create(tx, submodelId, annotation)
-		if err != nil {
-			return err
-		}
-
-		// Insert link
-		_, err = tx.Exec(`INSERT INTO annotated_rel_annotation (rel_id, annotation_sme) VALUES ($1, $2)`, id, annId)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+// insertAnnotatedRelationshipElement stores the references of an AnnotatedRelationshipElement.
+// Its annotations are elements nested below it; BulkInsertSubmodelElements inserts them with
+// their annotated_rel_annotation rows.
+func insertAnnotatedRelationshipElement(ctx context.Context, areElem *gen.AnnotatedRelationshipElement, tx elementWriter, id int) error {
+	return insertRelationshipElement(ctx, &gen.RelationshipElement{First: areElem.First, Second: areElem.Second}, tx, id)
 }
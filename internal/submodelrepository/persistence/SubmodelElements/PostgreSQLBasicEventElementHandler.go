@@ -1,20 +1,23 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLBasicEventElementHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLBasicEventElementHandler(db *sql.DB) (*PostgreSQLBasicEventElementHandler, error) {
+func NewPostgreSQLBasicEventElementHandler(db *pgxpool.Pool) (*PostgreSQLBasicEventElementHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -22,20 +25,20 @@ func NewPostgreSQLBasicEventElementHandler(db *sql.DB) (*PostgreSQLBasicEventEle
 	return &PostgreSQLBasicEventElementHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLBasicEventElementHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLBasicEventElementHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	basicEvent, ok := submodelElement.(*gen.BasicEventElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type BasicEventElement")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// BasicEventElement-specific database insertion
-	err = insertBasicEventElement(basicEvent, tx, id)
+	err = insertBasicEventElement(ctx, basicEvent, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -43,20 +46,20 @@ func (p PostgreSQLBasicEventElementHandler) Create(tx *sql.Tx, submodelId string
 	return id, nil
 }
 
-func (p PostgreSQLBasicEventElementHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLBasicEventElementHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	basicEvent, ok := submodelElement.(*gen.BasicEventElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type BasicEventElement")
 	}
 
 	// Create the nested basic event element with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// BasicEventElement-specific database insertion for nested element
-	err = insertBasicEventElement(basicEvent, tx, id)
+	err = insertBasicEventElement(ctx, basicEvent, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -64,10 +67,10 @@ func (p PostgreSQLBasicEventElementHandler) CreateNested(tx *sql.Tx, submodelId
 	return id, nil
 }
 
-func (p PostgreSQLBasicEventElementHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLBasicEventElementHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	// First, get the base submodel element
 	var baseSME gen.SubmodelElement
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &baseSME)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &baseSME)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +88,7 @@ func (p PostgreSQLBasicEventElementHandler) Read(tx *sql.Tx, submodelId string,
 	var lastUpdate sql.NullTime
 	var minInterval, maxInterval sql.NullString
 
-	err = tx.QueryRow(`SELECT observed_ref, direction, state, message_topic, message_broker_ref, last_update, min_interval, max_interval FROM basic_event_element WHERE id = $1`, id).Scan(
+	err = tx.QueryRow(ctx, `SELECT observed_ref, direction, state, message_topic, message_broker_ref, last_update, min_interval, max_interval FROM basic_event_element WHERE id = $1`, id).Scan(
 		&observedRef, &direction, &state, &messageTopic, &messageBrokerRef, &lastUpdate, &minInterval, &maxInterval)
 	if err != nil {
 		return nil, err
@@ -108,7 +111,7 @@ func (p PostgreSQLBasicEventElementHandler) Read(tx *sql.Tx, submodelId string,
 	}
 
 	if observedRef.Valid {
-		ref, err := readReference(tx, observedRef.Int64)
+		ref, err := readReference(ctx, tx, observedRef.Int64)
 		if err != nil {
 			return nil, err
 		}
@@ -116,7 +119,7 @@ func (p PostgreSQLBasicEventElementHandler) Read(tx *sql.Tx, submodelId string,
 	}
 
 	if messageBrokerRef.Valid {
-		ref, err := readReference(tx, messageBrokerRef.Int64)
+		ref, err := readReference(ctx, tx, messageBrokerRef.Int64)
 		if err != nil {
 			return nil, err
 		}
@@ -138,43 +141,23 @@ func (p PostgreSQLBasicEventElementHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertBasicEventElement(basicEvent *gen.BasicEventElement, tx *sql.Tx, id int) error {
+func insertBasicEventElement(ctx context.Context, basicEvent *gen.BasicEventElement, tx elementWriter, id int) error {
 	var observedRefID sql.NullInt64
 	if !isEmptyReference(basicEvent.Observed) {
-		var refID int
-		err := tx.QueryRow(`INSERT INTO reference (type) VALUES ($1) RETURNING id`, basicEvent.Observed.Type).Scan(&refID)
+		refID, err := tx.InsertReference(ctx, *basicEvent.Observed)
 		if err != nil {
 			return err
 		}
 		observedRefID = sql.NullInt64{Int64: int64(refID), Valid: true}
-
-		keys := basicEvent.Observed.Keys
-		for i := range keys {
-			_, err = tx.Exec(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
-				refID, i, keys[i].Type, keys[i].Value)
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	var messageBrokerRefID sql.NullInt64
 	if !isEmptyReference(basicEvent.MessageBroker) {
-		var refID int
-		err := tx.QueryRow(`INSERT INTO reference (type) VALUES ($1) RETURNING id`, basicEvent.MessageBroker.Type).Scan(&refID)
+		refID, err := tx.InsertReference(ctx, *basicEvent.MessageBroker)
 		if err != nil {
 			return err
 		}
 		messageBrokerRefID = sql.NullInt64{Int64: int64(refID), Valid: true}
-
-		keys := basicEvent.MessageBroker.Keys
-		for i := range keys {
-			_, err = tx.Exec(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
-				refID, i, keys[i].Type, keys[i].Value)
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	// Handle nullable fields
@@ -198,7 +181,7 @@ func insertBasicEventElement(basicEvent *gen.BasicEventElement, tx *sql.Tx, id i
 		messageTopic = sql.NullString{String: basicEvent.MessageTopic, Valid: true}
 	}
 
-	_, err := tx.Exec(`INSERT INTO basic_event_element (id, observed_ref, direction, state, message_topic, message_broker_ref, last_update, min_interval, max_interval) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+	_, err := tx.Exec(ctx, `INSERT INTO basic_event_element (id, observed_ref, direction, state, message_topic, message_broker_ref, last_update, min_interval, max_interval) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
 		id, observedRefID, basicEvent.Direction, basicEvent.State, messageTopic, messageBrokerRefID, lastUpdate, minInterval, maxInterval)
 	return err
 }
@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLBlobHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLBlobHandler(db *sql.DB) (*PostgreSQLBlobHandler, error) {
+func NewPostgreSQLBlobHandler(db *pgxpool.Pool) (*PostgreSQLBlobHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,21 +23,20 @@ func NewPostgreSQLBlobHandler(db *sql.DB) (*PostgreSQLBlobHandler, error) {
 	return &PostgreSQLBlobHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLBlobHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLBlobHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	blob, ok := submodelElement.(*gen.Blob)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Blob")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Blob-specific database insertion
-	_, err = tx.Exec(`INSERT INTO blob_element (id, content_type, value) VALUES ($1, $2, $3)`,
-		id, blob.ContentType, []byte(blob.Value))
+	err = insertBlob(ctx, blob, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -43,21 +44,20 @@ func (p PostgreSQLBlobHandler) Create(tx *sql.Tx, submodelId string, submodelEle
 	return id, nil
 }
 
-func (p PostgreSQLBlobHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLBlobHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	blob, ok := submodelElement.(*gen.Blob)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Blob")
 	}
 
 	// Create the nested blob with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Blob-specific database insertion for nested element
-	_, err = tx.Exec(`INSERT INTO blob_element (id, content_type, value) VALUES ($1, $2, $3)`,
-		id, blob.ContentType, []byte(blob.Value))
+	err = insertBlob(ctx, blob, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -65,15 +65,15 @@ func (p PostgreSQLBlobHandler) CreateNested(tx *sql.Tx, submodelId string, paren
 	return id, nil
 }
 
-func (p PostgreSQLBlobHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLBlobHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.Blob{}
 	var contentType string
 	var value []byte
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`
+	err = tx.QueryRow(ctx, `
 		SELECT content_type, value
 		FROM blob_element
 		WHERE id = $1
@@ -98,3 +98,9 @@ func (p PostgreSQLBlobHandler) Delete(idShortOrPath string) error {
 	}
 	return nil
 }
+
+func insertBlob(ctx context.Context, blob *gen.Blob, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO blob_element (id, content_type, value) VALUES ($1, $2, $3)`,
+		id, blob.ContentType, []byte(blob.Value))
+	return err
+}
@@ -0,0 +1,481 @@
+package submodelelements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	persistence_utils "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/utils"
+)
+
+// elementWriter receives the rows of the type specific tables of a submodel element.
+// txWriter executes them right away, bulkWriter collects them and sends them at once.
+type elementWriter interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	// InsertReference stores ref with its keys and returns the id of the reference row.
+	InsertReference(ctx context.Context, ref gen.Reference) (int, error)
+}
+
+type txWriter struct {
+	pgx.Tx
+}
+
+func (w txWriter) InsertReference(ctx context.Context, ref gen.Reference) (int, error) {
+	return insertReference(ctx, w.Tx, ref)
+}
+
+// insertSupplementalSemanticIds stores the supplementalSemanticIds of the element with id.
+func insertSupplementalSemanticIds(ctx context.Context, w elementWriter, id int, refs []gen.Reference) error {
+	for _, ref := range refs {
+		refId, err := w.InsertReference(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Exec(ctx, `INSERT INTO sme_supplemental_semantic (sme_id, reference_id) VALUES ($1, $2)`, id, refId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertQualifiers stores the qualifiers of the element with id. Their values are typed as the
+// values of properties.
+func insertQualifiers(ctx context.Context, w elementWriter, id int, qualifiers []gen.Qualifier) error {
+	for _, q := range qualifiers {
+		var valueId sql.NullInt64
+		if !isEmptyReference(q.ValueId) {
+			refId, err := w.InsertReference(ctx, *q.ValueId)
+			if err != nil {
+				return err
+			}
+			valueId = sql.NullInt64{Int64: int64(refId), Valid: true}
+		}
+		kind := q.Kind
+		if kind == "" {
+			kind = gen.QUALIFIERKIND_CONCEPT_QUALIFIER
+		}
+		text, num, boolean, timeOfDay, dateTime := typedValue(q.ValueType, q.Value)
+		_, err := w.Exec(ctx, `INSERT INTO qualifier (submodel_element_id, kind, type, value_type, value_text, value_num, value_bool, value_time, value_datetime, value_id)
+					 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			id, kind, q.Type, q.ValueType, text, num, boolean, timeOfDay, dateTime, valueId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referenceIdChunk is the number of reference ids taken from the sequence at once.
+const referenceIdChunk = 256
+
+// BulkElement is the root of a tree of submodel elements to insert.
+type BulkElement struct {
+	Element gen.SubmodelElement
+	// ParentId is the database id of the parent element, 0 for a top level element.
+	ParentId    int
+	IdShortPath string
+	Position    int
+}
+
+// flatElement is a node of the flattened tree. parent is the index of the parent in the
+// flattened slice or -1 for a root. role and rolePosition place a variable of an Operation,
+// annotation marks an annotation of an AnnotatedRelationshipElement.
+type flatElement struct {
+	BulkElement
+	parent       int
+	role         string
+	rolePosition int
+	annotation   bool
+}
+
+// BulkInsertSubmodelElements inserts the trees below roots with a constant number of round
+// trips: the trees are flattened in memory, element ids and the groups of displayNames and
+// descriptions are taken up front, submodel_element, reference, reference_key and language
+// string rows are loaded with COPY and the rows of the type specific tables are sent as one
+// batch. Element types without a bulk insert are created
+// by their handlers.
+func BulkInsertSubmodelElements(ctx context.Context, tx pgx.Tx, db *pgxpool.Pool, submodelId string, roots []BulkElement) error {
+	elements, err := flattenSubmodelElements(submodelId, roots)
+	if err != nil {
+		return err
+	}
+	if err := checkIdShortPathsAvailable(ctx, tx, submodelId, roots); err != nil {
+		return err
+	}
+
+	var bulk, deferred []int
+	for i, el := range elements {
+		if isBulkInsertable(el.Element) {
+			bulk = append(bulk, i)
+		} else {
+			deferred = append(deferred, i)
+		}
+	}
+
+	ids := make([]int, len(elements))
+	elementIds, err := nextIds(ctx, tx, "submodel_element", len(bulk))
+	if err != nil {
+		return err
+	}
+	for n, i := range bulk {
+		ids[i] = elementIds[n]
+	}
+
+	w := &bulkWriter{tx: tx}
+	var displayNames, descriptions int
+	for _, i := range bulk {
+		if len(elements[i].Element.GetDisplayName()) > 0 {
+			displayNames++
+		}
+		if len(elements[i].Element.GetDescription()) > 0 {
+			descriptions++
+		}
+	}
+	if w.displayNameIds, err = newLangStringReferences(ctx, tx, "lang_string_name_type_reference", displayNames); err != nil {
+		return err
+	}
+	if w.descriptionIds, err = newLangStringReferences(ctx, tx, "lang_string_text_type_reference", descriptions); err != nil {
+		return err
+	}
+	for _, i := range bulk {
+		el := elements[i]
+		parentId := parentIdOf(elements, ids, i)
+		if err := w.addElement(ctx, submodelId, ids[i], parentId, el.BulkElement); err != nil {
+			return err
+		}
+		switch {
+		case el.role != "":
+			_, err = w.Exec(ctx, `INSERT INTO operation_variable (operation_id, role, position, value_sme) VALUES ($1, $2, $3, $4)`,
+				parentId, el.role, el.rolePosition, ids[i])
+		case el.annotation:
+			_, err = w.Exec(ctx, `INSERT INTO annotated_rel_annotation (rel_id, annotation_sme) VALUES ($1, $2)`, parentId, ids[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.flush(ctx); err != nil {
+		return err
+	}
+
+	for _, i := range deferred {
+		el := elements[i]
+		handler, err := GetSMEHandler(el.Element, db)
+		if err != nil {
+			return err
+		}
+		parentId := parentIdOf(elements, ids, i)
+		if parentId == 0 {
+			_, err = handler.Create(ctx, tx, submodelId, el.Element)
+		} else {
+			_, err = handler.CreateNested(ctx, tx, submodelId, parentId, el.IdShortPath, el.Element, el.Position)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenSubmodelElements lists the roots and all elements nested in them, parents before
+// their children, and rejects duplicate idShort paths. Besides the values of collections and
+// lists, the statements of entities, the annotations of annotated relationships and the
+// variables of operations are nested below their element, addressed by their idShort.
+func flattenSubmodelElements(submodelId string, roots []BulkElement) ([]flatElement, error) {
+	var elements []flatElement
+	paths := make(map[string]bool)
+	add := func(el flatElement) error {
+		if paths[el.IdShortPath] {
+			return common.NewErrConflict(fmt.Sprintf("SubmodelElement with submodelId '%s' and idshort_path '%s' already exists", submodelId, el.IdShortPath))
+		}
+		paths[el.IdShortPath] = true
+		elements = append(elements, el)
+		return nil
+	}
+
+	for _, root := range roots {
+		if err := add(flatElement{BulkElement: root, parent: -1}); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < len(elements); i++ {
+		path := elements[i].IdShortPath
+		nested := func(children []gen.SubmodelElement, link func(*flatElement, int)) error {
+			for pos, child := range children {
+				el := flatElement{BulkElement: BulkElement{Element: child, IdShortPath: path + "." + child.GetIdShort(), Position: pos}, parent: i}
+				if link != nil {
+					link(&el, pos)
+				}
+				if err := add(el); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		switch string(elements[i].Element.GetModelType()) {
+		case "SubmodelElementCollection":
+			collection, ok := elements[i].Element.(*gen.SubmodelElementCollection)
+			if !ok {
+				return nil, common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementCollection' is not of type SubmodelElementCollection")
+			}
+			if err := nested(collection.Value, nil); err != nil {
+				return nil, err
+			}
+		case "SubmodelElementList":
+			list, ok := elements[i].Element.(*gen.SubmodelElementList)
+			if !ok {
+				return nil, common.NewInternalServerError("SubmodelElement with modelType 'SubmodelElementList' is not of type SubmodelElementList")
+			}
+			for pos, child := range list.Value {
+				if err := add(flatElement{BulkElement: BulkElement{Element: child, IdShortPath: path + "[" + strconv.Itoa(pos) + "]", Position: pos}, parent: i}); err != nil {
+					return nil, err
+				}
+			}
+		case "Entity":
+			entity, ok := elements[i].Element.(*gen.Entity)
+			if !ok {
+				return nil, common.NewInternalServerError("SubmodelElement with modelType 'Entity' is not of type Entity")
+			}
+			if err := nested(entity.Statements, nil); err != nil {
+				return nil, err
+			}
+		case "AnnotatedRelationshipElement":
+			are, ok := elements[i].Element.(*gen.AnnotatedRelationshipElement)
+			if !ok {
+				return nil, common.NewInternalServerError("SubmodelElement with modelType 'AnnotatedRelationshipElement' is not of type AnnotatedRelationshipElement")
+			}
+			if err := nested(are.Annotations, func(el *flatElement, _ int) { el.annotation = true }); err != nil {
+				return nil, err
+			}
+		case "Operation":
+			operation, ok := elements[i].Element.(*gen.Operation)
+			if !ok {
+				return nil, common.NewInternalServerError("SubmodelElement with modelType 'Operation' is not of type Operation")
+			}
+			// positions count through all variables, the position within the role is kept
+			// in operation_variable
+			var values []gen.SubmodelElement
+			var roles []string
+			var rolePositions []int
+			for _, group := range []struct {
+				role      string
+				variables []gen.OperationVariable
+			}{{"in", operation.InputVariables}, {"out", operation.OutputVariables}, {"inout", operation.InoutputVariables}} {
+				for n, v := range group.variables {
+					values = append(values, v.Value)
+					roles = append(roles, group.role)
+					rolePositions = append(rolePositions, n)
+				}
+			}
+			if err := nested(values, func(el *flatElement, pos int) { el.role, el.rolePosition = roles[pos], rolePositions[pos] }); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return elements, nil
+}
+
+// checkIdShortPathsAvailable fails if an element already uses the path of a root. Nested paths
+// start with the path of their root, so checking the roots is sufficient.
+func checkIdShortPathsAvailable(ctx context.Context, tx pgx.Tx, submodelId string, roots []BulkElement) error {
+	paths := make([]string, len(roots))
+	for i, root := range roots {
+		paths[i] = root.IdShortPath
+	}
+	var existing string
+	err := tx.QueryRow(ctx, `SELECT idshort_path FROM submodel_element WHERE submodel_id = $1 AND idshort_path = ANY($2) LIMIT 1`,
+		submodelId, paths).Scan(&existing)
+	if err == nil {
+		return common.NewErrConflict(fmt.Sprintf("SubmodelElement with submodelId '%s' and idshort_path '%s' already exists", submodelId, existing))
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+func isBulkInsertable(element gen.SubmodelElement) bool {
+	switch element.(type) {
+	case *gen.Property, *gen.MultiLanguageProperty, *gen.Range, *gen.Blob, *gen.File,
+		*gen.ReferenceElement, *gen.RelationshipElement, *gen.AnnotatedRelationshipElement,
+		*gen.BasicEventElement, *gen.Capability, *gen.Entity, *gen.Operation,
+		*gen.SubmodelElementCollection, *gen.SubmodelElementList:
+		return true
+	}
+	return false
+}
+
+func parentIdOf(elements []flatElement, ids []int, i int) int {
+	if elements[i].parent < 0 {
+		return elements[i].ParentId
+	}
+	return ids[elements[i].parent]
+}
+
+// nextIds takes n values from the id sequence of table.
+func nextIds(ctx context.Context, tx pgx.Tx, table string, n int) ([]int, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	rows, err := tx.Query(ctx, `SELECT nextval(pg_get_serial_sequence($1, 'id')) FROM generate_series(1, $2)`, table, n)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int])
+}
+
+// newLangStringReferences inserts n rows into table, one of the tables that group language
+// strings, and returns their ids.
+func newLangStringReferences(ctx context.Context, tx pgx.Tx, table string, n int) ([]int, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	rows, err := tx.Query(ctx, `INSERT INTO `+table+` SELECT FROM generate_series(1, $1) RETURNING id`, n)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int])
+}
+
+// bulkWriter collects the rows of a bulk insert until flush. displayNameIds and
+// descriptionIds are the language string groups created up front for the elements that have
+// a displayName or description.
+type bulkWriter struct {
+	tx             pgx.Tx
+	referenceIds   []int
+	displayNameIds []int
+	descriptionIds []int
+	elements       [][]any
+	references     [][]any
+	referenceKeys  [][]any
+	displayNames   [][]any
+	descriptions   [][]any
+	batch          pgx.Batch
+}
+
+func (w *bulkWriter) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	w.batch.Queue(sql, arguments...)
+	return pgconn.CommandTag{}, nil
+}
+
+func (w *bulkWriter) InsertReference(ctx context.Context, ref gen.Reference) (int, error) {
+	if len(w.referenceIds) == 0 {
+		ids, err := nextIds(ctx, w.tx, "reference", referenceIdChunk)
+		if err != nil {
+			return 0, err
+		}
+		w.referenceIds = ids
+	}
+	id := w.referenceIds[0]
+	w.referenceIds = w.referenceIds[1:]
+
+	w.references = append(w.references, []any{id, string(ref.Type)})
+	for i, key := range ref.Keys {
+		w.referenceKeys = append(w.referenceKeys, []any{id, i, string(key.Type), key.Value})
+	}
+	return id, nil
+}
+
+// addElement collects the rows of a single element, matching what its handler writes.
+func (w *bulkWriter) addElement(ctx context.Context, submodelId string, id int, parentId int, el BulkElement) error {
+	var semanticId any
+	if ref := el.Element.GetSemanticId(); ref != nil && !reflect.DeepEqual(*ref, gen.Reference{}) {
+		refId, err := w.InsertReference(ctx, *ref)
+		if err != nil {
+			return err
+		}
+		semanticId = refId
+	}
+	var parent any
+	if parentId != 0 {
+		parent = parentId
+	}
+	var displayNameId, descriptionId any
+	if names := el.Element.GetDisplayName(); len(names) > 0 {
+		displayNameId, w.displayNameIds = w.displayNameIds[0], w.displayNameIds[1:]
+		for _, name := range names {
+			w.displayNames = append(w.displayNames, []any{displayNameId, name.Language, name.Text})
+		}
+	}
+	if texts := el.Element.GetDescription(); len(texts) > 0 {
+		descriptionId, w.descriptionIds = w.descriptionIds[0], w.descriptionIds[1:]
+		for _, text := range texts {
+			w.descriptions = append(w.descriptions, []any{descriptionId, text.Language, text.Text})
+		}
+	}
+	w.elements = append(w.elements, []any{
+		id, submodelId, parent, el.Position, el.Element.GetIdShort(), el.Element.GetCategory(),
+		string(el.Element.GetModelType()), semanticId, el.IdShortPath, displayNameId, descriptionId,
+	})
+	if err := insertSupplementalSemanticIds(ctx, w, id, el.Element.GetSupplementalSemanticIds()); err != nil {
+		return err
+	}
+	if err := insertQualifiers(ctx, w, id, el.Element.GetQualifiers()); err != nil {
+		return err
+	}
+
+	switch element := el.Element.(type) {
+	case *gen.Property:
+		return insertProperty(ctx, element, nil, w, id)
+	case *gen.MultiLanguageProperty:
+		return insertMultiLanguageProperty(ctx, element, w, id)
+	case *gen.Range:
+		return insertRange(ctx, element, w, id)
+	case *gen.Blob:
+		return insertBlob(ctx, element, w, id)
+	case *gen.File:
+		return insertFile(ctx, element, w, id)
+	case *gen.ReferenceElement:
+		return insertReferenceElement(ctx, element, w, id)
+	case *gen.RelationshipElement:
+		return insertRelationshipElement(ctx, element, w, id)
+	case *gen.AnnotatedRelationshipElement:
+		return insertAnnotatedRelationshipElement(ctx, element, w, id)
+	case *gen.Operation:
+		return insertOperation(ctx, w, id)
+	case *gen.BasicEventElement:
+		return insertBasicEventElement(ctx, element, w, id)
+	case *gen.Capability:
+		return insertCapability(ctx, element, w, id)
+	case *gen.Entity:
+		return insertEntity(ctx, element, w, id)
+	case *gen.SubmodelElementCollection:
+		return insertSubmodelElementCollection(ctx, w, id)
+	case *gen.SubmodelElementList:
+		return insertSubmodelElementList(ctx, element, w, id)
+	}
+	return fmt.Errorf("bulk insert does not support modelType '%s'", el.Element.GetModelType())
+}
+
+// flush copies the collected rows in foreign key order and sends the batch.
+func (w *bulkWriter) flush(ctx context.Context) error {
+	copies := []struct {
+		table   string
+		columns []string
+		rows    [][]any
+	}{
+		{"reference", []string{"id", "type"}, w.references},
+		{"reference_key", []string{"reference_id", "position", "type", "value"}, w.referenceKeys},
+		{"lang_string_name_type", []string{"lang_string_name_type_reference_id", "language", "text"}, w.displayNames},
+		{"lang_string_text_type", []string{"lang_string_text_type_reference_id", "language", "text"}, w.descriptions},
+		{"submodel_element", []string{"id", "submodel_id", "parent_sme_id", "position", "id_short", "category", "model_type", "semantic_id", "idshort_path", "displayname_id", "description_id"}, w.elements},
+	}
+	for _, c := range copies {
+		if len(c.rows) == 0 {
+			continue
+		}
+		if _, err := w.tx.CopyFrom(ctx, pgx.Identifier{c.table}, c.columns, pgx.CopyFromRows(c.rows)); err != nil {
+			return err
+		}
+	}
+	return persistence_utils.ExecBatch(ctx, w.tx, &w.batch)
+}
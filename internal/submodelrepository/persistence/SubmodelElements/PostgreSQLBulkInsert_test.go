@@ -0,0 +1,113 @@
+package submodelelements
+
+import (
+	"strings"
+	"testing"
+
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func TestFlattenSubmodelElements(t *testing.T) {
+	list := &gen.SubmodelElementList{IdShort: "list", ModelType: "SubmodelElementList", Value: []gen.SubmodelElement{
+		&gen.Property{ModelType: "Property", ValueType: "xs:int", Value: "1"},
+		&gen.SubmodelElementCollection{IdShort: "entry", ModelType: "SubmodelElementCollection", Value: []gen.SubmodelElement{
+			&gen.File{IdShort: "file", ModelType: "File"},
+		}},
+	}}
+	root := &gen.SubmodelElementCollection{IdShort: "root", ModelType: "SubmodelElementCollection", Value: []gen.SubmodelElement{
+		&gen.Property{IdShort: "a", ModelType: "Property", ValueType: "xs:string"},
+		list,
+	}}
+
+	elements, err := flattenSubmodelElements("sm", []BulkElement{{Element: root, ParentId: 7, IdShortPath: "parent.root", Position: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		path     string
+		position int
+		parent   int
+	}{
+		{"parent.root", 3, -1},
+		{"parent.root.a", 0, 0},
+		{"parent.root.list", 1, 0},
+		{"parent.root.list[0]", 0, 2},
+		{"parent.root.list[1]", 1, 2},
+		{"parent.root.list[1].file", 0, 4},
+	}
+	if len(elements) != len(want) {
+		t.Fatalf("expected %d elements, got %d", len(want), len(elements))
+	}
+	for i, w := range want {
+		el := elements[i]
+		if el.IdShortPath != w.path || el.Position != w.position || el.parent != w.parent {
+			t.Errorf("element %d: got path %q position %d parent %d, want %+v", i, el.IdShortPath, el.Position, el.parent, w)
+		}
+	}
+
+	ids := []int{100, 101, 102, 103, 104, 105}
+	if got := parentIdOf(elements, ids, 0); got != 7 {
+		t.Errorf("expected the root to keep its parent id, got %d", got)
+	}
+	if got := parentIdOf(elements, ids, 5); got != 104 {
+		t.Errorf("expected parent id 104, got %d", got)
+	}
+}
+
+func TestFlattenSubmodelElementsRejectsDuplicatePaths(t *testing.T) {
+	root := &gen.SubmodelElementCollection{IdShort: "root", ModelType: "SubmodelElementCollection", Value: []gen.SubmodelElement{
+		&gen.Property{IdShort: "a", ModelType: "Property"},
+		&gen.Blob{IdShort: "a", ModelType: "Blob"},
+	}}
+	_, err := flattenSubmodelElements("sm", []BulkElement{{Element: root, IdShortPath: "root"}})
+	if err == nil || !strings.Contains(err.Error(), "'root.a' already exists") {
+		t.Fatalf("expected duplicate path error, got %v", err)
+	}
+}
+
+func TestFlattenSubmodelElementsNestsStatementsAnnotationsAndVariables(t *testing.T) {
+	entity := &gen.Entity{IdShort: "entity", ModelType: "Entity", Statements: []gen.SubmodelElement{
+		&gen.Property{IdShort: "s", ModelType: "Property", ValueType: "xs:string"},
+	}}
+	are := &gen.AnnotatedRelationshipElement{IdShort: "rel", ModelType: "AnnotatedRelationshipElement", Annotations: []gen.SubmodelElement{
+		&gen.Property{IdShort: "note", ModelType: "Property", ValueType: "xs:string"},
+	}}
+	op := &gen.Operation{IdShort: "op", ModelType: "Operation",
+		InputVariables:  []gen.OperationVariable{{Value: &gen.Property{IdShort: "in0", ModelType: "Property", ValueType: "xs:int"}}},
+		OutputVariables: []gen.OperationVariable{{Value: &gen.Property{IdShort: "out0", ModelType: "Property", ValueType: "xs:int"}}, {Value: &gen.Property{IdShort: "out1", ModelType: "Property", ValueType: "xs:int"}}},
+	}
+
+	elements, err := flattenSubmodelElements("sm", []BulkElement{{Element: entity, IdShortPath: "entity"}, {Element: are, IdShortPath: "rel", Position: 1}, {Element: op, IdShortPath: "op", Position: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		path         string
+		position     int
+		parent       int
+		role         string
+		rolePosition int
+		annotation   bool
+	}{
+		{"entity", 0, -1, "", 0, false},
+		{"rel", 1, -1, "", 0, false},
+		{"op", 2, -1, "", 0, false},
+		{"entity.s", 0, 0, "", 0, false},
+		{"rel.note", 0, 1, "", 0, true},
+		{"op.in0", 0, 2, "in", 0, false},
+		{"op.out0", 1, 2, "out", 0, false},
+		{"op.out1", 2, 2, "out", 1, false},
+	}
+	if len(elements) != len(want) {
+		t.Fatalf("expected %d elements, got %d", len(want), len(elements))
+	}
+	for i, w := range want {
+		el := elements[i]
+		if el.IdShortPath != w.path || el.Position != w.position || el.parent != w.parent || el.role != w.role || el.rolePosition != w.rolePosition || el.annotation != w.annotation {
+			t.Errorf("element %d: got path %q position %d parent %d role %q/%d annotation %v, want %+v",
+				i, el.IdShortPath, el.Position, el.parent, el.role, el.rolePosition, el.annotation, w)
+		}
+	}
+}
@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLCapabilityHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLCapabilityHandler(db *sql.DB) (*PostgreSQLCapabilityHandler, error) {
+func NewPostgreSQLCapabilityHandler(db *pgxpool.Pool) (*PostgreSQLCapabilityHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +23,20 @@ func NewPostgreSQLCapabilityHandler(db *sql.DB) (*PostgreSQLCapabilityHandler, e
 	return &PostgreSQLCapabilityHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLCapabilityHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLCapabilityHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	capability, ok := submodelElement.(*gen.Capability)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Capability")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Capability-specific database insertion
-	err = insertCapability(capability, tx, id)
+	err = insertCapability(ctx, capability, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +44,20 @@ func (p PostgreSQLCapabilityHandler) Create(tx *sql.Tx, submodelId string, submo
 	return id, nil
 }
 
-func (p PostgreSQLCapabilityHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLCapabilityHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	capability, ok := submodelElement.(*gen.Capability)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Capability")
 	}
 
 	// Create the nested capability with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Capability-specific database insertion for nested element
-	err = insertCapability(capability, tx, id)
+	err = insertCapability(ctx, capability, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,10 +65,10 @@ func (p PostgreSQLCapabilityHandler) CreateNested(tx *sql.Tx, submodelId string,
 	return id, nil
 }
 
-func (p PostgreSQLCapabilityHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLCapabilityHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	// First, get the base submodel element
 	var baseSME gen.SubmodelElement
-	_, err := p.decorated.Read(tx, submodelId, idShortOrPath, &baseSME)
+	_, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &baseSME)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +95,7 @@ func (p PostgreSQLCapabilityHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertCapability(capability *gen.Capability, tx *sql.Tx, id int) error {
-	_, err := tx.Exec(`INSERT INTO capability_element (id) VALUES ($1)`, id)
+func insertCapability(ctx context.Context, capability *gen.Capability, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO capability_element (id) VALUES ($1)`, id)
 	return err
 }
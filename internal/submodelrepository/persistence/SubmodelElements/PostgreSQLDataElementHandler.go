@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLDataElementHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLDataElementHandler(db *sql.DB) (*PostgreSQLDataElementHandler, error) {
+func NewPostgreSQLDataElementHandler(db *pgxpool.Pool) (*PostgreSQLDataElementHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,14 +23,14 @@ func NewPostgreSQLDataElementHandler(db *sql.DB) (*PostgreSQLDataElementHandler,
 	return &PostgreSQLDataElementHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLDataElementHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLDataElementHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	_, ok := submodelElement.(*gen.DataElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type DataElement")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
@@ -41,13 +43,13 @@ func (p PostgreSQLDataElementHandler) Create(tx *sql.Tx, submodelId string, subm
 	return id, nil
 }
 
-func (p PostgreSQLDataElementHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLDataElementHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	return 0, errors.New("not implemented")
 }
 
-func (p PostgreSQLDataElementHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLDataElementHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement
-	_, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	_, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLEntityHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLEntityHandler(db *sql.DB) (*PostgreSQLEntityHandler, error) {
+func NewPostgreSQLEntityHandler(db *pgxpool.Pool) (*PostgreSQLEntityHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +24,20 @@ func NewPostgreSQLEntityHandler(db *sql.DB) (*PostgreSQLEntityHandler, error) {
 	return &PostgreSQLEntityHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLEntityHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLEntityHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	entity, ok := submodelElement.(*gen.Entity)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Entity")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Entity-specific database insertion
-	err = insertEntity(entity, tx, id)
+	err = insertEntity(ctx, entity, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +45,20 @@ func (p PostgreSQLEntityHandler) Create(tx *sql.Tx, submodelId string, submodelE
 	return id, nil
 }
 
-func (p PostgreSQLEntityHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLEntityHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	entity, ok := submodelElement.(*gen.Entity)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Entity")
 	}
 
 	// Create the nested entity with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Entity-specific database insertion for nested element
-	err = insertEntity(entity, tx, id)
+	err = insertEntity(ctx, entity, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,9 +66,9 @@ func (p PostgreSQLEntityHandler) CreateNested(tx *sql.Tx, submodelId string, par
 	return id, nil
 }
 
-func (p PostgreSQLEntityHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLEntityHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement
-	_, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	_, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +87,8 @@ func (p PostgreSQLEntityHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertEntity(entity *gen.Entity, tx *sql.Tx, id int) error {
-	_, err := tx.Exec(`INSERT INTO entity_element (id, entity_type, global_asset_id) VALUES ($1, $2, $3)`,
+func insertEntity(ctx context.Context, entity *gen.Entity, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO entity_element (id, entity_type, global_asset_id) VALUES ($1, $2, $3)`,
 		id, entity.EntityType, entity.GlobalAssetId)
 	if err != nil {
 		return err
@@ -95,13 +98,13 @@ func insertEntity(entity *gen.Entity, tx *sql.Tx, id int) error {
 	for _, sai := range entity.SpecificAssetIds {
 		var extRef sql.NullInt64
 		if !isEmptyReference(sai.ExternalSubjectId) {
-			refId, err := insertReference(tx, *sai.ExternalSubjectId)
+			refId, err := tx.InsertReference(ctx, *sai.ExternalSubjectId)
 			if err != nil {
 				return err
 			}
 			extRef = sql.NullInt64{Int64: int64(refId), Valid: true}
 		}
-		_, err = tx.Exec(`INSERT INTO entity_specific_asset_id (entity_id, name, value, external_subject_ref) VALUES ($1, $2, $3, $4)`,
+		_, err = tx.Exec(ctx, `INSERT INTO entity_specific_asset_id (entity_id, name, value, external_subject_ref) VALUES ($1, $2, $3, $4)`,
 			id, sai.Name, sai.Value, extRef)
 		if err != nil {
 			return err
@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLFileHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLFileHandler(db *sql.DB) (*PostgreSQLFileHandler, error) {
+func NewPostgreSQLFileHandler(db *pgxpool.Pool) (*PostgreSQLFileHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +23,19 @@ func NewPostgreSQLFileHandler(db *sql.DB) (*PostgreSQLFileHandler, error) {
 	return &PostgreSQLFileHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLFileHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLFileHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	file, ok := submodelElement.(*gen.File)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type File")
 	}
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// File-specific database insertion
-	_, err = tx.Exec(`INSERT INTO file_element (id, content_type, value) VALUES ($1, $2, $3)`,
-		id, file.ContentType, file.Value)
+	err = insertFile(ctx, file, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,21 +43,20 @@ func (p PostgreSQLFileHandler) Create(tx *sql.Tx, submodelId string, submodelEle
 	return id, nil
 }
 
-func (p PostgreSQLFileHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLFileHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	file, ok := submodelElement.(*gen.File)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type File")
 	}
 
 	// Create the nested file with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// File-specific database insertion for nested element
-	_, err = tx.Exec(`INSERT INTO file_element (id, content_type, value) VALUES ($1, $2, $3)`,
-		id, file.ContentType, file.Value)
+	err = insertFile(ctx, file, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -64,14 +64,14 @@ func (p PostgreSQLFileHandler) CreateNested(tx *sql.Tx, submodelId string, paren
 	return id, nil
 }
 
-func (p PostgreSQLFileHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLFileHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.File{}
 	var contentType, value string
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`
+	err = tx.QueryRow(ctx, `
 		SELECT content_type, value
 		FROM file_element
 		WHERE id = $1
@@ -96,3 +96,9 @@ func (p PostgreSQLFileHandler) Delete(idShortOrPath string) error {
 	}
 	return nil
 }
+
+func insertFile(ctx context.Context, file *gen.File, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO file_element (id, content_type, value) VALUES ($1, $2, $3)`,
+		id, file.ContentType, file.Value)
+	return err
+}
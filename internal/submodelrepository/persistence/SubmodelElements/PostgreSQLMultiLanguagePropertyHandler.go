@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLMultiLanguagePropertyHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLMultiLanguagePropertyHandler(db *sql.DB) (*PostgreSQLMultiLanguagePropertyHandler, error) {
+func NewPostgreSQLMultiLanguagePropertyHandler(db *pgxpool.Pool) (*PostgreSQLMultiLanguagePropertyHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,19 +23,19 @@ func NewPostgreSQLMultiLanguagePropertyHandler(db *sql.DB) (*PostgreSQLMultiLang
 	return &PostgreSQLMultiLanguagePropertyHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLMultiLanguagePropertyHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLMultiLanguagePropertyHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	mlp, ok := submodelElement.(*gen.MultiLanguageProperty)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type MultiLanguageProperty")
 	}
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// MultiLanguageProperty-specific database insertion
-	err = insertMultiLanguageProperty(mlp, tx, id)
+	err = insertMultiLanguageProperty(ctx, mlp, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -41,20 +43,20 @@ func (p PostgreSQLMultiLanguagePropertyHandler) Create(tx *sql.Tx, submodelId st
 	return id, nil
 }
 
-func (p PostgreSQLMultiLanguagePropertyHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLMultiLanguagePropertyHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	mlp, ok := submodelElement.(*gen.MultiLanguageProperty)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type MultiLanguageProperty")
 	}
 
 	// Create the nested mlp with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// MultiLanguageProperty-specific database insertion for nested element
-	err = insertMultiLanguageProperty(mlp, tx, id)
+	err = insertMultiLanguageProperty(ctx, mlp, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -62,15 +64,15 @@ func (p PostgreSQLMultiLanguagePropertyHandler) CreateNested(tx *sql.Tx, submode
 	return id, nil
 }
 
-func (p PostgreSQLMultiLanguagePropertyHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLMultiLanguagePropertyHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.MultiLanguageProperty{}
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
 
 	// Read values
-	rows, err := tx.Query(`SELECT language, text FROM multilanguage_property_value WHERE mlp_id = $1`, id)
+	rows, err := tx.Query(ctx, `SELECT language, text FROM multilanguage_property_value WHERE mlp_id = $1`, id)
 	if err != nil {
 		return sme, nil
 	}
@@ -105,16 +107,16 @@ func (p PostgreSQLMultiLanguagePropertyHandler) Delete(idShortOrPath string) err
 	return nil
 }
 
-func insertMultiLanguageProperty(mlp *gen.MultiLanguageProperty, tx *sql.Tx, id int) error {
+func insertMultiLanguageProperty(ctx context.Context, mlp *gen.MultiLanguageProperty, tx elementWriter, id int) error {
 	// Insert into multilanguage_property
-	_, err := tx.Exec(`INSERT INTO multilanguage_property (id) VALUES ($1)`, id)
+	_, err := tx.Exec(ctx, `INSERT INTO multilanguage_property (id) VALUES ($1)`, id)
 	if err != nil {
 		return err
 	}
 
 	// Insert values
 	for _, val := range mlp.Value {
-		_, err = tx.Exec(`INSERT INTO multilanguage_property_value (mlp_id, language, text) VALUES ($1, $2, $3)`,
+		_, err = tx.Exec(ctx, `INSERT INTO multilanguage_property_value (mlp_id, language, text) VALUES ($1, $2, $3)`,
 			id, val.Language, val.Text)
 		if err != nil {
 			return err
@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLOperationHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLOperationHandler(db *sql.DB) (*PostgreSQLOperationHandler, error) {
+func NewPostgreSQLOperationHandler(db *pgxpool.Pool) (*PostgreSQLOperationHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +23,20 @@ func NewPostgreSQLOperationHandler(db *sql.DB) (*PostgreSQLOperationHandler, err
 	return &PostgreSQLOperationHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLOperationHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
-	operation, ok := submodelElement.(*gen.Operation)
+func (p PostgreSQLOperationHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+	_, ok := submodelElement.(*gen.Operation)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Operation")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Operation-specific database insertion
-	err = insertOperation(operation, tx, id, submodelId, p.db)
+	err = insertOperation(ctx, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +44,20 @@ func (p PostgreSQLOperationHandler) Create(tx *sql.Tx, submodelId string, submod
 	return id, nil
 }
 
-func (p PostgreSQLOperationHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
-	operation, ok := submodelElement.(*gen.Operation)
+func (p PostgreSQLOperationHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+	_, ok := submodelElement.(*gen.Operation)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Operation")
 	}
 
 	// Create the nested operation with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Operation-specific database insertion for nested element
-	err = insertOperation(operation, tx, id, submodelId, p.db)
+	err = insertOperation(ctx, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,10 +65,10 @@ func (p PostgreSQLOperationHandler) CreateNested(tx *sql.Tx, submodelId string,
 	return id, nil
 }
 
-func (p PostgreSQLOperationHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLOperationHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	// First, get the base submodel element
 	var baseSME gen.SubmodelElement
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &baseSME)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &baseSME)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +80,7 @@ func (p PostgreSQLOperationHandler) Read(tx *sql.Tx, submodelId string, idShortO
 	}
 
 	// Query operation variables
-	rows, err := tx.Query(`SELECT role, position, value_sme FROM operation_variable WHERE operation_id = $1 ORDER BY position`, id)
+	rows, err := tx.Query(ctx, `SELECT role, position, value_sme FROM operation_variable WHERE operation_id = $1 ORDER BY position`, id)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +98,7 @@ func (p PostgreSQLOperationHandler) Read(tx *sql.Tx, submodelId string, idShortO
 
 		// Get the idshort_path and model_type for the value SME
 		var valueIdShortPath, valueModelType string
-		err = tx.QueryRow(`SELECT idshort_path, model_type FROM submodel_element WHERE id = $1`, valueSmeId).Scan(&valueIdShortPath, &valueModelType)
+		err = tx.QueryRow(ctx, `SELECT idshort_path, model_type FROM submodel_element WHERE id = $1`, valueSmeId).Scan(&valueIdShortPath, &valueModelType)
 		if err != nil {
 			return nil, err
 		}
@@ -108,7 +110,7 @@ func (p PostgreSQLOperationHandler) Read(tx *sql.Tx, submodelId string, idShortO
 		}
 
 		// Read the value submodel element
-		valueSme, err := handler.Read(tx, submodelId, valueIdShortPath)
+		valueSme, err := handler.Read(ctx, tx, submodelId, valueIdShortPath)
 		if err != nil {
 			return nil, err
 		}
@@ -143,44 +145,9 @@ func (p PostgreSQLOperationHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertOperation(operation *gen.Operation, tx *sql.Tx, id int, submodelId string, db *sql.DB) error {
-	_, err := tx.Exec(`INSERT INTO operation_element (id) VALUES ($1)`, id)
-	if err != nil {
-		return err
-	}
-
-	// Insert variables
-	err = insertOperationVariables(tx, operation.InputVariables, "in", id, submodelId, db)
-	if err != nil {
-		return err
-	}
-	err = insertOperationVariables(tx, operation.OutputVariables, "out", id, submodelId, db)
-	if err != nil {
-		return err
-	}
-	err = insertOperationVariables(tx, operation.InoutputVariables, "inout", id, submodelId, db)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func insertOperationVariables(tx *sql.Tx, variables []gen.OperationVariable, role string, operationId int, submodelId string, db *sql.DB) error {
-	for i, ov := range variables {
-		// Create the value submodel element
-		handler, err := GetSMEHandler(ov.Value, db)
-		if err != nil {
-			return err
-		}
-		valueId, err := handler.Create(tx, submodelId, ov.Value)
-		if err != nil {
-			return err
-		}
-		_, err = tx.Exec(`INSERT INTO operation_variable (operation_id, role, position, value_sme) VALUES ($1, $2, $3, $4)`,
-			operationId, role, i, valueId)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+// insertOperation stores the row of an Operation. Its variables are elements nested below the
+// operation; BulkInsertSubmodelElements inserts them with their operation_variable rows.
+func insertOperation(ctx context.Context, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO operation_element (id) VALUES ($1)`, id)
+	return err
 }
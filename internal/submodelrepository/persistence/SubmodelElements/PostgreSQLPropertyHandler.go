@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLPropertyHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLPropertyHandler(db *sql.DB) (*PostgreSQLPropertyHandler, error) {
+func NewPostgreSQLPropertyHandler(db *pgxpool.Pool) (*PostgreSQLPropertyHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,21 +24,21 @@ func NewPostgreSQLPropertyHandler(db *sql.DB) (*PostgreSQLPropertyHandler, error
 	return &PostgreSQLPropertyHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLPropertyHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLPropertyHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	property, ok := submodelElement.(*gen.Property)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Property")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Property-specific database insertion
 	// Determine which column to use based on valueType
-	err = insertProperty(property, err, tx, id)
+	err = insertProperty(ctx, property, err, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -43,20 +46,20 @@ func (p PostgreSQLPropertyHandler) Create(tx *sql.Tx, submodelId string, submode
 	return id, nil
 }
 
-func (p PostgreSQLPropertyHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLPropertyHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	property, ok := submodelElement.(*gen.Property)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Property")
 	}
 
 	// Create the nested property with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Property-specific database insertion for nested element
-	err = insertProperty(property, err, tx, id)
+	err = insertProperty(ctx, property, err, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -64,14 +67,14 @@ func (p PostgreSQLPropertyHandler) CreateNested(tx *sql.Tx, submodelId string, p
 	return id, nil
 }
 
-func (p PostgreSQLPropertyHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLPropertyHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.Property{}
 	var valueType, value string
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`
+	err = tx.QueryRow(ctx, `
 		SELECT value_type, COALESCE(p.value_text, p.value_num::text, p.value_bool::text, p.value_time::text, p.value_datetime::text) AS value
 		FROM property_element p
 		WHERE id = $1
@@ -103,30 +106,10 @@ func (p PostgreSQLPropertyHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertProperty(property *gen.Property, err error, tx *sql.Tx, id int) error {
-	var valueText, valueNum, valueBool, valueTime, valueDatetime sql.NullString
+func insertProperty(ctx context.Context, property *gen.Property, err error, tx elementWriter, id int) error {
+	valueText, valueNum, valueBool, valueTime, valueDatetime := typedValue(property.ValueType, property.Value)
 	var valueId sql.NullInt64
 
-	switch property.ValueType {
-	case "xs:string", "xs:anyURI", "xs:base64Binary", "xs:hexBinary":
-		valueText = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	case "xs:int", "xs:integer", "xs:long", "xs:short", "xs:byte",
-		"xs:unsignedInt", "xs:unsignedLong", "xs:unsignedShort", "xs:unsignedByte",
-		"xs:positiveInteger", "xs:negativeInteger", "xs:nonNegativeInteger", "xs:nonPositiveInteger",
-		"xs:decimal", "xs:double", "xs:float":
-		valueNum = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	case "xs:boolean":
-		valueBool = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	case "xs:time":
-		valueTime = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	case "xs:date", "xs:dateTime", "xs:duration", "xs:gDay", "xs:gMonth",
-		"xs:gMonthDay", "xs:gYear", "xs:gYearMonth":
-		valueDatetime = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	default:
-		// Fallback to text for unknown types
-		valueText = sql.NullString{String: property.Value, Valid: property.Value != ""}
-	}
-
 	// Handle valueId if present
 	if property.ValueId != nil && len(property.ValueId.Keys) > 0 && property.ValueId.Keys[0].Value != "" {
 		// Assuming ValueId references another element by ID - you may need to adjust this logic
@@ -134,7 +117,7 @@ func insertProperty(property *gen.Property, err error, tx *sql.Tx, id int) error
 	}
 
 	// Insert Property-specific data
-	_, err = tx.Exec(`INSERT INTO property_element (id, value_type, value_text, value_num, value_bool, value_time, value_datetime, value_id)
+	_, err = tx.Exec(ctx, `INSERT INTO property_element (id, value_type, value_text, value_num, value_bool, value_time, value_datetime, value_id)
 					 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
 		id,
 		property.ValueType,
@@ -147,3 +130,29 @@ func insertProperty(property *gen.Property, err error, tx *sql.Tx, id int) error
 	)
 	return err
 }
+
+// typedValue returns value in the column of the typed value columns that matches valueType,
+// the others are NULL. An empty value is NULL.
+func typedValue(valueType gen.DataTypeDefXsd, value string) (valueText, valueNum, valueBool, valueTime, valueDatetime sql.NullString) {
+	v := sql.NullString{String: value, Valid: value != ""}
+	switch valueType {
+	case "xs:string", "xs:anyURI", "xs:base64Binary", "xs:hexBinary":
+		valueText = v
+	case "xs:int", "xs:integer", "xs:long", "xs:short", "xs:byte",
+		"xs:unsignedInt", "xs:unsignedLong", "xs:unsignedShort", "xs:unsignedByte",
+		"xs:positiveInteger", "xs:negativeInteger", "xs:nonNegativeInteger", "xs:nonPositiveInteger",
+		"xs:decimal", "xs:double", "xs:float":
+		valueNum = v
+	case "xs:boolean":
+		valueBool = v
+	case "xs:time":
+		valueTime = v
+	case "xs:date", "xs:dateTime", "xs:duration", "xs:gDay", "xs:gMonth",
+		"xs:gMonthDay", "xs:gYear", "xs:gYearMonth":
+		valueDatetime = v
+	default:
+		// Fallback to text for unknown types
+		valueText = v
+	}
+	return
+}
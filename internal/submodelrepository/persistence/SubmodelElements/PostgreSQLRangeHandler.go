@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLRangeHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLRangeHandler(db *sql.DB) (*PostgreSQLRangeHandler, error) {
+func NewPostgreSQLRangeHandler(db *pgxpool.Pool) (*PostgreSQLRangeHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +24,20 @@ func NewPostgreSQLRangeHandler(db *sql.DB) (*PostgreSQLRangeHandler, error) {
 	return &PostgreSQLRangeHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLRangeHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLRangeHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	rangeElem, ok := submodelElement.(*gen.Range)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Range")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// Range-specific database insertion
-	err = insertRange(rangeElem, tx, id)
+	err = insertRange(ctx, rangeElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +45,20 @@ func (p PostgreSQLRangeHandler) Create(tx *sql.Tx, submodelId string, submodelEl
 	return id, nil
 }
 
-func (p PostgreSQLRangeHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLRangeHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	rangeElem, ok := submodelElement.(*gen.Range)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type Range")
 	}
 
 	// Create the nested range with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// Range-specific database insertion for nested element
-	err = insertRange(rangeElem, tx, id)
+	err = insertRange(ctx, rangeElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,14 +66,14 @@ func (p PostgreSQLRangeHandler) CreateNested(tx *sql.Tx, submodelId string, pare
 	return id, nil
 }
 
-func (p PostgreSQLRangeHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLRangeHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.Range{}
 	var valueType, min, max string
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`
+	err = tx.QueryRow(ctx, `
 		SELECT value_type, COALESCE(min_text, min_num::text, min_time::text, min_datetime::text) as min_val,
 		COALESCE(max_text, max_num::text, max_time::text, max_datetime::text) as max_val
 		FROM range_element
@@ -102,7 +105,7 @@ func (p PostgreSQLRangeHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertRange(rangeElem *gen.Range, tx *sql.Tx, id int) error {
+func insertRange(ctx context.Context, rangeElem *gen.Range, tx elementWriter, id int) error {
 	var minText, maxText, minNum, maxNum, minTime, maxTime, minDatetime, maxDatetime sql.NullString
 
 	switch rangeElem.ValueType {
@@ -129,7 +132,7 @@ func insertRange(rangeElem *gen.Range, tx *sql.Tx, id int) error {
 	}
 
 	// Insert Range-specific data
-	_, err := tx.Exec(`INSERT INTO range_element (id, value_type, min_text, max_text, min_num, max_num, min_time, max_time, min_datetime, max_datetime)
+	_, err := tx.Exec(ctx, `INSERT INTO range_element (id, value_type, min_text, max_text, min_num, max_num, min_time, max_time, min_datetime, max_datetime)
 					 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 		id, rangeElem.ValueType,
 		minText, maxText, minNum, maxNum, minTime, maxTime, minDatetime, maxDatetime)
@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLReferenceElementHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLReferenceElementHandler(db *sql.DB) (*PostgreSQLReferenceElementHandler, error) {
+func NewPostgreSQLReferenceElementHandler(db *pgxpool.Pool) (*PostgreSQLReferenceElementHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +24,20 @@ func NewPostgreSQLReferenceElementHandler(db *sql.DB) (*PostgreSQLReferenceEleme
 	return &PostgreSQLReferenceElementHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLReferenceElementHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLReferenceElementHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	refElem, ok := submodelElement.(*gen.ReferenceElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type ReferenceElement")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// ReferenceElement-specific database insertion
-	err = insertReferenceElement(refElem, tx, id)
+	err = insertReferenceElement(ctx, refElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +45,20 @@ func (p PostgreSQLReferenceElementHandler) Create(tx *sql.Tx, submodelId string,
 	return id, nil
 }
 
-func (p PostgreSQLReferenceElementHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLReferenceElementHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	refElem, ok := submodelElement.(*gen.ReferenceElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type ReferenceElement")
 	}
 
 	// Create the nested refElem with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// ReferenceElement-specific database insertion for nested element
-	err = insertReferenceElement(refElem, tx, id)
+	err = insertReferenceElement(ctx, refElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,25 +66,25 @@ func (p PostgreSQLReferenceElementHandler) CreateNested(tx *sql.Tx, submodelId s
 	return id, nil
 }
 
-func (p PostgreSQLReferenceElementHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLReferenceElementHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.ReferenceElement{}
 	var valueRef sql.NullInt64
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`SELECT value_ref FROM reference_element WHERE id = $1`, id).Scan(&valueRef)
+	err = tx.QueryRow(ctx, `SELECT value_ref FROM reference_element WHERE id = $1`, id).Scan(&valueRef)
 	if err != nil {
 		return sme, nil
 	}
 	if valueRef.Valid {
 		// Read the reference
 		var refType string
-		err = tx.QueryRow(`SELECT type FROM reference WHERE id = $1`, valueRef.Int64).Scan(&refType)
+		err = tx.QueryRow(ctx, `SELECT type FROM reference WHERE id = $1`, valueRef.Int64).Scan(&refType)
 		if err != nil {
 			return nil, err
 		}
-		rows, err := tx.Query(`SELECT type, value FROM reference_key WHERE reference_id = $1 ORDER BY position`, valueRef.Int64)
+		rows, err := tx.Query(ctx, `SELECT type, value FROM reference_key WHERE reference_id = $1 ORDER BY position`, valueRef.Int64)
 		if err != nil {
 			return nil, err
 		}
@@ -112,30 +115,20 @@ func (p PostgreSQLReferenceElementHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func insertReferenceElement(refElem *gen.ReferenceElement, tx *sql.Tx, id int) error {
+func insertReferenceElement(ctx context.Context, refElem *gen.ReferenceElement, tx elementWriter, id int) error {
 	if isEmptyReference(refElem.Value) {
 		// Insert with NULL
-		_, err := tx.Exec(`INSERT INTO reference_element (id, value_ref) VALUES ($1, $2)`, id, nil)
+		_, err := tx.Exec(ctx, `INSERT INTO reference_element (id, value_ref) VALUES ($1, $2)`, id, nil)
 		return err
 	}
 
 	// Insert the reference
-	var refId int
-	err := tx.QueryRow(`INSERT INTO reference (type) VALUES ($1) RETURNING id`, refElem.Value.Type).Scan(&refId)
+	refId, err := tx.InsertReference(ctx, *refElem.Value)
 	if err != nil {
 		return err
 	}
 
-	// Insert reference keys
-	for i, key := range refElem.Value.Keys {
-		_, err = tx.Exec(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
-			refId, i, key.Type, key.Value)
-		if err != nil {
-			return err
-		}
-	}
-
 	// Insert reference_element
-	_, err = tx.Exec(`INSERT INTO reference_element (id, value_ref) VALUES ($1, $2)`, id, refId)
+	_, err = tx.Exec(ctx, `INSERT INTO reference_element (id, value_ref) VALUES ($1, $2)`, id, refId)
 	return err
 }
@@ -1,19 +1,23 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
+	persistence_utils "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/utils"
 )
 
 type PostgreSQLRelationshipElementHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLRelationshipElementHandler(db *sql.DB) (*PostgreSQLRelationshipElementHandler, error) {
+func NewPostgreSQLRelationshipElementHandler(db *pgxpool.Pool) (*PostgreSQLRelationshipElementHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +25,20 @@ func NewPostgreSQLRelationshipElementHandler(db *sql.DB) (*PostgreSQLRelationshi
 	return &PostgreSQLRelationshipElementHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLRelationshipElementHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLRelationshipElementHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	relElem, ok := submodelElement.(*gen.RelationshipElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type RelationshipElement")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// RelationshipElement-specific database insertion
-	err = insertRelationshipElement(relElem, tx, id)
+	err = insertRelationshipElement(ctx, relElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +46,20 @@ func (p PostgreSQLRelationshipElementHandler) Create(tx *sql.Tx, submodelId stri
 	return id, nil
 }
 
-func (p PostgreSQLRelationshipElementHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLRelationshipElementHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	relElem, ok := submodelElement.(*gen.RelationshipElement)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type RelationshipElement")
 	}
 
 	// Create the nested relElem with the provided idShortPath using the decorated handler
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// RelationshipElement-specific database insertion for nested element
-	err = insertRelationshipElement(relElem, tx, id)
+	err = insertRelationshipElement(ctx, relElem, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,27 +67,27 @@ func (p PostgreSQLRelationshipElementHandler) CreateNested(tx *sql.Tx, submodelI
 	return id, nil
 }
 
-func (p PostgreSQLRelationshipElementHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLRelationshipElementHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	var sme gen.SubmodelElement = &gen.RelationshipElement{}
 	var firstRef, secondRef sql.NullInt64
-	id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	if err != nil {
 		return nil, err
 	}
-	err = tx.QueryRow(`SELECT first_ref, second_ref FROM relationship_element WHERE id = $1`, id).Scan(&firstRef, &secondRef)
+	err = tx.QueryRow(ctx, `SELECT first_ref, second_ref FROM relationship_element WHERE id = $1`, id).Scan(&firstRef, &secondRef)
 	if err != nil {
 		return sme, nil
 	}
 	relElem := sme.(*gen.RelationshipElement)
 	if firstRef.Valid {
-		ref, err := readReference(tx, firstRef.Int64)
+		ref, err := readReference(ctx, tx, firstRef.Int64)
 		if err != nil {
 			return nil, err
 		}
 		relElem.First = ref
 	}
 	if secondRef.Valid {
-		ref, err := readReference(tx, secondRef.Int64)
+		ref, err := readReference(ctx, tx, secondRef.Int64)
 		if err != nil {
 			return nil, err
 		}
@@ -92,13 +96,13 @@ func (p PostgreSQLRelationshipElementHandler) Read(tx *sql.Tx, submodelId string
 	return sme, nil
 }
 
-func readReference(tx *sql.Tx, refId int64) (*gen.Reference, error) {
+func readReference(ctx context.Context, tx pgx.Tx, refId int64) (*gen.Reference, error) {
 	var refType string
-	err := tx.QueryRow(`SELECT type FROM reference WHERE id = $1`, refId).Scan(&refType)
+	err := tx.QueryRow(ctx, `SELECT type FROM reference WHERE id = $1`, refId).Scan(&refType)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := tx.Query(`SELECT type, value FROM reference_key WHERE reference_id = $1 ORDER BY position`, refId)
+	rows, err := tx.Query(ctx, `SELECT type, value FROM reference_key WHERE reference_id = $1 ORDER BY position`, refId)
 	if err != nil {
 		return nil, err
 	}
@@ -126,11 +130,11 @@ func (p PostgreSQLRelationshipElementHandler) Delete(idShortOrPath string) error
 	return nil
 }
 
-func insertRelationshipElement(relElem *gen.RelationshipElement, tx *sql.Tx, id int) error {
+func insertRelationshipElement(ctx context.Context, relElem *gen.RelationshipElement, tx elementWriter, id int) error {
 	var firstRefId, secondRefId sql.NullInt64
 
 	if !isEmptyReference(relElem.First) {
-		refId, err := insertReference(tx, *relElem.First)
+		refId, err := tx.InsertReference(ctx, *relElem.First)
 		if err != nil {
 			return err
 		}
@@ -138,30 +142,26 @@ func insertRelationshipElement(relElem *gen.RelationshipElement, tx *sql.Tx, id
 	}
 
 	if !isEmptyReference(relElem.Second) {
-		refId, err := insertReference(tx, *relElem.Second)
+		refId, err := tx.InsertReference(ctx, *relElem.Second)
 		if err != nil {
 			return err
 		}
 		secondRefId = sql.NullInt64{Int64: int64(refId), Valid: true}
 	}
 
-	_, err := tx.Exec(`INSERT INTO relationship_element (id, first_ref, second_ref) VALUES ($1, $2, $3)`,
+	_, err := tx.Exec(ctx, `INSERT INTO relationship_element (id, first_ref, second_ref) VALUES ($1, $2, $3)`,
 		id, firstRefId, secondRefId)
 	return err
 }
 
-func insertReference(tx *sql.Tx, ref gen.Reference) (int, error) {
+func insertReference(ctx context.Context, tx pgx.Tx, ref gen.Reference) (int, error) {
 	var refId int
-	err := tx.QueryRow(`INSERT INTO reference (type) VALUES ($1) RETURNING id`, ref.Type).Scan(&refId)
+	err := tx.QueryRow(ctx, `INSERT INTO reference (type) VALUES ($1) RETURNING id`, ref.Type).Scan(&refId)
 	if err != nil {
 		return 0, err
 	}
-	for i, key := range ref.Keys {
-		_, err = tx.Exec(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
-			refId, i, key.Type, key.Value)
-		if err != nil {
-			return 0, err
-		}
+	if err := persistence_utils.InsertReferenceKeys(ctx, tx, int64(refId), ref.Keys); err != nil {
+		return 0, err
 	}
 	return refId, nil
 }
@@ -1,17 +1,20 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 	persistence_utils "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/utils"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLSMECrudHandler struct {
-	db *sql.DB
+	db *pgxpool.Pool
 }
 
 // isEmptyReference checks if a Reference is empty (zero value)
@@ -22,19 +25,19 @@ func isEmptyReference(ref *gen.Reference) bool {
 	return reflect.DeepEqual(ref, gen.Reference{})
 }
 
-func NewPostgreSQLSMECrudHandler(db *sql.DB) (*PostgreSQLSMECrudHandler, error) {
+func NewPostgreSQLSMECrudHandler(db *pgxpool.Pool) (*PostgreSQLSMECrudHandler, error) {
 	return &PostgreSQLSMECrudHandler{db: db}, nil
 }
 
 // Create performs the base SubmodelElement operations within an existing transaction
-func (p *PostgreSQLSMECrudHandler) CreateAndPath(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, position int) (int, error) {
-	referenceID, err := persistence_utils.CreateSemanticId(tx, submodelElement.GetSemanticId())
+func (p *PostgreSQLSMECrudHandler) CreateAndPath(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, position int) (int, error) {
+	referenceID, err := persistence_utils.CreateSemanticId(ctx, tx, submodelElement.GetSemanticId())
 	if err != nil {
 		return 0, err
 	}
 	// Check if a SubmodelElement with the same submodelId and idshort_path already exists
 	var exists bool
-	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2)`,
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2)`,
 		submodelId, idShortPath).Scan(&exists)
 	if err != nil {
 		return 0, err
@@ -44,10 +47,18 @@ func (p *PostgreSQLSMECrudHandler) CreateAndPath(tx *sql.Tx, submodelId string,
 		return 0, fmt.Errorf("SubmodelElement with submodelId '%s' and idshort_path '%s' already exists",
 			submodelId, idShortPath)
 	}
+	displayNameID, err := persistence_utils.CreateLangStringNameTypes(ctx, tx, submodelElement.GetDisplayName())
+	if err != nil {
+		return 0, err
+	}
+	descriptionID, err := persistence_utils.CreateLangStringTextTypes(ctx, tx, submodelElement.GetDescription())
+	if err != nil {
+		return 0, err
+	}
 	var id int
-	err = tx.QueryRow(`	INSERT INTO
-	 					submodel_element(submodel_id, parent_sme_id, position, id_short, category, model_type, semantic_id, idshort_path)
-						VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+	err = tx.QueryRow(ctx, `	INSERT INTO
+	 					submodel_element(submodel_id, parent_sme_id, position, id_short, category, model_type, semantic_id, idshort_path, displayname_id, description_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		submodelId,
 		parentId,
 		position,
@@ -56,23 +67,31 @@ func (p *PostgreSQLSMECrudHandler) CreateAndPath(tx *sql.Tx, submodelId string,
 		submodelElement.GetModelType(),
 		referenceID, // This will be NULL if no semantic ID was provided
 		idShortPath, // Use the provided idShortPath instead of just GetIdShort()
+		displayNameID,
+		descriptionID,
 	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
+	if err := insertSupplementalSemanticIds(ctx, txWriter{tx}, id, submodelElement.GetSupplementalSemanticIds()); err != nil {
+		return 0, err
+	}
+	if err := insertQualifiers(ctx, txWriter{tx}, id, submodelElement.GetQualifiers()); err != nil {
+		return 0, err
+	}
 	//println("Inserted SubmodelElement with idShort: " + submodelElement.GetIdShort())
 
 	return id, nil
 }
 
-func (p *PostgreSQLSMECrudHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
-	referenceID, err := persistence_utils.CreateSemanticId(tx, submodelElement.GetSemanticId())
+func (p *PostgreSQLSMECrudHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+	referenceID, err := persistence_utils.CreateSemanticId(ctx, tx, submodelElement.GetSemanticId())
 	if err != nil {
 		return 0, err
 	}
 	// Check if a SubmodelElement with the same submodelId and idshort_path already exists
 	var exists bool
-	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2)`,
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2)`,
 		submodelId, submodelElement.GetIdShort()).Scan(&exists)
 	if err != nil {
 		return 0, err
@@ -82,10 +101,18 @@ func (p *PostgreSQLSMECrudHandler) Create(tx *sql.Tx, submodelId string, submode
 		return 0, fmt.Errorf("SubmodelElement with submodelId '%s' and idshort_path '%s' already exists",
 			submodelId, submodelElement.GetIdShort())
 	}
+	displayNameID, err := persistence_utils.CreateLangStringNameTypes(ctx, tx, submodelElement.GetDisplayName())
+	if err != nil {
+		return 0, err
+	}
+	descriptionID, err := persistence_utils.CreateLangStringTextTypes(ctx, tx, submodelElement.GetDescription())
+	if err != nil {
+		return 0, err
+	}
 	var id int
-	err = tx.QueryRow(`	INSERT INTO
-	 					submodel_element(submodel_id, parent_sme_id, position, id_short, category, model_type, semantic_id, idshort_path)
-						VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+	err = tx.QueryRow(ctx, `	INSERT INTO
+	 					submodel_element(submodel_id, parent_sme_id, position, id_short, category, model_type, semantic_id, idshort_path, displayname_id, description_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		submodelId,
 		nil,
 		0,
@@ -94,19 +121,27 @@ func (p *PostgreSQLSMECrudHandler) Create(tx *sql.Tx, submodelId string, submode
 		submodelElement.GetModelType(),
 		referenceID, // This will be NULL if no semantic ID was provided
 		submodelElement.GetIdShort(),
+		displayNameID,
+		descriptionID,
 	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
+	if err := insertSupplementalSemanticIds(ctx, txWriter{tx}, id, submodelElement.GetSupplementalSemanticIds()); err != nil {
+		return 0, err
+	}
+	if err := insertQualifiers(ctx, txWriter{tx}, id, submodelElement.GetQualifiers()); err != nil {
+		return 0, err
+	}
 	//println("Inserted SubmodelElement with idShort: " + submodelElement.GetIdShort())
 
 	return id, nil
 }
 
-func (p *PostgreSQLSMECrudHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string, submodelElement *gen.SubmodelElement) (int, error) {
+func (p *PostgreSQLSMECrudHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string, submodelElement *gen.SubmodelElement) (int, error) {
 	var id int
 	var idShort, modelType string
-	err := tx.QueryRow(`
+	err := tx.QueryRow(ctx, `
 		SELECT id, id_short, model_type
 		FROM submodel_element
 		WHERE submodel_id = $1 AND idshort_path = $2
@@ -128,18 +163,18 @@ func (p *PostgreSQLSMECrudHandler) Delete(idShortOrPath string) error {
 	return nil
 }
 
-func (p *PostgreSQLSMECrudHandler) GetDatabaseId(idShortPath string) (int, error) {
+func (p *PostgreSQLSMECrudHandler) GetDatabaseId(ctx context.Context, idShortPath string) (int, error) {
 	var id int
-	err := p.db.QueryRow(`SELECT id FROM submodel_element WHERE idshort_path = $1`, idShortPath).Scan(&id)
+	err := p.db.QueryRow(ctx, `SELECT id FROM submodel_element WHERE idshort_path = $1`, idShortPath).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-func (p *PostgreSQLSMECrudHandler) GetNextPosition(parentId int) (int, error) {
+func (p *PostgreSQLSMECrudHandler) GetNextPosition(ctx context.Context, parentId int) (int, error) {
 	var position sql.NullInt64
-	err := p.db.QueryRow(`SELECT MAX(position) FROM submodel_element WHERE parent_sme_id = $1`, parentId).Scan(&position)
+	err := p.db.QueryRow(ctx, `SELECT MAX(position) FROM submodel_element WHERE parent_sme_id = $1`, parentId).Scan(&position)
 	if err != nil {
 		return 0, err
 	}
@@ -149,9 +184,9 @@ func (p *PostgreSQLSMECrudHandler) GetNextPosition(parentId int) (int, error) {
 	return 0, nil // If no children exist, start at position 0
 }
 
-func (p *PostgreSQLSMECrudHandler) GetSubmodelElementType(idShortPath string) (string, error) {
+func (p *PostgreSQLSMECrudHandler) GetSubmodelElementType(ctx context.Context, idShortPath string) (string, error) {
 	var modelType string
-	err := p.db.QueryRow(`SELECT model_type FROM submodel_element WHERE idshort_path = $1`, idShortPath).Scan(&modelType)
+	err := p.db.QueryRow(ctx, `SELECT model_type FROM submodel_element WHERE idshort_path = $1`, idShortPath).Scan(&modelType)
 	if err != nil {
 		return "", err
 	}
@@ -1,15 +1,17 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
+
+	"github.com/jackc/pgx/v5"
 
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 )
 
 type PostgreSQLSMECrudInterface interface {
-	Create(*sql.Tx, string, gen.SubmodelElement) (int, error)
-	CreateNested(*sql.Tx, string, int, string, gen.SubmodelElement, int) (int, error)
-	Read(*sql.Tx, string, string) (gen.SubmodelElement, error)
+	Create(context.Context, pgx.Tx, string, gen.SubmodelElement) (int, error)
+	CreateNested(context.Context, pgx.Tx, string, int, string, gen.SubmodelElement, int) (int, error)
+	Read(context.Context, pgx.Tx, string, string) (gen.SubmodelElement, error)
 	Update(string, gen.SubmodelElement) error
 	Delete(string) error
 }
@@ -1,19 +1,21 @@
 package submodelelements
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLSubmodelElementCollectionHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLSubmodelElementCollectionHandler(db *sql.DB) (*PostgreSQLSubmodelElementCollectionHandler, error) {
+func NewPostgreSQLSubmodelElementCollectionHandler(db *pgxpool.Pool) (*PostgreSQLSubmodelElementCollectionHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +23,20 @@ func NewPostgreSQLSubmodelElementCollectionHandler(db *sql.DB) (*PostgreSQLSubmo
 	return &PostgreSQLSubmodelElementCollectionHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLSubmodelElementCollectionHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLSubmodelElementCollectionHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	_, ok := submodelElement.(*gen.SubmodelElementCollection)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type SubmodelElementCollection")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// SubmodelElementCollection-specific database insertion
-	_, err = tx.Exec(`INSERT INTO submodel_element_collection (id) VALUES ($1)`, id)
+	err = insertSubmodelElementCollection(ctx, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +44,20 @@ func (p PostgreSQLSubmodelElementCollectionHandler) Create(tx *sql.Tx, submodelI
 	return id, nil
 }
 
-func (p PostgreSQLSubmodelElementCollectionHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLSubmodelElementCollectionHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	_, ok := submodelElement.(*gen.SubmodelElementCollection)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type SubmodelElementCollection")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// SubmodelElementCollection-specific database insertion
-	_, err = tx.Exec(`INSERT INTO submodel_element_collection (id) VALUES ($1)`, id)
+	err = insertSubmodelElementCollection(ctx, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,17 +65,17 @@ func (p PostgreSQLSubmodelElementCollectionHandler) CreateNested(tx *sql.Tx, sub
 	return id, nil
 }
 
-func (p PostgreSQLSubmodelElementCollectionHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLSubmodelElementCollectionHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	return nil, nil
 	// var sme gen.SubmodelElement = &gen.SubmodelElementCollection{}
-	// id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	// id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	// if err != nil {
 	// 	return nil, err
 	// }
 
 	// // Check if there are Children and load them if necessary
 	// var idShortPath string
-	// rows, err := tx.Query(`
+	// rows, err := tx.Query(ctx, `
 	// 	SELECT idshort_path FROM submodel_element WHERE parent_sme_id = $1 ORDER BY position
 	// `, id)
 	// if err != nil {
@@ -109,3 +111,8 @@ func (p PostgreSQLSubmodelElementCollectionHandler) Delete(idShortOrPath string)
 	}
 	return nil
 }
+
+func insertSubmodelElementCollection(ctx context.Context, tx elementWriter, id int) error {
+	_, err := tx.Exec(ctx, `INSERT INTO submodel_element_collection (id) VALUES ($1)`, id)
+	return err
+}
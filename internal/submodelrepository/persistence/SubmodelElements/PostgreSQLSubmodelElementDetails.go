@@ -0,0 +1,242 @@
+package submodelelements
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	persistence_utils "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/utils"
+)
+
+// elementReference is a reference of an element that is stored in a table of its own; field
+// names the property of the element it is read into.
+type elementReference struct {
+	elementId   int64
+	field       string
+	referenceId int64
+}
+
+// elementReferencesQuery selects the references of the elements with the ids $1 that the
+// element rows do not carry, supplementalSemanticIds in the order they were stored.
+const elementReferencesQuery = `
+	SELECT sme_id, 'supplementalSemanticId', reference_id FROM sme_supplemental_semantic WHERE sme_id = ANY($1)
+	UNION ALL SELECT id, 'value', value_ref FROM reference_element WHERE id = ANY($1) AND value_ref IS NOT NULL
+	UNION ALL SELECT id, 'first', first_ref FROM relationship_element WHERE id = ANY($1) AND first_ref IS NOT NULL
+	UNION ALL SELECT id, 'second', second_ref FROM relationship_element WHERE id = ANY($1) AND second_ref IS NOT NULL
+	UNION ALL SELECT id, 'observed', observed_ref FROM basic_event_element WHERE id = ANY($1) AND observed_ref IS NOT NULL
+	UNION ALL SELECT id, 'messageBroker', message_broker_ref FROM basic_event_element WHERE id = ANY($1) AND message_broker_ref IS NOT NULL
+	UNION ALL SELECT id, 'semanticIdListElement', semantic_id_list_element FROM submodel_element_list WHERE id = ANY($1) AND semantic_id_list_element IS NOT NULL
+	ORDER BY 1, 3`
+
+// loadElementDetails fills in the parts of the elements in nodes that live in tables of their
+// own: displayName, description, supplementalSemanticIds and qualifiers of all elements, the
+// values of MultiLanguageProperties, the specificAssetIds of Entities and the references of
+// ReferenceElements, relationships, BasicEventElements and SubmodelElementLists. It needs two
+// round trips regardless of the number of elements.
+func loadElementDetails(ctx context.Context, tx pgx.Tx, nodes map[int64]*node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+
+	var references []elementReference
+	type qualifier struct {
+		elementId int64
+		qualifier gen.Qualifier
+		valueId   sql.NullInt64
+	}
+	var qualifiers []qualifier
+	type specificAssetId struct {
+		entityId      int64
+		id            gen.SpecificAssetId
+		externalRefId sql.NullInt64
+	}
+	var specificAssetIds []specificAssetId
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		SELECT e.id, l.language, l.text FROM submodel_element e
+		JOIN lang_string_name_type l ON l.lang_string_name_type_reference_id = e.displayname_id
+		WHERE e.id = ANY($1) ORDER BY e.id, l.language, l.id`, ids).Query(func(rows pgx.Rows) error {
+		var id int64
+		var s gen.LangStringNameType
+		_, err := pgx.ForEachRow(rows, []any{&id, &s.Language, &s.Text}, func() error {
+			el := nodes[id].element
+			el.SetDisplayName(append(el.GetDisplayName(), s))
+			return nil
+		})
+		return err
+	})
+	batch.Queue(`
+		SELECT e.id, l.language, l.text FROM submodel_element e
+		JOIN lang_string_text_type l ON l.lang_string_text_type_reference_id = e.description_id
+		WHERE e.id = ANY($1) ORDER BY e.id, l.language, l.id`, ids).Query(func(rows pgx.Rows) error {
+		var id int64
+		var s gen.LangStringTextType
+		_, err := pgx.ForEachRow(rows, []any{&id, &s.Language, &s.Text}, func() error {
+			el := nodes[id].element
+			el.SetDescription(append(el.GetDescription(), s))
+			return nil
+		})
+		return err
+	})
+	batch.Queue(`
+		SELECT mlp_id, language, text FROM multilanguage_property_value
+		WHERE mlp_id = ANY($1) ORDER BY mlp_id, id`, ids).Query(func(rows pgx.Rows) error {
+		var id int64
+		var s gen.LangStringTextType
+		_, err := pgx.ForEachRow(rows, []any{&id, &s.Language, &s.Text}, func() error {
+			if mlp, ok := nodes[id].element.(*gen.MultiLanguageProperty); ok {
+				mlp.Value = append(mlp.Value, s)
+			}
+			return nil
+		})
+		return err
+	})
+	batch.Queue(`
+		SELECT submodel_element_id, kind, type, value_type,
+		       COALESCE(value_text, value_num::text, value_bool::text, value_time::text, value_datetime::text), value_id
+		FROM qualifier WHERE submodel_element_id = ANY($1) ORDER BY submodel_element_id, id`, ids).Query(func(rows pgx.Rows) error {
+		var q qualifier
+		var kind, valueType string
+		var value sql.NullString
+		_, err := pgx.ForEachRow(rows, []any{&q.elementId, &kind, &q.qualifier.Type, &valueType, &value, &q.valueId}, func() error {
+			q.qualifier.Kind = gen.QualifierKind(kind)
+			q.qualifier.ValueType = gen.DataTypeDefXsd(valueType)
+			q.qualifier.Value = value.String
+			qualifiers = append(qualifiers, q)
+			return nil
+		})
+		return err
+	})
+	batch.Queue(`
+		SELECT entity_id, name, value, external_subject_ref FROM entity_specific_asset_id
+		WHERE entity_id = ANY($1) ORDER BY entity_id, id`, ids).Query(func(rows pgx.Rows) error {
+		var a specificAssetId
+		_, err := pgx.ForEachRow(rows, []any{&a.entityId, &a.id.Name, &a.id.Value, &a.externalRefId}, func() error {
+			specificAssetIds = append(specificAssetIds, a)
+			return nil
+		})
+		return err
+	})
+	batch.Queue(elementReferencesQuery, ids).Query(func(rows pgx.Rows) error {
+		var r elementReference
+		_, err := pgx.ForEachRow(rows, []any{&r.elementId, &r.field, &r.referenceId}, func() error {
+			references = append(references, r)
+			return nil
+		})
+		return err
+	})
+	if err := persistence_utils.ExecBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+
+	referenceIds := make([]int64, 0, len(references))
+	for _, r := range references {
+		referenceIds = append(referenceIds, r.referenceId)
+	}
+	for _, q := range qualifiers {
+		if q.valueId.Valid {
+			referenceIds = append(referenceIds, q.valueId.Int64)
+		}
+	}
+	for _, a := range specificAssetIds {
+		if a.externalRefId.Valid {
+			referenceIds = append(referenceIds, a.externalRefId.Int64)
+		}
+	}
+	refs, err := loadReferences(ctx, tx, referenceIds)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range references {
+		ref := refs[r.referenceId]
+		if ref == nil {
+			continue
+		}
+		switch el := nodes[r.elementId].element.(type) {
+		case *gen.ReferenceElement:
+			el.Value = ref
+		case *gen.RelationshipElement:
+			if r.field == "first" {
+				el.First = ref
+			} else if r.field == "second" {
+				el.Second = ref
+			}
+		case *gen.AnnotatedRelationshipElement:
+			if r.field == "first" {
+				el.First = ref
+			} else if r.field == "second" {
+				el.Second = ref
+			}
+		case *gen.BasicEventElement:
+			if r.field == "observed" {
+				el.Observed = ref
+			} else if r.field == "messageBroker" {
+				el.MessageBroker = ref
+			}
+		case *gen.SubmodelElementList:
+			if r.field == "semanticIdListElement" {
+				el.SemanticIdListElement = ref
+			}
+		}
+		if r.field == "supplementalSemanticId" {
+			el := nodes[r.elementId].element
+			el.SetSupplementalSemanticIds(append(el.GetSupplementalSemanticIds(), *ref))
+		}
+	}
+	for _, q := range qualifiers {
+		if q.valueId.Valid {
+			q.qualifier.ValueId = refs[q.valueId.Int64]
+		}
+		el := nodes[q.elementId].element
+		el.SetQualifiers(append(el.GetQualifiers(), q.qualifier))
+	}
+	for _, a := range specificAssetIds {
+		entity, ok := nodes[a.entityId].element.(*gen.Entity)
+		if !ok {
+			continue
+		}
+		if a.externalRefId.Valid {
+			a.id.ExternalSubjectId = refs[a.externalRefId.Int64]
+		}
+		entity.SpecificAssetIds = append(entity.SpecificAssetIds, a.id)
+	}
+	return nil
+}
+
+// loadReferences reads the references with ids, their keys in order.
+func loadReferences(ctx context.Context, tx pgx.Tx, ids []int64) (map[int64]*gen.Reference, error) {
+	refs := make(map[int64]*gen.Reference, len(ids))
+	if len(ids) == 0 {
+		return refs, nil
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.type, k.type, k.value FROM reference r
+		LEFT JOIN reference_key k ON k.reference_id = r.id
+		WHERE r.id = ANY($1) ORDER BY r.id, k.position`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var id int64
+	var refType string
+	var keyType, keyValue sql.NullString
+	_, err = pgx.ForEachRow(rows, []any{&id, &refType, &keyType, &keyValue}, func() error {
+		ref := refs[id]
+		if ref == nil {
+			ref = &gen.Reference{Type: gen.ReferenceTypes(refType)}
+			refs[id] = ref
+		}
+		if keyType.Valid {
+			ref.Keys = append(ref.Keys, gen.Key{Type: gen.KeyTypes(keyType.String), Value: keyValue.String})
+		}
+		return nil
+	})
+	return refs, err
+}
@@ -1,19 +1,22 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
 type PostgreSQLSubmodelElementListHandler struct {
-	db        *sql.DB
+	db        *pgxpool.Pool
 	decorated *PostgreSQLSMECrudHandler
 }
 
-func NewPostgreSQLSubmodelElementListHandler(db *sql.DB) (*PostgreSQLSubmodelElementListHandler, error) {
+func NewPostgreSQLSubmodelElementListHandler(db *pgxpool.Pool) (*PostgreSQLSubmodelElementListHandler, error) {
 	decoratedHandler, err := NewPostgreSQLSMECrudHandler(db)
 	if err != nil {
 		return nil, err
@@ -21,20 +24,20 @@ func NewPostgreSQLSubmodelElementListHandler(db *sql.DB) (*PostgreSQLSubmodelEle
 	return &PostgreSQLSubmodelElementListHandler{db: db, decorated: decoratedHandler}, nil
 }
 
-func (p PostgreSQLSubmodelElementListHandler) Create(tx *sql.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
+func (p PostgreSQLSubmodelElementListHandler) Create(ctx context.Context, tx pgx.Tx, submodelId string, submodelElement gen.SubmodelElement) (int, error) {
 	smeList, ok := submodelElement.(*gen.SubmodelElementList)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type SubmodelElementList")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.Create(tx, submodelId, submodelElement)
+	id, err := p.decorated.Create(ctx, tx, submodelId, submodelElement)
 	if err != nil {
 		return 0, err
 	}
 
 	// SubmodelElementList-specific database insertion
-	err = insertSubmodelElementList(smeList, tx, id)
+	err = insertSubmodelElementList(ctx, smeList, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -42,20 +45,20 @@ func (p PostgreSQLSubmodelElementListHandler) Create(tx *sql.Tx, submodelId stri
 	return id, nil
 }
 
-func (p PostgreSQLSubmodelElementListHandler) CreateNested(tx *sql.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
+func (p PostgreSQLSubmodelElementListHandler) CreateNested(ctx context.Context, tx pgx.Tx, submodelId string, parentId int, idShortPath string, submodelElement gen.SubmodelElement, pos int) (int, error) {
 	smeList, ok := submodelElement.(*gen.SubmodelElementList)
 	if !ok {
 		return 0, errors.New("submodelElement is not of type SubmodelElementList")
 	}
 
 	// First, perform base SubmodelElement operations within the transaction
-	id, err := p.decorated.CreateAndPath(tx, submodelId, parentId, idShortPath, submodelElement, pos)
+	id, err := p.decorated.CreateAndPath(ctx, tx, submodelId, parentId, idShortPath, submodelElement, pos)
 	if err != nil {
 		return 0, err
 	}
 
 	// SubmodelElementList-specific database insertion
-	err = insertSubmodelElementList(smeList, tx, id)
+	err = insertSubmodelElementList(ctx, smeList, txWriter{tx}, id)
 	if err != nil {
 		return 0, err
 	}
@@ -63,17 +66,17 @@ func (p PostgreSQLSubmodelElementListHandler) CreateNested(tx *sql.Tx, submodelI
 	return id, nil
 }
 
-func (p PostgreSQLSubmodelElementListHandler) Read(tx *sql.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
+func (p PostgreSQLSubmodelElementListHandler) Read(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) (gen.SubmodelElement, error) {
 	return nil, nil
 	// var sme gen.SubmodelElement = &gen.SubmodelElementList{}
-	// id, err := p.decorated.Read(tx, submodelId, idShortOrPath, &sme)
+	// id, err := p.decorated.Read(ctx, tx, submodelId, idShortOrPath, &sme)
 	// if err != nil {
 	// 	return nil, err
 	// }
 
 	// // Check if there are Children and load them if necessary
 	// var idShortPath string
-	// rows, err := tx.Query(`
+	// rows, err := tx.Query(ctx, `
 	// 	SELECT idshort_path FROM submodel_element WHERE parent_sme_id = $1 ORDER BY position
 	// `, id)
 	// if err != nil {
@@ -110,10 +113,10 @@ func (p PostgreSQLSubmodelElementListHandler) Delete(idShortOrPath string) error
 	return nil
 }
 
-func insertSubmodelElementList(smeList *gen.SubmodelElementList, tx *sql.Tx, id int) error {
+func insertSubmodelElementList(ctx context.Context, smeList *gen.SubmodelElementList, tx elementWriter, id int) error {
 	var semanticId sql.NullInt64
 	if smeList.SemanticIdListElement != nil && !isEmptyReference(smeList.SemanticIdListElement) {
-		refId, err := insertReference(tx, *smeList.SemanticIdListElement)
+		refId, err := tx.InsertReference(ctx, *smeList.SemanticIdListElement)
 		if err != nil {
 			return err
 		}
@@ -128,7 +131,7 @@ func insertSubmodelElementList(smeList *gen.SubmodelElementList, tx *sql.Tx, id
 		valueType = sql.NullString{String: string(smeList.ValueTypeListElement), Valid: true}
 	}
 
-	_, err := tx.Exec(`INSERT INTO submodel_element_list (id, order_relevant, semantic_id_list_element, type_value_list_element, value_type_list_element)
+	_, err := tx.Exec(ctx, `INSERT INTO submodel_element_list (id, order_relevant, semantic_id_list_element, type_value_list_element, value_type_list_element)
 					 VALUES ($1, $2, $3, $4, $5)`,
 		id, smeList.OrderRelevant, semanticId, typeValue, valueType)
 	return err
@@ -1,15 +1,20 @@
 package submodelelements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
@@ -46,6 +51,7 @@ type node struct {
 	path     string              // Full path for navigation
 	position sql.NullInt32       // Position within parent for ordering
 	element  gen.SubmodelElement // The actual submodel element data
+	role     string              // Role of an Operation variable, empty otherwise
 }
 
 // ================================================================================
@@ -57,126 +63,126 @@ type node struct {
 
 // GetSMEHandler creates the appropriate CRUD handler for a submodel element
 // Uses the Factory Pattern for clean, testable handler instantiation
-func GetSMEHandler(submodelElement gen.SubmodelElement, db *sql.DB) (PostgreSQLSMECrudInterface, error) {
+func GetSMEHandler(submodelElement gen.SubmodelElement, db *pgxpool.Pool) (PostgreSQLSMECrudInterface, error) {
 	return GetSMEHandlerByModelType(string(submodelElement.GetModelType()), db)
 }
 
 // GetSMEHandlerByModelType creates a handler by model type string
 // Single Responsibility: Only handles the logic for determining and creating handlers
-func GetSMEHandlerByModelType(modelType string, db *sql.DB) (PostgreSQLSMECrudInterface, error) {
+func GetSMEHandlerByModelType(modelType string, db *pgxpool.Pool) (PostgreSQLSMECrudInterface, error) {
 	var handler PostgreSQLSMECrudInterface
 
 	switch modelType {
 	case "AnnotatedRelationshipElement":
 		areHandler, err := NewPostgreSQLAnnotatedRelationshipElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating AnnotatedRelationshipElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create AnnotatedRelationshipElement handler. See console for details.")
+			slog.Error("Error creating AnnotatedRelationshipElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create AnnotatedRelationshipElement handler.")
 		}
 		handler = areHandler
 	case "BasicEventElement":
 		beeHandler, err := NewPostgreSQLBasicEventElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating BasicEventElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create BasicEventElement handler. See console for details.")
+			slog.Error("Error creating BasicEventElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create BasicEventElement handler.")
 		}
 		handler = beeHandler
 	case "Blob":
 		blobHandler, err := NewPostgreSQLBlobHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Blob handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Blob handler. See console for details.")
+			slog.Error("Error creating Blob handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Blob handler.")
 		}
 		handler = blobHandler
 	case "Capability":
 		capHandler, err := NewPostgreSQLCapabilityHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Capability handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Capability handler. See console for details.")
+			slog.Error("Error creating Capability handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Capability handler.")
 		}
 		handler = capHandler
 	case "DataElement":
 		deHandler, err := NewPostgreSQLDataElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating DataElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create DataElement handler. See console for details.")
+			slog.Error("Error creating DataElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create DataElement handler.")
 		}
 		handler = deHandler
 	case "Entity":
 		entityHandler, err := NewPostgreSQLEntityHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Entity handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Entity handler. See console for details.")
+			slog.Error("Error creating Entity handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Entity handler.")
 		}
 		handler = entityHandler
 	case "EventElement":
 		eventElemHandler, err := NewPostgreSQLEventElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating EventElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create EventElement handler. See console for details.")
+			slog.Error("Error creating EventElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create EventElement handler.")
 		}
 		handler = eventElemHandler
 	case "File":
 		fileHandler, err := NewPostgreSQLFileHandler(db)
 		if err != nil {
-			fmt.Println("Error creating File handler:", err)
-			return nil, common.NewInternalServerError("Failed to create File handler. See console for details.")
+			slog.Error("Error creating File handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create File handler.")
 		}
 		handler = fileHandler
 	case "MultiLanguageProperty":
 		mlpHandler, err := NewPostgreSQLMultiLanguagePropertyHandler(db)
 		if err != nil {
-			fmt.Println("Error creating MultiLanguageProperty handler:", err)
-			return nil, common.NewInternalServerError("Failed to create MultiLanguageProperty handler. See console for details.")
+			slog.Error("Error creating MultiLanguageProperty handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create MultiLanguageProperty handler.")
 		}
 		handler = mlpHandler
 	case "Operation":
 		opHandler, err := NewPostgreSQLOperationHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Operation handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Operation handler. See console for details.")
+			slog.Error("Error creating Operation handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Operation handler.")
 		}
 		handler = opHandler
 	case "Property":
 		propHandler, err := NewPostgreSQLPropertyHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Property handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Property handler. See console for details.")
+			slog.Error("Error creating Property handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Property handler.")
 		}
 		handler = propHandler
 	case "Range":
 		rangeHandler, err := NewPostgreSQLRangeHandler(db)
 		if err != nil {
-			fmt.Println("Error creating Range handler:", err)
-			return nil, common.NewInternalServerError("Failed to create Range handler. See console for details.")
+			slog.Error("Error creating Range handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create Range handler.")
 		}
 		handler = rangeHandler
 	case "ReferenceElement":
 		refElemHandler, err := NewPostgreSQLReferenceElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating ReferenceElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create ReferenceElement handler. See console for details.")
+			slog.Error("Error creating ReferenceElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create ReferenceElement handler.")
 		}
 		handler = refElemHandler
 	case "RelationshipElement":
 		relElemHandler, err := NewPostgreSQLRelationshipElementHandler(db)
 		if err != nil {
-			fmt.Println("Error creating RelationshipElement handler:", err)
-			return nil, common.NewInternalServerError("Failed to create RelationshipElement handler. See console for details.")
+			slog.Error("Error creating RelationshipElement handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create RelationshipElement handler.")
 		}
 		handler = relElemHandler
 	case "SubmodelElementCollection":
 		smeColHandler, err := NewPostgreSQLSubmodelElementCollectionHandler(db)
 		if err != nil {
-			fmt.Println("Error creating SubmodelElementCollection handler:", err)
-			return nil, common.NewInternalServerError("Failed to create SubmodelElementCollection handler. See console for details.")
+			slog.Error("Error creating SubmodelElementCollection handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create SubmodelElementCollection handler.")
 		}
 		handler = smeColHandler
 	case "SubmodelElementList":
 		smeListHandler, err := NewPostgreSQLSubmodelElementListHandler(db)
 		if err != nil {
-			fmt.Println("Error creating SubmodelElementList handler:", err)
-			return nil, common.NewInternalServerError("Failed to create SubmodelElementList handler. See console for details.")
+			slog.Error("Error creating SubmodelElementList handler", "error", err)
+			return nil, common.NewInternalServerError("Failed to create SubmodelElementList handler.")
 		}
 		handler = smeListHandler
 	default:
@@ -197,20 +203,21 @@ func GetSMEHandlerByModelType(modelType string, db *sql.DB) (PostgreSQLSMECrudIn
 
 // GetSubmodelElementsWithPath retrieves submodel elements by path with pagination support
 // Clean API: Clear parameters, proper validation, and meaningful error messages
-func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idShortOrPath string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
+func GetSubmodelElementsWithPath(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string, idShortOrPath string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
 	if limit < 1 {
 		limit = 100
 	}
 	//Check if Submodel exists
 	qExist, argsExist := qb.NewSelect("id").From("submodel").Where("id = $1", submodelId).Build()
-	sRows, err := tx.Query(qExist, argsExist...)
+	sRows, err := tx.Query(ctx, qExist, argsExist...)
 	if err != nil {
 		return nil, "", err
 	}
-	if !sRows.Next() {
+	exists := sRows.Next()
+	sRows.Close()
+	if !exists {
 		return nil, "", common.NewErrNotFound("Submodel not found")
 	}
-	sRows.Close()
 
 	// Get OFFSET based on Cursor
 	offset := 0
@@ -221,7 +228,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 		).From("submodel_element").
 			Where("submodel_id = $1 AND parent_sme_id IS NULL", submodelId).
 			Build()
-		cRows, err := tx.Query(qCursor, argsCursor...)
+		cRows, err := tx.Query(ctx, qCursor, argsCursor...)
 		if err != nil {
 			return nil, "", err
 		}
@@ -292,7 +299,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 		` + getSubmodelElementLeftJoins() + `
         ORDER BY sme.parent_sme_id NULLS FIRST, sme.idshort_path, sme.position`
 
-	rows, err := tx.Query(baseQuery, args...)
+	rows, err := tx.Query(ctx, baseQuery, args...)
 
 	if err != nil {
 		return nil, "", err
@@ -343,6 +350,8 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 			beeLastUpdate       sql.NullTime
 			beeMinInterval      sql.NullString
 			beeMaxInterval      sql.NullString
+			// Operation variable
+			opVarRole sql.NullString
 		)
 
 		if err := rows.Scan(
@@ -357,6 +366,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 			&relFirstRef, &relSecondRef,
 			&entityType, &entityGlobalAssetId,
 			&beeObservedRef, &beeDirection, &beeState, &beeMessageTopic, &beeMessageBrokerRef, &beeLastUpdate, &beeMinInterval, &beeMaxInterval,
+			&opVarRole,
 		); err != nil {
 			return nil, "", err
 		}
@@ -364,7 +374,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 		// Materialize the concrete element based on modelType (no reflection)
 		var semanticIdObj *gen.Reference
 		if semanticId.Valid {
-			semanticIdObj, err = persistence_utils.GetSemanticId(db, semanticId)
+			semanticIdObj, err = persistence_utils.GetSemanticId(ctx, db, semanticId)
 			if err != nil {
 				return nil, "", err
 			}
@@ -425,11 +435,11 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 			el = rg
 
 		case "SubmodelElementCollection":
-			coll := &gen.SubmodelElementCollection{IdShort: idShort, Category: category, ModelType: modelType, Value: make([]gen.SubmodelElement, 0, 4)}
+			coll := &gen.SubmodelElementCollection{IdShort: idShort, Category: category, ModelType: modelType, SemanticId: semanticIdObj, Value: make([]gen.SubmodelElement, 0, 4)}
 			el = coll
 
 		case "SubmodelElementList":
-			lst := &gen.SubmodelElementList{IdShort: idShort, Category: category, ModelType: modelType, Value: make([]gen.SubmodelElement, 0, 4)}
+			lst := &gen.SubmodelElementList{IdShort: idShort, Category: category, ModelType: modelType, SemanticId: semanticIdObj, Value: make([]gen.SubmodelElement, 0, 4)}
 			if typeValueListElement.Valid {
 				if tv, err := gen.NewAasSubmodelElementsFromValue(typeValueListElement.String); err == nil {
 					lst.TypeValueListElement = &tv
@@ -489,7 +499,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 				bee.MessageTopic = beeMessageTopic.String
 			}
 			if beeLastUpdate.Valid {
-				bee.LastUpdate = beeLastUpdate.Time.Format(time.RFC3339)
+				bee.LastUpdate = beeLastUpdate.Time.UTC().Format(time.RFC3339)
 			}
 			// Intervals not set for now
 			el = bee
@@ -509,6 +519,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 			path:     idShortPath,
 			position: position,
 			element:  el,
+			role:     opVarRole.String,
 		}
 		nodes[id] = n
 
@@ -528,8 +539,11 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 		return nil, "", err
 	}
 
+	if err := loadElementDetails(ctx, tx, nodes); err != nil {
+		return nil, "", err
+	}
+
 	// --- Attach children (O(n)) ----------------------------------------------
-	// We only attach to SMC/SML parents; other types cannot have children.
 	attachChildrenToSubmodelElements(nodes, children)
 
 	// --- Build result ---------------------------------------------------------
@@ -563,7 +577,7 @@ func GetSubmodelElementsWithPath(db *sql.DB, tx *sql.Tx, submodelId string, idSh
 
 // GetSubmodelWithSubmodelElements retrieves a submodel with all its elements (standard path)
 // Maintains backward compatibility while supporting new displayName/description features
-func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string) (*gen.Submodel, error) {
+func GetSubmodelWithSubmodelElements(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string) (*gen.Submodel, error) {
 	// --- Build the unified query with CTE ----------------------------------------------------------
 	var cte string
 	args := []any{submodelId}
@@ -582,7 +596,7 @@ func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string)
 		` + getSubmodelElementLeftJoins() + `
         WHERE s.id = $1
         ORDER BY sme.parent_sme_id NULLS FIRST, sme.idshort_path, sme.position`
-	rows, err := tx.Query(baseQuery, args...)
+	rows, err := tx.Query(ctx, baseQuery, args...)
 	// Print execution duration
 
 	if err != nil {
@@ -593,7 +607,7 @@ func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string)
 	// --- Working structs ------------------------------------------------------
 
 	// Pre-size conservatively to reduce re-allocations
-	nodes, children, roots, dbSmId, dbSubmodelIdShort, dbSubmodelCategory, dbSubmodelKind, dbSubmodelSemanticId, result, err := loadSubmodelSubmodelElementsIntoMemory(rows, err, db)
+	nodes, children, roots, dbSmId, dbSubmodelIdShort, dbSubmodelCategory, dbSubmodelKind, dbSubmodelSemanticId, result, err := loadSubmodelSubmodelElementsIntoMemory(ctx, rows, err, db)
 	if err != nil {
 		return result, err
 	}
@@ -601,8 +615,11 @@ func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string)
 		return nil, err
 	}
 
+	if err := loadElementDetails(ctx, tx, nodes); err != nil {
+		return nil, err
+	}
+
 	// --- Attach children (O(n)) ----------------------------------------------
-	// We only attach to SMC/SML parents; other types cannot have children.
 	attachChildrenToSubmodelElements(nodes, children)
 
 	// --- Build result ---------------------------------------------------------
@@ -634,19 +651,22 @@ func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string)
 		From("submodel").
 		Where("id = $1", dbSmId).
 		Build()
-	err = tx.QueryRow(qMeta, argsMeta...).Scan(&submodelDisplayNameId, &submodelDescriptionId)
+	err = tx.QueryRow(ctx, qMeta, argsMeta...).Scan(&submodelDisplayNameId, &submodelDescriptionId)
 	if err == nil {
 		if submodelDisplayNameId.Valid {
-			submodel.DisplayName = loadLangStringNameType(db, tx, submodelDisplayNameId.Int64)
+			submodel.DisplayName = loadLangStringNameType(ctx, db, tx, submodelDisplayNameId.Int64)
 		}
 		if submodelDescriptionId.Valid {
-			submodel.Description = loadLangStringTextType(db, tx, submodelDescriptionId.Int64)
+			submodel.Description = loadLangStringTextType(ctx, db, tx, submodelDescriptionId.Int64)
 		}
 	}
 
 	// Load SemanticID for submodel if present
 	if dbSubmodelSemanticId.Valid {
-		submodel.SemanticId = loadSemanticReference(db, tx, dbSubmodelSemanticId.Int64)
+		submodel.SemanticId, err = persistence_utils.GetSemanticId(ctx, db, dbSubmodelSemanticId)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// return idShort of last element in res as next cursor
@@ -657,7 +677,7 @@ func GetSubmodelWithSubmodelElements(db *sql.DB, tx *sql.Tx, submodelId string)
 // - a slice with a single Submodel when submodelId is non-empty
 // - all Submodels (each with its SubmodelElements) when submodelId is empty
 // Backward compatible: the original GetSubmodelWithSubmodelElements remains unchanged.
-func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submodel, error) {
+func GetSubmodelWithSubmodelElementsOrAll(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx) ([]gen.Submodel, error) {
 	// Single-query path: fetch all submodels and their elements in one go (no WHERE on submodel)
 	baseQuery := `
 		SELECT 
@@ -673,12 +693,12 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 		` + getSubmodelElementLeftJoins() + `
 		ORDER BY s.id, sme.parent_sme_id NULLS FIRST, sme.idshort_path, sme.position`
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 	if tx != nil {
-		rows, err = tx.Query(baseQuery)
+		rows, err = tx.Query(ctx, baseQuery)
 	} else {
-		rows, err = db.Query(baseQuery)
+		rows, err = db.Query(ctx, baseQuery)
 	}
 	if err != nil {
 		return nil, err
@@ -745,6 +765,8 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 			beeLastUpdate       sql.NullTime
 			beeMinInterval      sql.NullString
 			beeMaxInterval      sql.NullString
+			// Operation variable
+			opVarRole sql.NullString
 		)
 
 		if err := rows.Scan(
@@ -760,6 +782,7 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 			&relFirstRef, &relSecondRef,
 			&entityType, &entityGlobalAssetId,
 			&beeObservedRef, &beeDirection, &beeState, &beeMessageTopic, &beeMessageBrokerRef, &beeLastUpdate, &beeMinInterval, &beeMaxInterval,
+			&opVarRole,
 		); err != nil {
 			return nil, err
 		}
@@ -902,7 +925,7 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 				bee.MessageTopic = beeMessageTopic.String
 			}
 			if beeLastUpdate.Valid {
-				bee.LastUpdate = beeLastUpdate.Time.Format(time.RFC3339)
+				bee.LastUpdate = beeLastUpdate.Time.UTC().Format(time.RFC3339)
 			}
 			el = bee
 		case "Capability":
@@ -918,6 +941,7 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 			path:     idShortPath.String,
 			position: position,
 			element:  el,
+			role:     opVarRole.String,
 		}
 		g.nodes[n.id] = n
 		if parentSmeID.Valid {
@@ -971,7 +995,9 @@ func GetSubmodelWithSubmodelElementsOrAll(db *sql.DB, tx *sql.Tx) ([]gen.Submode
 // structures from flat database results and attaching parent-child relationships.
 
 // attachChildrenToSubmodelElements builds the hierarchical tree structure from flat database results
-// Implements efficient O(n) tree building algorithm with stable sorting
+// Implements efficient O(n) tree building algorithm with stable sorting. Children are the values
+// of collections and lists, the statements of entities, the annotations of annotated
+// relationships and the variables of operations.
 func attachChildrenToSubmodelElements(nodes map[int64]*node, children map[int64][]*node) {
 	for id, parent := range nodes {
 		kids := children[id]
@@ -1006,11 +1032,31 @@ func attachChildrenToSubmodelElements(nodes map[int64]*node, children map[int64]
 			for _, ch := range kids {
 				p.Value = append(p.Value, ch.element)
 			}
+		case *gen.Entity:
+			for _, ch := range kids {
+				p.Statements = append(p.Statements, ch.element)
+			}
+		case *gen.AnnotatedRelationshipElement:
+			for _, ch := range kids {
+				p.Annotations = append(p.Annotations, ch.element)
+			}
+		case *gen.Operation:
+			for _, ch := range kids {
+				v := gen.OperationVariable{Value: ch.element}
+				switch ch.role {
+				case "in":
+					p.InputVariables = append(p.InputVariables, v)
+				case "out":
+					p.OutputVariables = append(p.OutputVariables, v)
+				case "inout":
+					p.InoutputVariables = append(p.InoutputVariables, v)
+				}
+			}
 		}
 	}
 }
 
-func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.DB) (map[int64]*node, map[int64][]*node, []*node, string, string, string, string, sql.NullInt64, *gen.Submodel, error) {
+func loadSubmodelSubmodelElementsIntoMemory(ctx context.Context, rows pgx.Rows, err error, db *pgxpool.Pool) (map[int64]*node, map[int64][]*node, []*node, string, string, string, string, sql.NullInt64, *gen.Submodel, error) {
 	nodes := make(map[int64]*node, 256)
 	children := make(map[int64][]*node, 256)
 	roots := make([]*node, 0, 16)
@@ -1059,6 +1105,8 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 			beeLastUpdate       sql.NullTime
 			beeMinInterval      sql.NullString
 			beeMaxInterval      sql.NullString
+			// Operation variable
+			opVarRole sql.NullString
 		)
 
 		if err := rows.Scan(
@@ -1074,14 +1122,10 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 			&relFirstRef, &relSecondRef,
 			&entityType, &entityGlobalAssetId,
 			&beeObservedRef, &beeDirection, &beeState, &beeMessageTopic, &beeMessageBrokerRef, &beeLastUpdate, &beeMinInterval, &beeMaxInterval,
+			&opVarRole,
 		); err != nil {
 			return nil, nil, nil, "", "", "", "", sql.NullInt64{}, nil, err
 		}
-		if !id.Valid {
-			// This row only represents the submodel itself (no SME)
-			// Skip adding to nodes
-			continue
-		}
 		if dbSmId == "" {
 			dbSmId = submodelID
 			dbSubmodelIdShort = submodelIdShort
@@ -1089,11 +1133,16 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 			dbSubmodelKind = submodelKind
 			dbSubmodelSemanticId = submodelSemanticId
 		}
+		if !id.Valid {
+			// This row only represents the submodel itself (no SME)
+			// Skip adding to nodes
+			continue
+		}
 
 		// Materialize the concrete element based on modelType (no reflection)
 		var semanticIdObj *gen.Reference
 		if semanticId.Valid {
-			semanticIdObj, err = persistence_utils.GetSemanticId(db, semanticId)
+			semanticIdObj, err = persistence_utils.GetSemanticId(ctx, db, semanticId)
 			if err != nil {
 				return nil, nil, nil, "", "", "", "", sql.NullInt64{}, nil, err
 			}
@@ -1154,11 +1203,11 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 			el = rg
 
 		case "SubmodelElementCollection":
-			coll := &gen.SubmodelElementCollection{IdShort: idShort, Category: category, ModelType: modelType, Value: make([]gen.SubmodelElement, 0, 4)}
+			coll := &gen.SubmodelElementCollection{IdShort: idShort, Category: category, ModelType: modelType, SemanticId: semanticIdObj, Value: make([]gen.SubmodelElement, 0, 4)}
 			el = coll
 
 		case "SubmodelElementList":
-			lst := &gen.SubmodelElementList{IdShort: idShort, Category: category, ModelType: modelType, Value: make([]gen.SubmodelElement, 0, 4)}
+			lst := &gen.SubmodelElementList{IdShort: idShort, Category: category, ModelType: modelType, SemanticId: semanticIdObj, Value: make([]gen.SubmodelElement, 0, 4)}
 			if typeValueListElement.Valid {
 				if tv, err := gen.NewAasSubmodelElementsFromValue(typeValueListElement.String); err == nil {
 					lst.TypeValueListElement = &tv
@@ -1218,7 +1267,7 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 				bee.MessageTopic = beeMessageTopic.String
 			}
 			if beeLastUpdate.Valid {
-				bee.LastUpdate = beeLastUpdate.Time.Format(time.RFC3339)
+				bee.LastUpdate = beeLastUpdate.Time.UTC().Format(time.RFC3339)
 			}
 			// Intervals not set for now
 			el = bee
@@ -1238,6 +1287,7 @@ func loadSubmodelSubmodelElementsIntoMemory(rows *sql.Rows, err error, db *sql.D
 			path:     idShortPath,
 			position: position,
 			element:  el,
+			role:     opVarRole.String,
 		}
 		nodes[id.Int64] = n
 
@@ -1272,6 +1322,7 @@ func getSubmodelElementLeftJoins() string {
         LEFT JOIN relationship_element rel_elem ON sme.id = rel_elem.id
         LEFT JOIN entity_element entity ON sme.id = entity.id
         LEFT JOIN basic_event_element bee ON sme.id = bee.id
+        LEFT JOIN operation_variable op_var ON sme.id = op_var.value_sme
 	`
 }
 
@@ -1299,19 +1350,21 @@ func getSubmodelElementDataQueryPart() string {
             -- Entity data
             entity.entity_type as entity_type, entity.global_asset_id as entity_global_asset_id,
             -- BasicEventElement data
-            bee.observed_ref as bee_observed_ref, bee.direction as bee_direction, bee.state as bee_state, bee.message_topic as bee_message_topic, bee.message_broker_ref as bee_message_broker_ref, bee.last_update as bee_last_update, bee.min_interval as bee_min_interval, bee.max_interval as bee_max_interval
+            bee.observed_ref as bee_observed_ref, bee.direction as bee_direction, bee.state as bee_state, bee.message_topic as bee_message_topic, bee.message_broker_ref as bee_message_broker_ref, bee.last_update as bee_last_update, bee.min_interval as bee_min_interval, bee.max_interval as bee_max_interval,
+            -- Operation variable role
+            op_var.role as op_var_role
 	`
 }
 
 // This method removes a SubmodelElement by its idShort or path and all its nested elements
 // If the deleted Element is in a SubmodelElementList, the indices of the remaining elements are adjusted accordingly
-func DeleteSubmodelElementByPath(tx *sql.Tx, submodelId string, idShortOrPath string) error {
+func DeleteSubmodelElementByPath(ctx context.Context, tx pgx.Tx, submodelId string, idShortOrPath string) error {
 	query := `DELETE FROM submodel_element WHERE submodel_id = $1 AND (idshort_path = $2 OR idshort_path LIKE $2 || '.%' OR idshort_path LIKE $2 || '[%')`
-	result, err := tx.Exec(query, submodelId, idShortOrPath)
+	result, err := tx.Exec(ctx, query, submodelId, idShortOrPath)
 	if err != nil {
 		return err
 	}
-	affectedRows, err := result.RowsAffected()
+	affectedRows := result.RowsAffected()
 	//if idShortPath ends with ] it is part of a SubmodelElementList and we need to update the indices of the remaining elements
 	if idShortOrPath[len(idShortOrPath)-1] == ']' {
 		//extract the parent path and the index of the deleted element
@@ -1332,20 +1385,20 @@ func DeleteSubmodelElementByPath(tx *sql.Tx, submodelId string, idShortOrPath st
 
 		//get the id of the parent SubmodelElementList
 		var parentId int
-		err = tx.QueryRow(`SELECT id FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2`, submodelId, parentPath).Scan(&parentId)
+		err = tx.QueryRow(ctx, `SELECT id FROM submodel_element WHERE submodel_id = $1 AND idshort_path = $2`, submodelId, parentPath).Scan(&parentId)
 		if err != nil {
 			return err
 		}
 
 		//update the indices of the remaining elements in the SubmodelElementList
 		updateQuery := `UPDATE submodel_element SET position = position - 1 WHERE parent_sme_id = $1 AND position > $2`
-		_, err = tx.Exec(updateQuery, parentId, deletedIndex)
+		_, err = tx.Exec(ctx, updateQuery, parentId, deletedIndex)
 		if err != nil {
 			return err
 		}
 		// update their idshort_path as well
 		updatePathQuery := `UPDATE submodel_element SET idshort_path = regexp_replace(idshort_path, '\[' || (position + 1) || '\]', '[' || position || ']') WHERE parent_sme_id = $1 AND position >= $2`
-		_, err = tx.Exec(updatePathQuery, parentId, deletedIndex)
+		_, err = tx.Exec(ctx, updatePathQuery, parentId, deletedIndex)
 		if err != nil {
 			return err
 		}
@@ -1374,24 +1427,24 @@ func DeleteSubmodelElementByPath(tx *sql.Tx, submodelId string, idShortOrPath st
 
 // loadLangStringNameType loads display name language strings for a reference ID
 // Used for loading displayName fields with proper internationalization support
-func loadLangStringNameType(db *sql.DB, tx *sql.Tx, refId int64) []gen.LangStringNameType {
+func loadLangStringNameType(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, refId int64) []gen.LangStringNameType {
 	if refId == 0 {
 		return nil
 	}
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 
 	q, args := qb.NewSelect("language", "text").
 		From("lang_string_name_type").
 		Where("lang_string_name_type_reference_id = $1", refId).
-		OrderBy("language").
+		OrderBy("language, id").
 		Build()
 
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1415,24 +1468,24 @@ func loadLangStringNameType(db *sql.DB, tx *sql.Tx, refId int64) []gen.LangStrin
 }
 
 // Load LangStringTextType (description) with caching
-func loadLangStringTextType(db *sql.DB, tx *sql.Tx, refId int64) []gen.LangStringTextType {
+func loadLangStringTextType(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, refId int64) []gen.LangStringTextType {
 	if refId == 0 {
 		return nil
 	}
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 
 	q, args := qb.NewSelect("language", "text").
 		From("lang_string_text_type").
 		Where("lang_string_text_type_reference_id = $1", refId).
-		OrderBy("language").
+		OrderBy("language, id").
 		Build()
 
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1456,7 +1509,7 @@ func loadLangStringTextType(db *sql.DB, tx *sql.Tx, refId int64) []gen.LangStrin
 }
 
 // Batch load LangStringNameType for multiple references (optimization for bulk operations)
-func batchLoadLangStringNameType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int64][]gen.LangStringNameType {
+func batchLoadLangStringNameType(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, refIds []int64) map[int64][]gen.LangStringNameType {
 	if len(refIds) == 0 {
 		return nil
 	}
@@ -1473,12 +1526,12 @@ func batchLoadLangStringNameType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int
 		OrderBy("lang_string_name_type_reference_id, language").
 		Build()
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1503,7 +1556,7 @@ func batchLoadLangStringNameType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int
 }
 
 // Batch load LangStringTextType for multiple references (optimization for bulk operations)
-func batchLoadLangStringTextType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int64][]gen.LangStringTextType {
+func batchLoadLangStringTextType(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, refIds []int64) map[int64][]gen.LangStringTextType {
 	if len(refIds) == 0 {
 		return nil
 	}
@@ -1520,12 +1573,12 @@ func batchLoadLangStringTextType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int
 		OrderBy("lang_string_text_type_reference_id, language").
 		Build()
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1550,8 +1603,8 @@ func batchLoadLangStringTextType(db *sql.DB, tx *sql.Tx, refIds []int64) map[int
 }
 
 // Helper function to load semantic reference efficiently
-func loadSemanticReference(db *sql.DB, tx *sql.Tx, refId int64) *gen.Reference {
-	var rows *sql.Rows
+func loadSemanticReference(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, refId int64) *gen.Reference {
+	var rows pgx.Rows
 	var err error
 
 	q, args := qb.NewSelect(
@@ -1562,9 +1615,9 @@ func loadSemanticReference(db *sql.DB, tx *sql.Tx, refId int64) *gen.Reference {
 		Build()
 
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1612,17 +1665,17 @@ func loadSemanticReference(db *sql.DB, tx *sql.Tx, refId int64) *gen.Reference {
 }
 
 // STEP 1: Discover element types to enable selective JOINs with parallel processing hints
-func getElementTypesForSubmodel(db *sql.DB, tx *sql.Tx, submodelId string) ([]string, error) {
+func getElementTypesForSubmodel(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string) ([]string, error) {
 	// Query with DISTINCT using query builder
 	q, args := qb.NewSelect("model_type").Distinct().From("submodel_element").Where("submodel_id = $1", submodelId).Build()
 
-	var rows *sql.Rows
+	var rows pgx.Rows
 	var err error
 
 	if tx != nil {
-		rows, err = tx.Query(q, args...)
+		rows, err = tx.Query(ctx, q, args...)
 	} else {
-		rows, err = db.Query(q, args...)
+		rows, err = db.Query(ctx, q, args...)
 	}
 
 	if err != nil {
@@ -1841,7 +1894,7 @@ var (
 )
 
 // Cached element type discovery with TTL
-func getCachedElementTypes(db *sql.DB, tx *sql.Tx, submodelId string) ([]string, error) {
+func getCachedElementTypes(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string) ([]string, error) {
 	// Check cache first
 	if cached, ok := elementTypeCache.Load(submodelId); ok {
 		if lastUpdate, exists := lastElementTypeUpdate.Load(submodelId); exists {
@@ -1852,7 +1905,7 @@ func getCachedElementTypes(db *sql.DB, tx *sql.Tx, submodelId string) ([]string,
 	}
 
 	// Cache miss or expired - fetch fresh data
-	elementTypes, err := getElementTypesForSubmodel(db, tx, submodelId)
+	elementTypes, err := getElementTypesForSubmodel(ctx, db, tx, submodelId)
 	if err != nil {
 		return nil, err
 	}
@@ -1865,9 +1918,9 @@ func getCachedElementTypes(db *sql.DB, tx *sql.Tx, submodelId string) ([]string,
 }
 
 // Enhanced GetSubmodelWithSubmodelElementsOptimized with comprehensive caching
-func GetSubmodelWithSubmodelElementsOptimizedCached(db *sql.DB, tx *sql.Tx, submodelId string) (*gen.Submodel, error) {
+func GetSubmodelWithSubmodelElementsOptimizedCached(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string) (*gen.Submodel, error) {
 	// Phase 1: Get element types with caching (eliminates repeated discovery queries)
-	elementTypes, err := getCachedElementTypes(db, tx, submodelId)
+	elementTypes, err := getCachedElementTypes(ctx, db, tx, submodelId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cached element types: %w", err)
 	}
@@ -1875,11 +1928,11 @@ func GetSubmodelWithSubmodelElementsOptimizedCached(db *sql.DB, tx *sql.Tx, subm
 	// Fast path for empty submodels remains the same
 	if len(elementTypes) == 0 {
 		// Same empty submodel handling...
-		return GetSubmodelWithSubmodelElementsOptimized(db, tx, submodelId)
+		return GetSubmodelWithSubmodelElementsOptimized(ctx, db, tx, submodelId)
 	}
 
 	// Use the existing optimized implementation with cached element types
-	return GetSubmodelWithSubmodelElementsOptimized(db, tx, submodelId)
+	return GetSubmodelWithSubmodelElementsOptimized(ctx, db, tx, submodelId)
 }
 
 // Cache invalidation for element types when submodel is modified
@@ -1906,12 +1959,12 @@ func ClearAllCaches() {
 
 // STEP 4: Main optimized function - DRASTIC PERFORMANCE IMPROVEMENT
 // Enhanced with connection pooling, prepared statements, and comprehensive caching
-func GetSubmodelWithSubmodelElementsOptimized(db *sql.DB, tx *sql.Tx, submodelId string) (*gen.Submodel, error) {
+func GetSubmodelWithSubmodelElementsOptimized(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, submodelId string) (*gen.Submodel, error) {
 	// Connection pool optimization hint: Ensure db.SetMaxOpenConns(25), db.SetMaxIdleConns(5)
 	// Prepared statement recommendation: Consider using prepared statements for repeated calls
 
 	// Phase 1: Get element types (1 query instead of N+1)
-	elementTypes, err := getElementTypesForSubmodel(db, tx, submodelId)
+	elementTypes, err := getElementTypesForSubmodel(ctx, db, tx, submodelId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get element types: %w", err)
 	}
@@ -1919,14 +1972,14 @@ func GetSubmodelWithSubmodelElementsOptimized(db *sql.DB, tx *sql.Tx, submodelId
 	if len(elementTypes) == 0 {
 		// Fast path for empty submodels
 		var query string
-		var rows *sql.Rows
+		var rows pgx.Rows
 
 		query = `SELECT id, id_short, category, kind, semantic_id FROM submodel WHERE id = $1`
 
 		if tx != nil {
-			rows, err = tx.Query(query, submodelId)
+			rows, err = tx.Query(ctx, query, submodelId)
 		} else {
-			rows, err = db.Query(query, submodelId)
+			rows, err = db.Query(ctx, query, submodelId)
 		}
 
 		if err != nil {
@@ -1959,13 +2012,13 @@ func GetSubmodelWithSubmodelElementsOptimized(db *sql.DB, tx *sql.Tx, submodelId
 			}
 			// Load semantic reference for empty submodel if present
 			if semanticId.Valid {
-				submodel.SemanticId = loadSemanticReference(db, tx, semanticId.Int64)
+				submodel.SemanticId = loadSemanticReference(ctx, db, tx, semanticId.Int64)
 			}
 
 			return submodel, nil
 		}
 
-		return nil, fmt.Errorf("submodel not found")
+		return nil, common.NewErrNotFound("Submodel not found")
 	}
 
 	// Phase 2: Build mega-optimized query with reference pre-loading (eliminates N+1 queries)
@@ -2006,11 +2059,11 @@ func GetSubmodelWithSubmodelElementsOptimized(db *sql.DB, tx *sql.Tx, submodelId
 		ORDER BY sme.position ASC NULLS LAST, sme.id_short ASC`
 
 	// Phase 3: Execute mega-optimized query
-	var rows *sql.Rows
+	var rows pgx.Rows
 	if tx != nil {
-		rows, err = tx.Query(optimizedQuery, submodelId)
+		rows, err = tx.Query(ctx, optimizedQuery, submodelId)
 	} else {
-		rows, err = db.Query(optimizedQuery, submodelId)
+		rows, err = db.Query(ctx, optimizedQuery, submodelId)
 	}
 
 	if err != nil {
@@ -2019,11 +2072,11 @@ func GetSubmodelWithSubmodelElementsOptimized(db *sql.DB, tx *sql.Tx, submodelId
 	defer rows.Close()
 
 	// Phase 4: Process results with advanced caching and memory optimization
-	return processOptimizedResults(rows, db, tx)
+	return processOptimizedResults(ctx, rows, db, tx)
 }
 
 // STEP 5: Advanced result processing with reference caching and memory optimization
-func processOptimizedResults(rows *sql.Rows, db *sql.DB, tx *sql.Tx) (*gen.Submodel, error) {
+func processOptimizedResults(ctx context.Context, rows pgx.Rows, db *pgxpool.Pool, tx pgx.Tx) (*gen.Submodel, error) {
 	// Pre-allocate with generous capacity to avoid re-allocations
 	nodes := make(map[int64]*node, 512)
 	children := make(map[int64][]*node, 256)
@@ -2150,10 +2203,10 @@ func processOptimizedResults(rows *sql.Rows, db *sql.DB, tx *sql.Tx) (*gen.Submo
 
 			// Load displayName and description for submodel
 			if submodelDisplayNameId.Valid {
-				submodel.DisplayName = loadLangStringNameType(db, tx, submodelDisplayNameId.Int64)
+				submodel.DisplayName = loadLangStringNameType(ctx, db, tx, submodelDisplayNameId.Int64)
 			}
 			if submodelDescriptionId.Valid {
-				submodel.Description = loadLangStringTextType(db, tx, submodelDescriptionId.Int64)
+				submodel.Description = loadLangStringTextType(ctx, db, tx, submodelDescriptionId.Int64)
 			}
 		}
 
@@ -2200,7 +2253,7 @@ func processOptimizedResults(rows *sql.Rows, db *sql.DB, tx *sql.Tx) (*gen.Submo
 		}
 
 		// Build element using optimized factory with displayName and description support
-		element, err := buildOptimizedElement(
+		element, err := buildOptimizedElement(ctx,
 			modelType.String, idShort.String,
 			getStringPtr(category), semanticIdObj,
 			displayNameId, descriptionId,
@@ -2245,7 +2298,7 @@ func processOptimizedResults(rows *sql.Rows, db *sql.DB, tx *sql.Tx) (*gen.Submo
 	}
 
 	if submodel == nil {
-		return nil, fmt.Errorf("submodel not found")
+		return nil, common.NewErrNotFound("Submodel not found")
 	}
 
 	// Build hierarchy with optimized sorting
@@ -2262,7 +2315,7 @@ func processOptimizedResults(rows *sql.Rows, db *sql.DB, tx *sql.Tx) (*gen.Submo
 }
 
 // STEP 6: Optimized element factory with reduced allocations and displayName/description support
-func buildOptimizedElement(modelType, idShort string, category *string, semanticId *gen.Reference,
+func buildOptimizedElement(ctx context.Context, modelType, idShort string, category *string, semanticId *gen.Reference,
 	displayNameId, descriptionId sql.NullInt64,
 	propValueType, propValue sql.NullString,
 	blobContentType sql.NullString, blobValue []byte,
@@ -2275,16 +2328,16 @@ func buildOptimizedElement(modelType, idShort string, category *string, semantic
 	beeObservedRef, beeMessageBrokerRef sql.NullInt64,
 	beeDirection, beeState, beeMessageTopic sql.NullString,
 	beeLastUpdate sql.NullTime, beeMinInterval, beeMaxInterval sql.NullString,
-	db *sql.DB, tx *sql.Tx) (gen.SubmodelElement, error) {
+	db *pgxpool.Pool, tx pgx.Tx) (gen.SubmodelElement, error) {
 
 	// Load displayName and description if present
 	var displayName []gen.LangStringNameType
 	var description []gen.LangStringTextType
 	if displayNameId.Valid {
-		displayName = loadLangStringNameType(db, tx, displayNameId.Int64)
+		displayName = loadLangStringNameType(ctx, db, tx, displayNameId.Int64)
 	}
 	if descriptionId.Valid {
-		description = loadLangStringTextType(db, tx, descriptionId.Int64)
+		description = loadLangStringTextType(ctx, db, tx, descriptionId.Int64)
 	}
 
 	// Fast element creation with type-specific optimizations
@@ -0,0 +1,218 @@
+package submodelelements
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+)
+
+// The JSON read mode renders a submodel or an element tree with a single recursive query. The
+// tree CTE walks the elements from the roots down and gives every element a sort key: the key
+// of its parent, the slot it is nested in (1 for values, statements, annotations and input
+// variables, 2 for output and 3 for in-output variables) and its ordinal among its siblings.
+// Each element then contributes text tokens - its JSON object without the closing brace, the
+// opening and closing brackets of its slots and its closing brace - that string_agg joins in
+// key order into one document. Elements are ordered and filled exactly like the Go read path
+// in GetSubmodelWithSubmodelElements and GetSubmodelElementsWithPath does it.
+
+// referenceJSON returns an SQL expression that renders the reference with the id in column,
+// keys in order and without keys if it has none.
+func referenceJSON(column string) string {
+	return `(SELECT jsonb_build_object('type', r.type, 'keys',
+		(SELECT jsonb_agg(jsonb_build_object('type', k.type, 'value', k.value) ORDER BY k.position)
+		 FROM reference_key k WHERE k.reference_id = r.id))
+	FROM reference r WHERE r.id = ` + column + `)`
+}
+
+// langStringJSON returns an SQL expression that renders the language strings of table
+// referenced by column, ordered by language.
+func langStringJSON(table string, column string) string {
+	return `(SELECT jsonb_agg(jsonb_build_object('language', l.language, 'text', l.text) ORDER BY l.language, l.id)
+	FROM ` + table + ` l WHERE l.` + table + `_reference_id = ` + column + `)`
+}
+
+// elementDocument renders the element sme without its nested elements, the tables of the
+// element types are joined as in getSubmodelElementLeftJoins.
+var elementDocument = `jsonb_strip_nulls(jsonb_build_object(
+		'idShort', sme.id_short,
+		'category', sme.category,
+		'modelType', sme.model_type,
+		'semanticId', ` + referenceJSON("sme.semantic_id") + `,
+		'supplementalSemanticIds', (SELECT jsonb_agg(` + referenceJSON("ss.reference_id") + ` ORDER BY ss.reference_id)
+			FROM sme_supplemental_semantic ss WHERE ss.sme_id = sme.id),
+		'displayName', ` + langStringJSON("lang_string_name_type", "sme.displayname_id") + `,
+		'description', ` + langStringJSON("lang_string_text_type", "sme.description_id") + `,
+		'qualifiers', (SELECT jsonb_agg(jsonb_build_object(
+				'kind', q.kind,
+				'type', q.type,
+				'valueType', q.value_type,
+				'value', COALESCE(q.value_text, q.value_num::text, q.value_bool::text, q.value_time::text, q.value_datetime::text),
+				'valueId', ` + referenceJSON("q.value_id") + `) ORDER BY q.id)
+			FROM qualifier q WHERE q.submodel_element_id = sme.id)
+	) || CASE sme.model_type
+		WHEN 'Property' THEN jsonb_build_object(
+			'valueType', prop.value_type,
+			'value', COALESCE(prop.value_text, prop.value_num::text, prop.value_bool::text, prop.value_time::text, prop.value_datetime::text))
+		WHEN 'MultiLanguageProperty' THEN jsonb_build_object(
+			'value', (SELECT jsonb_agg(jsonb_build_object('language', v.language, 'text', v.text) ORDER BY v.id)
+				FROM multilanguage_property_value v WHERE v.mlp_id = sme.id))
+		WHEN 'Blob' THEN jsonb_build_object(
+			'contentType', blob.content_type,
+			'value', convert_from(blob.value, 'UTF8'))
+		WHEN 'File' THEN jsonb_build_object(
+			'contentType', file.content_type,
+			'value', file.value)
+		WHEN 'Range' THEN jsonb_build_object(
+			'valueType', range_elem.value_type,
+			'min', COALESCE(range_elem.min_text, range_elem.min_num::text, range_elem.min_time::text, range_elem.min_datetime::text),
+			'max', COALESCE(range_elem.max_text, range_elem.max_num::text, range_elem.max_time::text, range_elem.max_datetime::text))
+		WHEN 'SubmodelElementList' THEN jsonb_build_object(
+			'typeValueListElement', sme_list.type_value_list_element,
+			'valueTypeListElement', sme_list.value_type_list_element,
+			'orderRelevant', sme_list.order_relevant,
+			'semanticIdListElement', ` + referenceJSON("sme_list.semantic_id_list_element") + `)
+		WHEN 'ReferenceElement' THEN jsonb_build_object(
+			'value', ` + referenceJSON("ref_elem.value_ref") + `)
+		WHEN 'RelationshipElement' THEN jsonb_build_object(
+			'first', ` + referenceJSON("rel_elem.first_ref") + `,
+			'second', ` + referenceJSON("rel_elem.second_ref") + `)
+		WHEN 'AnnotatedRelationshipElement' THEN jsonb_build_object(
+			'first', ` + referenceJSON("rel_elem.first_ref") + `,
+			'second', ` + referenceJSON("rel_elem.second_ref") + `)
+		WHEN 'Entity' THEN jsonb_build_object(
+			'entityType', entity.entity_type,
+			'globalAssetId', entity.global_asset_id,
+			'specificAssetIds', (SELECT jsonb_agg(jsonb_build_object(
+					'name', a.name,
+					'value', a.value,
+					'externalSubjectId', ` + referenceJSON("a.external_subject_ref") + `) ORDER BY a.id)
+				FROM entity_specific_asset_id a WHERE a.entity_id = sme.id))
+		WHEN 'BasicEventElement' THEN jsonb_build_object(
+			'observed', ` + referenceJSON("bee.observed_ref") + `,
+			'direction', bee.direction,
+			'state', bee.state,
+			'messageTopic', bee.message_topic,
+			'messageBroker', ` + referenceJSON("bee.message_broker_ref") + `,
+			'lastUpdate', to_char(bee.last_update AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'))
+		ELSE '{}'::jsonb
+	END)`
+
+// submodelDocument renders the submodel s without its elements.
+var submodelDocument = `jsonb_strip_nulls(jsonb_build_object(
+		'id', s.id,
+		'idShort', s.id_short,
+		'category', s.category,
+		'kind', COALESCE(s.kind::text, 'Instance'),
+		'modelType', 'Submodel',
+		'semanticId', ` + referenceJSON("s.semantic_id") + `,
+		'displayName', ` + langStringJSON("lang_string_name_type", "s.displayname_id") + `,
+		'description', ` + langStringJSON("lang_string_text_type", "s.description_id") + `))`
+
+// lastKey sorts the closing tokens of an element or slot after everything nested in it.
+const lastKey = "9223372036854775807"
+
+// submodelJSONQuery returns the query that renders the submodel $1, or with subtree set only
+// the element at idShortPath $2 of it.
+func submodelJSONQuery(subtree bool) string {
+	elementFilter := ""
+	roots := `SELECT e.id, e.model_type, ARRAY[1, e.ordinal], ARRAY[1]::bigint[], 1::bigint, NULL::text, false, e.ordinal
+		FROM element e WHERE e.parent_sme_id IS NULL`
+	if subtree {
+		elementFilter = ` AND (sme.idshort_path = $2 OR sme.idshort_path LIKE $2 || '.%' OR sme.idshort_path LIKE $2 || '[%')`
+		roots = `SELECT e.id, e.model_type, ARRAY[1]::bigint[], '{}'::bigint[], 1::bigint, NULL::text, false, 1::bigint
+		FROM element e WHERE e.idshort_path = $2`
+	}
+
+	var q strings.Builder
+	q.WriteString(`
+	WITH RECURSIVE element AS (
+		SELECT sme.id, sme.parent_sme_id, sme.model_type::text AS model_type, sme.idshort_path, ov.role::text AS role,
+		       CASE WHEN sme.parent_sme_id IS NULL
+		            THEN row_number() OVER (PARTITION BY sme.parent_sme_id ORDER BY sme.idshort_path, sme.position)
+		            ELSE row_number() OVER (PARTITION BY sme.parent_sme_id, ov.role ORDER BY sme.position NULLS LAST, sme.idshort_path COLLATE "C")
+		       END AS ordinal
+		FROM submodel_element sme
+		LEFT JOIN operation_variable ov ON sme.id = ov.value_sme
+		WHERE sme.submodel_id = $1 AND sme.model_type <> ALL ('{DataElement,EventElement,SubmodelElement}')` + elementFilter + `
+	), tree (id, model_type, sort_key, parent_key, slot, slot_name, wrap, ordinal) AS (
+		` + roots + `
+		UNION ALL
+		SELECT c.id, c.model_type, t.sort_key || slot.no || c.ordinal, t.sort_key, slot.no, slot.name, t.model_type = 'Operation', c.ordinal
+		FROM tree t
+		JOIN element c ON c.parent_sme_id = t.id
+		CROSS JOIN LATERAL (SELECT
+			CASE c.role WHEN 'out' THEN 2 WHEN 'inout' THEN 3 ELSE 1 END::bigint,
+			CASE t.model_type
+				WHEN 'Entity' THEN 'statements'
+				WHEN 'AnnotatedRelationshipElement' THEN 'annotations'
+				WHEN 'Operation' THEN CASE c.role WHEN 'out' THEN 'outputVariables' WHEN 'inout' THEN 'inoutputVariables' ELSE 'inputVariables' END
+				ELSE 'value'
+			END) AS slot (no, name)
+		WHERE t.model_type IN ('SubmodelElementCollection', 'SubmodelElementList', 'Entity', 'AnnotatedRelationshipElement', 'Operation')
+		  AND (t.model_type <> 'Operation' OR c.role IS NOT NULL)
+	)
+	SELECT string_agg(token, '' ORDER BY key)::jsonb FROM (
+		SELECT t.sort_key AS key,
+		       CASE WHEN t.ordinal > 1 THEN ',' ELSE '' END || CASE WHEN t.wrap THEN '{"value":' ELSE '' END || left((` + elementDocument + `)::text, -1) AS token
+		FROM tree t
+		JOIN submodel_element sme ON sme.id = t.id` + getSubmodelElementLeftJoins() + `
+		UNION ALL
+		SELECT t.sort_key || ` + lastKey + `::bigint, CASE WHEN t.wrap THEN '}}' ELSE '}' END FROM tree t
+		UNION ALL
+		SELECT t.parent_key || t.slot, ', "' || t.slot_name || '": [' FROM tree t WHERE t.ordinal = 1 AND t.slot_name IS NOT NULL
+		UNION ALL
+		SELECT t.parent_key || t.slot || ` + lastKey + `::bigint, ']' FROM tree t WHERE t.ordinal = 1 AND t.slot_name IS NOT NULL`)
+	if !subtree {
+		q.WriteString(`
+		UNION ALL
+		SELECT '{}'::bigint[], left((` + submodelDocument + `)::text, -1)
+		       || CASE WHEN EXISTS (SELECT 1 FROM tree) THEN ', "submodelElements": [' ELSE '' END
+		FROM submodel s WHERE s.id = $1
+		UNION ALL
+		SELECT ARRAY[` + lastKey + `]::bigint[], CASE WHEN EXISTS (SELECT 1 FROM tree) THEN ']}' ELSE '}' END
+		FROM submodel s WHERE s.id = $1`)
+	}
+	q.WriteString(`
+	) tokens`)
+	return q.String()
+}
+
+var (
+	submodelJSON        = submodelJSONQuery(false)
+	submodelElementJSON = `SELECT (` + submodelJSONQuery(true) + `) FROM submodel s WHERE s.id = $1`
+)
+
+// GetSubmodelJSON returns the submodel with all its elements rendered as AAS JSON by a single
+// recursive query, regardless of the depth of the tree.
+func GetSubmodelJSON(ctx context.Context, tx pgx.Tx, submodelId string) ([]byte, error) {
+	var data []byte
+	err := tx.QueryRow(ctx, submodelJSON, submodelId).Scan(&data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, common.NewErrNotFound("Submodel not found")
+	}
+	return data, nil
+}
+
+// GetSubmodelElementJSON returns the element at idShortPath with all nested elements rendered
+// as AAS JSON, see GetSubmodelJSON.
+func GetSubmodelElementJSON(ctx context.Context, tx pgx.Tx, submodelId string, idShortPath string) ([]byte, error) {
+	var data []byte
+	err := tx.QueryRow(ctx, submodelElementJSON, submodelId, idShortPath).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, common.NewErrNotFound("Submodel not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, common.NewErrNotFound("SubmodelElement with idShort or path '" + idShortPath + "' not found in submodel '" + submodelId + "'")
+	}
+	return data, nil
+}
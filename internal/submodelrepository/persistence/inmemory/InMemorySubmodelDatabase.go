@@ -0,0 +1,416 @@
+// Package persistence_inmemory provides a non-persistent Submodel Repository backend for
+// demos and tests. It follows the idShortPath, list-index and paging behaviour of the
+// PostgreSQL backend, but all data is lost when the process exits.
+package persistence_inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+type InMemorySubmodelDatabase struct {
+	mu        sync.RWMutex
+	submodels map[string]*gen.Submodel
+}
+
+func NewInMemorySubmodelBackend() *InMemorySubmodelDatabase {
+	return &InMemorySubmodelDatabase{submodels: make(map[string]*gen.Submodel)}
+}
+
+// GetAllSubmodels returns the submodels ordered by id. The cursor is the id of the first
+// submodel of the requested page.
+func (m *InMemorySubmodelDatabase) GetAllSubmodels(ctx context.Context, limit int32, cursor string, idShort string) ([]gen.Submodel, string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.submodels))
+	for id, sm := range m.submodels {
+		if idShort != "" && sm.IdShort != idShort {
+			continue
+		}
+		if cursor != "" && id < cursor {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	nextCursor := ""
+	if len(ids) > int(limit) {
+		nextCursor = ids[limit]
+		ids = ids[:limit]
+	}
+
+	result := make([]gen.Submodel, 0, len(ids))
+	for _, id := range ids {
+		sm, err := copySubmodel(m.submodels[id])
+		if err != nil {
+			return nil, "", common.NewInternalServerError("Failed to copy submodel '" + id + "': " + err.Error())
+		}
+		result = append(result, *sm)
+	}
+	return result, nextCursor, nil
+}
+
+func (m *InMemorySubmodelDatabase) GetSubmodel(ctx context.Context, id string) (gen.Submodel, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, ok := m.submodels[id]
+	if !ok {
+		return gen.Submodel{}, common.NewErrNotFound("Submodel not found")
+	}
+	sm, err := copySubmodel(stored)
+	if err != nil {
+		return gen.Submodel{}, common.NewInternalServerError("Failed to copy submodel '" + id + "': " + err.Error())
+	}
+	return *sm, nil
+}
+
+func (m *InMemorySubmodelDatabase) CreateSubmodel(ctx context.Context, sm gen.Submodel) error {
+	stored, err := copySubmodel(&sm)
+	if err != nil {
+		return common.NewErrBadRequest("Invalid submodel: " + err.Error())
+	}
+	if err := checkUniqueIdShorts(stored.SubmodelElements, ""); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.submodels[sm.Id]; exists {
+		return common.NewErrConflict("Submodel with id '" + sm.Id + "' already exists")
+	}
+	m.submodels[sm.Id] = stored
+	return nil
+}
+
+func (m *InMemorySubmodelDatabase) DeleteSubmodel(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.submodels[id]; !ok {
+		return common.NewErrNotFound("Submodel not found")
+	}
+	delete(m.submodels, id)
+	return nil
+}
+
+func (m *InMemorySubmodelDatabase) GetSubmodelElement(ctx context.Context, submodelId string, idShortOrPath string, limit int, cursor string) (gen.SubmodelElement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sm, ok := m.submodels[submodelId]
+	if !ok {
+		return nil, common.NewErrNotFound("Submodel not found")
+	}
+	path, err := parseIdShortPath(idShortOrPath)
+	if err != nil {
+		return nil, err
+	}
+	el, _, _ := resolve(sm, path)
+	if el == nil {
+		return nil, common.NewErrNotFound("SubmodelElement with idShort or path '" + idShortOrPath + "' not found in submodel '" + submodelId + "'")
+	}
+	out, err := copyElement(el)
+	if err != nil {
+		return nil, common.NewInternalServerError("Failed to copy submodel element '" + idShortOrPath + "': " + err.Error())
+	}
+	return out, nil
+}
+
+// GetSubmodelElements pages over the top-level elements ordered by idShort. As with the
+// PostgreSQL backend the page starts after the element whose idShort equals cursor, and the
+// returned cursor is the idShort of the last element of the page.
+func (m *InMemorySubmodelDatabase) GetSubmodelElements(ctx context.Context, submodelId string, limit int, cursor string) ([]gen.SubmodelElement, string, error) {
+	if limit < 1 {
+		limit = 100
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sm, ok := m.submodels[submodelId]
+	if !ok {
+		return nil, "", common.NewErrNotFound("Submodel not found")
+	}
+
+	roots := append([]gen.SubmodelElement(nil), sm.SubmodelElements...)
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].GetIdShort() < roots[j].GetIdShort() })
+
+	offset := 0
+	if cursor != "" {
+		found := false
+		for i, el := range roots {
+			if el.GetIdShort() == cursor {
+				offset, found = i+1, true
+			}
+		}
+		if !found {
+			return nil, "", common.NewErrBadRequest("Invalid cursor " + cursor)
+		}
+	}
+
+	end := min(offset+limit, len(roots))
+	res := make([]gen.SubmodelElement, 0, end-offset)
+	for _, el := range roots[offset:end] {
+		out, err := copyElement(el)
+		if err != nil {
+			return nil, "", common.NewInternalServerError("Failed to copy submodel element '" + el.GetIdShort() + "': " + err.Error())
+		}
+		res = append(res, out)
+	}
+
+	if len(res) == 0 {
+		return res, "", nil
+	}
+	return res, res[len(res)-1].GetIdShort(), nil
+}
+
+func (m *InMemorySubmodelDatabase) AddSubmodelElement(ctx context.Context, submodelId string, submodelElement gen.SubmodelElement) error {
+	el, err := copyElement(submodelElement)
+	if err != nil {
+		return common.NewErrBadRequest("Invalid submodel element: " + err.Error())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sm, ok := m.submodels[submodelId]
+	if !ok {
+		return common.NewErrNotFound("Submodel not found")
+	}
+	if err := checkUniqueIdShorts(append(append([]gen.SubmodelElement(nil), sm.SubmodelElements...), el), ""); err != nil {
+		return err
+	}
+	sm.SubmodelElements = append(sm.SubmodelElements, el)
+	return nil
+}
+
+func (m *InMemorySubmodelDatabase) AddSubmodelElementWithPath(ctx context.Context, submodelId string, idShortPath string, submodelElement gen.SubmodelElement) error {
+	el, err := copyElement(submodelElement)
+	if err != nil {
+		return common.NewErrBadRequest("Invalid submodel element: " + err.Error())
+	}
+	path, err := parseIdShortPath(idShortPath)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sm, ok := m.submodels[submodelId]
+	if !ok {
+		return common.NewErrNotFound("Submodel not found")
+	}
+	parent, _, _ := resolve(sm, path)
+	if parent == nil {
+		return common.NewErrNotFound("Submodel-Element ID-Short: " + idShortPath)
+	}
+
+	switch p := parent.(type) {
+	case *gen.SubmodelElementCollection:
+		if err := checkUniqueIdShorts(append(append([]gen.SubmodelElement(nil), p.Value...), el), idShortPath+"."); err != nil {
+			return err
+		}
+		p.Value = append(p.Value, el)
+	case *gen.SubmodelElementList:
+		if err := checkUniqueIdShorts([]gen.SubmodelElement{el}, idShortPath+"["+strconv.Itoa(len(p.Value))+"]."); err != nil {
+			return err
+		}
+		p.Value = append(p.Value, el)
+	default:
+		return common.NewErrBadRequest("cannot add nested element to non-collection/list element")
+	}
+	return nil
+}
+
+func (m *InMemorySubmodelDatabase) DeleteSubmodelElementByPath(ctx context.Context, submodelId string, idShortOrPath string) error {
+	path, err := parseIdShortPath(idShortOrPath)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sm, ok := m.submodels[submodelId]
+	if !ok {
+		return common.NewErrNotFound("Submodel not found")
+	}
+	el, siblings, index := resolve(sm, path)
+	if el == nil {
+		return common.NewErrNotFound("Submodel-Element ID-Short: " + idShortOrPath)
+	}
+	// Removing the entry from the slice shifts later list entries down by one index.
+	*siblings = append((*siblings)[:index], (*siblings)[index+1:]...)
+	return nil
+}
+
+// pathSegment is one step of an idShortPath: either an idShort or a list index.
+type pathSegment struct {
+	idShort string
+	index   int
+	isIndex bool
+}
+
+// parseIdShortPath splits "a.b[2].c" into its segments.
+func parseIdShortPath(path string) ([]pathSegment, error) {
+	invalid := func() error { return common.NewErrBadRequest("Invalid idShortPath '" + path + "'") }
+	if path == "" {
+		return nil, invalid()
+	}
+
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name == "" && len(segments) == 0 {
+			return nil, invalid()
+		}
+		if name != "" {
+			segments = append(segments, pathSegment{idShort: name})
+		} else if rest == "" {
+			return nil, invalid()
+		}
+		for rest != "" {
+			indexStr, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, invalid()
+			}
+			index, err := strconv.Atoi(indexStr)
+			if err != nil || index < 0 {
+				return nil, invalid()
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, invalid()
+			}
+			rest = after[1:]
+		}
+	}
+	return segments, nil
+}
+
+// resolve walks the path and returns the element, the slice holding it and its index in
+// that slice. The element is nil if the path does not exist.
+func resolve(sm *gen.Submodel, path []pathSegment) (gen.SubmodelElement, *[]gen.SubmodelElement, int) {
+	siblings := &sm.SubmodelElements
+	var current gen.SubmodelElement
+
+	for i, seg := range path {
+		if i > 0 {
+			siblings = childrenOf(current, seg.isIndex)
+			if siblings == nil {
+				return nil, nil, 0
+			}
+		}
+
+		index := -1
+		if seg.isIndex {
+			if seg.index < len(*siblings) {
+				index = seg.index
+			}
+		} else {
+			for j, el := range *siblings {
+				if el.GetIdShort() == seg.idShort {
+					index = j
+					break
+				}
+			}
+		}
+		if index < 0 {
+			return nil, nil, 0
+		}
+		current = (*siblings)[index]
+		if i == len(path)-1 {
+			return current, siblings, index
+		}
+	}
+	return nil, nil, 0
+}
+
+// childrenOf returns the children of a collection (addressed by idShort) or a list
+// (addressed by index), or nil if el cannot be addressed that way.
+func childrenOf(el gen.SubmodelElement, byIndex bool) *[]gen.SubmodelElement {
+	switch e := el.(type) {
+	case *gen.SubmodelElementCollection:
+		if !byIndex {
+			return &e.Value
+		}
+	case *gen.SubmodelElementList:
+		if byIndex {
+			return &e.Value
+		}
+	}
+	return nil
+}
+
+// checkUniqueIdShorts rejects siblings with equal idShorts, recursively. List entries are
+// addressed by index, so only their nested collections are checked.
+func checkUniqueIdShorts(elements []gen.SubmodelElement, prefix string) error {
+	seen := make(map[string]struct{}, len(elements))
+	for _, el := range elements {
+		if _, dup := seen[el.GetIdShort()]; dup {
+			return common.NewErrConflict(fmt.Sprintf("SubmodelElement with idShortPath '%s%s' already exists", prefix, el.GetIdShort()))
+		}
+		seen[el.GetIdShort()] = struct{}{}
+
+		switch e := el.(type) {
+		case *gen.SubmodelElementCollection:
+			if err := checkUniqueIdShorts(e.Value, prefix+e.IdShort+"."); err != nil {
+				return err
+			}
+		case *gen.SubmodelElementList:
+			for i, child := range e.Value {
+				if c, ok := child.(*gen.SubmodelElementCollection); ok {
+					if err := checkUniqueIdShorts(c.Value, prefix+e.IdShort+"["+strconv.Itoa(i)+"]."); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// copySubmodel deep-copies a submodel so that stored data is never shared with callers.
+func copySubmodel(sm *gen.Submodel) (*gen.Submodel, error) {
+	raw, err := json.Marshal(sm)
+	if err != nil {
+		return nil, err
+	}
+	var out gen.Submodel
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// copyElement deep-copies a submodel element, see copySubmodel.
+func copyElement(el gen.SubmodelElement) (gen.SubmodelElement, error) {
+	if el == nil {
+		return nil, fmt.Errorf("submodel element is nil")
+	}
+	raw, err := json.Marshal(el)
+	if err != nil {
+		return nil, err
+	}
+	return gen.UnmarshalSubmodelElement(raw)
+}
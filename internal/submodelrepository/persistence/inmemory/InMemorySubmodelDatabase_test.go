@@ -0,0 +1,173 @@
+package persistence_inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+func property(idShort string, value string) *gen.Property {
+	return &gen.Property{IdShort: idShort, ModelType: "Property", ValueType: gen.DATATYPEDEFXSD_XS_STRING, Value: value}
+}
+
+func newTestSubmodel() gen.Submodel {
+	return gen.Submodel{
+		Id:        "urn:sm:1",
+		IdShort:   "Technical",
+		ModelType: "Submodel",
+		SubmodelElements: []gen.SubmodelElement{
+			property("b", "1"),
+			&gen.SubmodelElementCollection{IdShort: "c", ModelType: "SubmodelElementCollection", Value: []gen.SubmodelElement{
+				&gen.SubmodelElementList{IdShort: "list", ModelType: "SubmodelElementList", Value: []gen.SubmodelElement{
+					property("", "x0"),
+					property("", "x1"),
+					property("", "x2"),
+				}},
+			}},
+			property("a", "2"),
+		},
+	}
+}
+
+func TestCreateGetDeleteSubmodel(t *testing.T) {
+	db := NewInMemorySubmodelBackend()
+	sm := newTestSubmodel()
+	if err := db.CreateSubmodel(context.Background(), sm); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateSubmodel(context.Background(), sm); !common.IsErrConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+
+	// stored data must not alias the caller's elements
+	sm.SubmodelElements[0].(*gen.Property).Value = "changed"
+	got, err := db.GetSubmodel(context.Background(), "urn:sm:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SubmodelElements[0].(*gen.Property).Value != "1" {
+		t.Fatalf("stored submodel was modified through the caller's copy")
+	}
+
+	if err := db.DeleteSubmodel(context.Background(), "urn:sm:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.GetSubmodel(context.Background(), "urn:sm:1"); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if err := db.DeleteSubmodel(context.Background(), "urn:sm:1"); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found on second delete, got %v", err)
+	}
+}
+
+func TestGetAllSubmodelsPaging(t *testing.T) {
+	db := NewInMemorySubmodelBackend()
+	for _, id := range []string{"urn:sm:3", "urn:sm:1", "urn:sm:2"} {
+		if err := db.CreateSubmodel(context.Background(), gen.Submodel{Id: id, IdShort: "sm", ModelType: "Submodel"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, next, err := db.GetAllSubmodels(context.Background(), 2, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || page[0].Id != "urn:sm:1" || page[1].Id != "urn:sm:2" || next != "urn:sm:3" {
+		t.Fatalf("unexpected first page: %v, cursor %q", page, next)
+	}
+	page, next, err = db.GetAllSubmodels(context.Background(), 2, next, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || page[0].Id != "urn:sm:3" || next != "" {
+		t.Fatalf("unexpected last page: %v, cursor %q", page, next)
+	}
+
+	page, _, _ = db.GetAllSubmodels(context.Background(), 0, "", "other")
+	if len(page) != 0 {
+		t.Fatalf("expected idShort filter to exclude all submodels, got %d", len(page))
+	}
+}
+
+func TestSubmodelElementPaths(t *testing.T) {
+	db := NewInMemorySubmodelBackend()
+	if err := db.CreateSubmodel(context.Background(), newTestSubmodel()); err != nil {
+		t.Fatal(err)
+	}
+
+	el, err := db.GetSubmodelElement(context.Background(), "urn:sm:1", "c.list[1]", 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if el.(*gen.Property).Value != "x1" {
+		t.Fatalf("unexpected element at c.list[1]: %+v", el)
+	}
+	if _, err := db.GetSubmodelElement(context.Background(), "urn:sm:1", "c.list[3]", 0, ""); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := db.GetSubmodelElement(context.Background(), "urn:sm:1", "c.list.x", 0, ""); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found for idShort access into a list, got %v", err)
+	}
+	if _, err := db.GetSubmodelElement(context.Background(), "urn:sm:1", "c..list", 0, ""); !common.IsErrBadRequest(err) {
+		t.Fatalf("expected bad request, got %v", err)
+	}
+
+	// deleting a list entry shifts later entries down
+	if err := db.DeleteSubmodelElementByPath(context.Background(), "urn:sm:1", "c.list[0]"); err != nil {
+		t.Fatal(err)
+	}
+	el, err = db.GetSubmodelElement(context.Background(), "urn:sm:1", "c.list[1]", 0, "")
+	if err != nil || el.(*gen.Property).Value != "x2" {
+		t.Fatalf("expected x2 at c.list[1] after delete, got %+v (%v)", el, err)
+	}
+	if err := db.DeleteSubmodelElementByPath(context.Background(), "urn:sm:1", "c.list[5]"); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	if err := db.AddSubmodelElementWithPath(context.Background(), "urn:sm:1", "c.list", property("", "x3")); err != nil {
+		t.Fatal(err)
+	}
+	el, err = db.GetSubmodelElement(context.Background(), "urn:sm:1", "c.list[2]", 0, "")
+	if err != nil || el.(*gen.Property).Value != "x3" {
+		t.Fatalf("expected appended element at c.list[2], got %+v (%v)", el, err)
+	}
+	if err := db.AddSubmodelElementWithPath(context.Background(), "urn:sm:1", "c", property("list", "")); !common.IsErrConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if err := db.AddSubmodelElementWithPath(context.Background(), "urn:sm:1", "a", property("p", "")); !common.IsErrBadRequest(err) {
+		t.Fatalf("expected bad request for a non-container parent, got %v", err)
+	}
+	if err := db.AddSubmodelElement(context.Background(), "urn:sm:1", property("a", "")); !common.IsErrConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+}
+
+func TestGetSubmodelElementsPaging(t *testing.T) {
+	db := NewInMemorySubmodelBackend()
+	if err := db.CreateSubmodel(context.Background(), newTestSubmodel()); err != nil {
+		t.Fatal(err)
+	}
+
+	page, next, err := db.GetSubmodelElements(context.Background(), "urn:sm:1", 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || page[0].GetIdShort() != "a" || page[1].GetIdShort() != "b" || next != "b" {
+		t.Fatalf("unexpected first page, cursor %q", next)
+	}
+	page, next, err = db.GetSubmodelElements(context.Background(), "urn:sm:1", 2, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || page[0].GetIdShort() != "c" || next != "c" {
+		t.Fatalf("unexpected second page, cursor %q", next)
+	}
+	if _, _, err := db.GetSubmodelElements(context.Background(), "urn:sm:1", 2, "unknown"); !common.IsErrBadRequest(err) {
+		t.Fatalf("expected bad request for an unknown cursor, got %v", err)
+	}
+	if _, _, err := db.GetSubmodelElements(context.Background(), "urn:sm:missing", 2, ""); !common.IsErrNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
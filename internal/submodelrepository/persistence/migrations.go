@@ -0,0 +1,16 @@
+package persistence_postgresql
+
+import (
+	"database/sql"
+	"embed"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator returns the schema migrator of the Submodel Repository.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	return migrate.New(db, "submodelrepository", migrationFiles, "migrations")
+}
@@ -0,0 +1,44 @@
+-- Drops the complete Submodel Repository schema. The ltree and pg_trgm extensions are kept,
+-- other schemas in the same database may use them.
+DROP TABLE IF EXISTS
+  qualifier,
+  capability_element,
+  basic_event_element,
+  operation_variable,
+  operation_element,
+  entity_specific_asset_id,
+  entity_element,
+  submodel_element_list,
+  submodel_element_collection,
+  annotated_rel_annotation,
+  relationship_element,
+  reference_element,
+  range_element,
+  file_element,
+  blob_element,
+  multilanguage_property_value,
+  multilanguage_property,
+  property_element,
+  sme_semantic_key,
+  sme_supplemental_semantic,
+  submodel_element,
+  submodel_semantic_key,
+  submodel,
+  lang_string_name_type,
+  lang_string_name_type_reference,
+  lang_string_text_type,
+  lang_string_text_type_reference,
+  reference_key,
+  reference;
+
+DROP TYPE IF EXISTS
+  key_type,
+  operation_var_role,
+  state_of_event,
+  direction,
+  entity_type,
+  qualifier_kind,
+  reference_types,
+  data_type_def_xsd,
+  aas_submodel_elements,
+  modelling_kind;
@@ -1,18 +1,18 @@
 -- ------------------------------------------
 -- Extensions
 -- ------------------------------------------
-CREATE EXTENSION IF NOT EXISTS ltree;
-CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS ltree SCHEMA public;
+CREATE EXTENSION IF NOT EXISTS pg_trgm SCHEMA public;
 
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'modelling_kind') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'modelling_kind' AND n.nspname = current_schema()) THEN
     CREATE TYPE modelling_kind AS ENUM ('Instance', 'Template');
  END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'aas_submodel_elements') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'aas_submodel_elements' AND n.nspname = current_schema()) THEN
     CREATE TYPE aas_submodel_elements AS ENUM (
       'AnnotatedRelationshipElement','BasicEventElement','Blob','Capability',
       'DataElement','Entity','EventElement','File','MultiLanguageProperty',
@@ -23,7 +23,7 @@ DO $$ BEGIN
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'data_type_def_xsd') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'data_type_def_xsd' AND n.nspname = current_schema()) THEN
     CREATE TYPE data_type_def_xsd AS ENUM (
       'xs:anyURI','xs:base64Binary','xs:boolean','xs:byte','xs:date','xs:dateTime',
       'xs:decimal','xs:double','xs:duration','xs:float','xs:gDay','xs:gMonth',
@@ -36,43 +36,43 @@ DO $$ BEGIN
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'reference_types') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'reference_types' AND n.nspname = current_schema()) THEN
     CREATE TYPE reference_types AS ENUM ('ExternalReference', 'ModelReference');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'qualifier_kind') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'qualifier_kind' AND n.nspname = current_schema()) THEN
     CREATE TYPE qualifier_kind AS ENUM ('ConceptQualifier','TemplateQualifier','ValueQualifier');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'entity_type') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'entity_type' AND n.nspname = current_schema()) THEN
     CREATE TYPE entity_type AS ENUM ('CoManagedEntity','SelfManagedEntity');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'direction') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'direction' AND n.nspname = current_schema()) THEN
     CREATE TYPE direction AS ENUM ('input','output');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'state_of_event') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'state_of_event' AND n.nspname = current_schema()) THEN
     CREATE TYPE state_of_event AS ENUM ('off','on');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'operation_var_role') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'operation_var_role' AND n.nspname = current_schema()) THEN
     CREATE TYPE operation_var_role AS ENUM ('in','out','inout');
   END IF;
 END $$;
 
 DO $$ BEGIN
-  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'key_type') THEN
+  IF NOT EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace WHERE t.typname = 'key_type' AND n.nspname = current_schema()) THEN
     CREATE TYPE key_type AS ENUM ('AnnotatedRelationshipElement','AssetAdministrationShell','BasicEventElement','Blob',
       'Capability','ConceptDescription','DataElement','Entity','EventElement','File','FragmentReference','GlobalReference','Identifiable',
       'MultiLanguageProperty','Operation','Property','Range','Referable','ReferenceElement','RelationshipElement','Submodel','SubmodelElement',
@@ -96,7 +96,7 @@ CREATE TABLE IF NOT EXISTS reference_key (
 );
 
 CREATE INDEX IF NOT EXISTS ix_refkey_type_val     ON reference_key(type, value);
-CREATE INDEX IF NOT EXISTS ix_refkey_val_trgm     ON reference_key USING GIN (value gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_refkey_val_trgm     ON reference_key USING GIN (value public.gin_trgm_ops);
 
 
 CREATE TABLE IF NOT EXISTS lang_string_text_type_reference(
@@ -141,7 +141,7 @@ CREATE TABLE IF NOT EXISTS submodel_semantic_key (
   PRIMARY KEY (submodel_id, position)
 );
 CREATE INDEX IF NOT EXISTS ix_smsem_key     ON submodel_semantic_key(key_type, key_value);
-CREATE INDEX IF NOT EXISTS ix_smsem_val_trgm ON submodel_semantic_key USING GIN (key_value gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_smsem_val_trgm ON submodel_semantic_key USING GIN (key_value public.gin_trgm_ops);
 
 CREATE TABLE IF NOT EXISTS submodel_element (
   id             BIGSERIAL PRIMARY KEY,
@@ -159,7 +159,7 @@ CREATE TABLE IF NOT EXISTS submodel_element (
   CONSTRAINT uq_sibling_pos     UNIQUE (submodel_id, parent_sme_id, position)
 );
 
-CREATE INDEX IF NOT EXISTS ix_sme_path_gin       ON submodel_element USING GIN (idshort_path gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_sme_path_gin       ON submodel_element USING GIN (idshort_path public.gin_trgm_ops);
 CREATE INDEX IF NOT EXISTS ix_sme_sub_path       ON submodel_element(submodel_id, idshort_path);
 CREATE INDEX IF NOT EXISTS ix_sme_parent_pos     ON submodel_element(parent_sme_id, position);
 CREATE INDEX IF NOT EXISTS ix_sme_sub_type       ON submodel_element(submodel_id, model_type);
@@ -178,7 +178,7 @@ CREATE TABLE IF NOT EXISTS sme_semantic_key (
   PRIMARY KEY (sme_id, position)
 );
 CREATE INDEX IF NOT EXISTS ix_smesem_key       ON sme_semantic_key(key_type, key_value);
-CREATE INDEX IF NOT EXISTS ix_smesem_val_trgm  ON sme_semantic_key USING GIN (key_value gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_smesem_val_trgm  ON sme_semantic_key USING GIN (key_value public.gin_trgm_ops);
 
 -- Property (typed for fast comparisons)
 CREATE TABLE IF NOT EXISTS property_element (
@@ -203,7 +203,7 @@ CREATE INDEX IF NOT EXISTS ix_prop_time     ON property_element(value_time)
   WHERE value_type = 'xs:time';
 CREATE INDEX IF NOT EXISTS ix_prop_bool     ON property_element(value_bool)
   WHERE value_type = 'xs:boolean';
-CREATE INDEX IF NOT EXISTS ix_prop_text_trgm ON property_element USING GIN (value_text gin_trgm_ops)
+CREATE INDEX IF NOT EXISTS ix_prop_text_trgm ON property_element USING GIN (value_text public.gin_trgm_ops)
   WHERE value_type = 'xs:string';
 
 CREATE TABLE IF NOT EXISTS multilanguage_property (
@@ -217,7 +217,7 @@ CREATE TABLE IF NOT EXISTS multilanguage_property_value (
   text     TEXT NOT NULL
 );
 CREATE INDEX IF NOT EXISTS ix_mlp_lang      ON multilanguage_property_value(mlp_id, language);
-CREATE INDEX IF NOT EXISTS ix_mlp_text_trgm ON multilanguage_property_value USING GIN (text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_mlp_text_trgm ON multilanguage_property_value USING GIN (text public.gin_trgm_ops);
 
 CREATE TABLE IF NOT EXISTS blob_element (
   id           BIGINT PRIMARY KEY REFERENCES submodel_element(id) ON DELETE CASCADE,
@@ -230,7 +230,7 @@ CREATE TABLE IF NOT EXISTS file_element (
   content_type TEXT,
   value        TEXT
 );
-CREATE INDEX IF NOT EXISTS ix_file_value_trgm ON file_element USING GIN (value gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_file_value_trgm ON file_element USING GIN (value public.gin_trgm_ops);
 
 -- Range (also typed)
 CREATE TABLE IF NOT EXISTS range_element (
@@ -338,7 +338,7 @@ CREATE INDEX IF NOT EXISTS ix_qual_sme       ON qualifier(submodel_element_id);
 CREATE INDEX IF NOT EXISTS ix_qual_type      ON qualifier(type);
 CREATE INDEX IF NOT EXISTS ix_qual_num       ON qualifier(value_num)
   WHERE value_type IN ('xs:decimal','xs:double','xs:float','xs:int','xs:integer','xs:long','xs:short');
-CREATE INDEX IF NOT EXISTS ix_qual_text_trgm ON qualifier USING GIN (value_text gin_trgm_ops)
+CREATE INDEX IF NOT EXISTS ix_qual_text_trgm ON qualifier USING GIN (value_text public.gin_trgm_ops)
   WHERE value_type = 'xs:string';
 
 ALTER TABLE submodel_element
@@ -352,4 +352,4 @@ CREATE INDEX IF NOT EXISTS ix_sme_roots_order
                        position,
                        idshort_path,
                        id)
-  WHERE parent_sme_id IS NULL;
\ No newline at end of file
+  WHERE parent_sme_id IS NULL;
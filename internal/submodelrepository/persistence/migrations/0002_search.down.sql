@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS ix_sme_displayname;
+DROP INDEX IF EXISTS ix_sme_description;
+DROP INDEX IF EXISTS ix_lsnt_text_trgm;
+DROP INDEX IF EXISTS ix_lstt_text_trgm;
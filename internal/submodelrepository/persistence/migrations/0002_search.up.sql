@@ -0,0 +1,9 @@
+-- Indexes for the full-text search over element values and lang strings. Values, MLP texts,
+-- file paths and idShortPaths already have trigram indexes; descriptions and display names
+-- get theirs here, together with the indexes that lead from a matching lang string back to
+-- the element that owns it.
+
+CREATE INDEX IF NOT EXISTS ix_lstt_text_trgm ON lang_string_text_type USING GIN (text public.gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_lsnt_text_trgm ON lang_string_name_type USING GIN (text public.gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ix_sme_description ON submodel_element(description_id);
+CREATE INDEX IF NOT EXISTS ix_sme_displayname ON submodel_element(displayname_id);
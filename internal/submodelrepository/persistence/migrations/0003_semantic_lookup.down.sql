@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_smesupp_reference;
+DROP INDEX IF EXISTS ix_sme_semantic_id;
+DROP INDEX IF EXISTS ix_refkey_val_pattern;
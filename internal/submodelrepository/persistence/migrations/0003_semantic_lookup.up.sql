@@ -0,0 +1,8 @@
+-- Indexes for the lookup of elements by semanticId across submodels. The btree with
+-- text_pattern_ops answers both equal keys and prefixes (LIKE 'x%'); the other two lead from
+-- a matching reference back to the elements that use it as semanticId or
+-- supplementalSemanticId.
+
+CREATE INDEX IF NOT EXISTS ix_refkey_val_pattern ON reference_key(value text_pattern_ops);
+CREATE INDEX IF NOT EXISTS ix_sme_semantic_id    ON submodel_element(semantic_id);
+CREATE INDEX IF NOT EXISTS ix_smesupp_reference  ON sme_supplemental_semantic(reference_id);
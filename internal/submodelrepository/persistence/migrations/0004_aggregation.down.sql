@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ix_sm_semantic_id;
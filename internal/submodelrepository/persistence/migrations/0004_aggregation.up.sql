@@ -0,0 +1,5 @@
+-- Index for the aggregations over all submodels with a semanticId: it leads from the
+-- matching reference to the submodels. The elements are then found by (submodel_id,
+-- idshort_path) and the values by the partial indexes of property_element.
+
+CREATE INDEX IF NOT EXISTS ix_sm_semantic_id ON submodel(semantic_id);
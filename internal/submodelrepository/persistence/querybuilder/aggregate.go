@@ -0,0 +1,64 @@
+package querybuilder
+
+import (
+	"strconv"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+)
+
+// groupValue is the value of the group property as text, whatever its type.
+const groupValue = "COALESCE(gp.value_text, gp.value_num::text, gp.value_bool::text, gp.value_time::text, gp.value_datetime::text)"
+
+// AggregatedValues selects the values that req aggregates, one row per submodel: submodel_id,
+// x, the value as double precision (date-times as seconds since the epoch, see
+// aggregate.Scalar), and grp, the value of req.GroupBy. ids restricts the submodels, nil for
+// all. The submodels are found by the first key of their semanticId, as $sm#semanticId.
+func AggregatedValues(req aggregate.Request, ids []string) *SelectBuilder {
+	x, column, types := "p.value_num::float8", "p.value_num", numericTypes
+	if req.Type == aggregate.TypeDateTime {
+		x, column, types = "extract(epoch FROM p.value_datetime)::float8", "p.value_datetime", dateTimeTypes
+	}
+	grp := "NULL::text"
+	if req.GroupBy != "" {
+		grp = groupValue
+	}
+
+	b := NewSelect("s.id AS submodel_id", x+" AS x", grp+" AS grp").From("submodel s")
+	b.Join("JOIN reference_key sk ON sk.reference_id = s.semantic_id AND sk.position = 0 AND sk.value = " + b.Arg(req.SemanticID))
+	b.Join("JOIN submodel_element e ON e.submodel_id = s.id AND e.idshort_path = " + b.Arg(req.IdShortPath))
+	// the type condition repeats the one of the partial index
+	b.Join("JOIN property_element p ON p.id = e.id AND p.value_type IN " + types)
+	if req.GroupBy != "" {
+		b.Join("LEFT JOIN submodel_element ge ON ge.submodel_id = s.id AND ge.idshort_path = " + b.Arg(req.GroupBy))
+		b.Join("LEFT JOIN property_element gp ON gp.id = ge.id")
+	}
+	b.Where(column + " IS NOT NULL")
+	if ids != nil {
+		b.Where("s.id = ANY(" + b.Arg(ids) + ")")
+	}
+	return b
+}
+
+// Aggregates computes count, min, max, avg and sum of the values per group, the largest
+// groups first. It selects one group more than req.Limit to detect truncated results.
+func Aggregates(req aggregate.Request, ids []string) (string, []any) {
+	values, args := AggregatedValues(req, ids).Build()
+	return "SELECT grp, count(x), min(x), max(x), avg(x), sum(x)\nFROM (" + values + ") v\n" +
+		"GROUP BY grp\nORDER BY count(x) DESC, grp NULLS LAST\nLIMIT " + strconv.Itoa(int(req.Limit)+1), args
+}
+
+// Histogram counts the values per group and bucket of req.Histogram. The buckets are numbered
+// from 1; the largest value, which width_bucket puts into an extra bucket, counts to the last
+// one. Every row also carries the bounds lo and hi, which default to the smallest and largest
+// value of all groups so that the histograms of the groups are comparable.
+func Histogram(req aggregate.Request, ids []string) (string, []any) {
+	b := AggregatedValues(req, ids)
+	lo, hi, _ := req.Bounds()
+	loArg, hiArg := b.Arg(lo), b.Arg(hi)
+	n := strconv.Itoa(req.Histogram.Buckets)
+	values, args := b.Build()
+	return "WITH v AS (" + values + "),\n" +
+		"b AS (SELECT COALESCE(" + loArg + "::float8, min(x)) AS lo, COALESCE(" + hiArg + "::float8, max(x)) AS hi FROM v)\n" +
+		"SELECT v.grp, CASE WHEN b.lo = b.hi THEN 1 ELSE LEAST(width_bucket(v.x, b.lo, b.hi, " + n + "), " + n + ") END AS bucket, count(*), b.lo, b.hi\n" +
+		"FROM v CROSS JOIN b\nWHERE v.x BETWEEN b.lo AND b.hi\nGROUP BY v.grp, bucket, b.lo, b.hi", args
+}
@@ -0,0 +1,59 @@
+package querybuilder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+)
+
+func TestAggregates(t *testing.T) {
+	req := aggregate.Request{SemanticID: "urn:td", IdShortPath: "MaxTemperature", GroupBy: "Manufacturer"}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	q, args := Aggregates(req, []string{"sm-1", "sm-2"})
+	for _, frag := range []string{
+		"SELECT grp, count(x), min(x), max(x), avg(x), sum(x)",
+		"JOIN reference_key sk ON sk.reference_id = s.semantic_id AND sk.position = 0 AND sk.value = $1",
+		"JOIN submodel_element e ON e.submodel_id = s.id AND e.idshort_path = $2",
+		"JOIN property_element p ON p.id = e.id AND p.value_type IN ('xs:byte'",
+		"LEFT JOIN submodel_element ge ON ge.submodel_id = s.id AND ge.idshort_path = $3",
+		"WHERE p.value_num IS NOT NULL AND s.id = ANY($4)",
+		"ORDER BY count(x) DESC, grp NULLS LAST",
+		"LIMIT 101",
+	} {
+		if !strings.Contains(q, frag) {
+			t.Errorf("missing %q in\n%s", frag, q)
+		}
+	}
+	if len(args) != 4 || args[0] != "urn:td" || args[2] != "Manufacturer" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestHistogramDateTime(t *testing.T) {
+	req := aggregate.Request{SemanticID: "urn:td", IdShortPath: "Built", Type: aggregate.TypeDateTime,
+		Histogram: &aggregate.Histogram{Buckets: 12, Min: "2024-01-01T00:00:00Z"}}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	q, args := Histogram(req, nil)
+	for _, frag := range []string{
+		"extract(epoch FROM p.value_datetime)::float8 AS x",
+		"NULL::text AS grp",
+		"p.value_type IN ('xs:dateTime','xs:date')",
+		"COALESCE($3::float8, min(x)) AS lo, COALESCE($4::float8, max(x)) AS hi",
+		"LEAST(width_bucket(v.x, b.lo, b.hi, 12), 12)",
+	} {
+		if !strings.Contains(q, frag) {
+			t.Errorf("missing %q in\n%s", frag, q)
+		}
+	}
+	if strings.Contains(q, "ANY(") {
+		t.Error("nil ids must not restrict the submodels")
+	}
+	if lo, ok := args[2].(*float64); !ok || *lo != 1704067200 || args[3].(*float64) != nil {
+		t.Errorf("bounds = %v, %v", args[2], args[3])
+	}
+}
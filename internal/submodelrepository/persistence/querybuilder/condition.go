@@ -0,0 +1,202 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+)
+
+// The value types of the partial indexes on property_element. Predicates repeat the index
+// condition so that PostgreSQL can use them.
+const (
+	numericTypes = "('xs:byte','xs:int','xs:integer','xs:long','xs:short','xs:decimal','xs:double','xs:float'," +
+		"'xs:nonNegativeInteger','xs:nonPositiveInteger','xs:positiveInteger'," +
+		"'xs:unsignedByte','xs:unsignedInt','xs:unsignedLong','xs:unsignedShort')"
+	dateTimeTypes = "('xs:dateTime','xs:date')"
+)
+
+var sqlOperators = map[query.Operator]string{
+	query.OpEq: "=",
+	query.OpNe: "<>",
+	query.OpGt: ">",
+	query.OpGe: ">=",
+	query.OpLt: "<",
+	query.OpLe: "<=",
+}
+
+// SubmodelIDs selects the ids of the submodels matching cond, ordered by id and starting after
+// cursor, which is the id of the last submodel of the previous page.
+//
+// Conditions on element values compare the typed columns of property_element: $numVal
+// compares value_num, $dateTimeVal value_datetime, $timeVal value_time, $boolean value_bool
+// and $strVal value_text, each restricted to the matching value types.
+func SubmodelIDs(cond query.LogicalExpression, limit int, cursor string) (*SelectBuilder, error) {
+	b := NewSelect("s.id").From("submodel s")
+	where, err := (&conditionCompiler{b: b}).expression(cond)
+	if err != nil {
+		return nil, err
+	}
+	b.Where(where)
+	if cursor != "" {
+		b.Where("s.id > " + b.Arg(cursor))
+	}
+	return b.OrderBy("s.id").Limit(limit), nil
+}
+
+// conditionCompiler translates conditions into predicates on "submodel s", adding the
+// literals to the args of b.
+type conditionCompiler struct {
+	b *SelectBuilder
+}
+
+func (c *conditionCompiler) expression(e query.LogicalExpression) (string, error) {
+	switch {
+	case e.And != nil:
+		return c.join(e.And, " AND ")
+	case e.Or != nil:
+		return c.join(e.Or, " OR ")
+	case e.Not != nil:
+		inner, err := c.expression(*e.Not)
+		return "NOT (" + inner + ")", err
+	case e.Boolean != nil:
+		if *e.Boolean {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	}
+	op, operands, _ := e.Comparison()
+	return c.comparison(op, operands[0], operands[1])
+}
+
+func (c *conditionCompiler) join(children []query.LogicalExpression, sep string) (string, error) {
+	parts := make([]string, len(children))
+	for i, child := range children {
+		p, err := c.expression(child)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = p
+	}
+	return "(" + strings.Join(parts, sep) + ")", nil
+}
+
+// comparison compiles a comparison of a field with a literal, in either order.
+func (c *conditionCompiler) comparison(op query.Operator, left, right query.Value) (string, error) {
+	if left.Field == nil && right.Field != nil && !op.IsString() {
+		left, right, op = right, left, op.Mirror()
+	}
+	if left.Field == nil || right.Field != nil {
+		return "", common.NewErrBadRequest(fmt.Sprintf("%s must compare a $field with a literal value, the field first for string operators", op))
+	}
+	if op.IsString() && right.StrVal == nil {
+		return "", common.NewErrBadRequest(fmt.Sprintf("%s needs a $strVal", op))
+	}
+	f, err := query.ParseField(*left.Field)
+	if err != nil {
+		return "", common.NewErrBadRequest(err.Error())
+	}
+
+	if f.Scope == query.ScopeSubmodel {
+		switch f.Attribute {
+		case query.AttrID:
+			return c.compareString(f, "s.id", op, right)
+		case query.AttrIdShort:
+			return c.compareString(f, "s.id_short", op, right)
+		}
+		return c.semanticID(f, "s.semantic_id", op, right)
+	}
+
+	element := "EXISTS (SELECT 1 FROM submodel_element e"
+	if f.Attribute == query.AttrValue || f.Attribute == query.AttrValueType {
+		element += " JOIN property_element p ON p.id = e.id"
+	}
+	element += " WHERE e.submodel_id = s.id"
+	if f.Path != "" {
+		element += " AND e.idshort_path = " + c.b.Arg(f.Path)
+	}
+	var pred string
+	switch f.Attribute {
+	case query.AttrIdShort:
+		pred, err = c.compareString(f, "e.id_short", op, right)
+	case query.AttrValueType:
+		pred, err = c.compareString(f, "p.value_type::text", op, right)
+	case query.AttrValue:
+		pred, err = c.propertyValue(op, right)
+	default:
+		pred, err = c.semanticID(f, "e.semantic_id", op, right)
+	}
+	return element + " AND " + pred + ")", err
+}
+
+// propertyValue compares the typed value column that matches the type of v.
+func (c *conditionCompiler) propertyValue(op query.Operator, v query.Value) (string, error) {
+	switch {
+	case v.NumVal != nil:
+		return c.compare("p.value_num", op, c.b.Arg(v.NumVal.String())+"::numeric") + " AND p.value_type IN " + numericTypes, nil
+	case v.DateTimeVal != nil:
+		return c.compare("p.value_datetime", op, c.b.Arg(*v.DateTimeVal)+"::timestamptz") + " AND p.value_type IN " + dateTimeTypes, nil
+	case v.TimeVal != nil:
+		return c.compare("p.value_time", op, c.b.Arg(*v.TimeVal)+"::time") + " AND p.value_type = 'xs:time'", nil
+	case v.Boolean != nil:
+		return c.compare("p.value_bool", op, c.b.Arg(*v.Boolean)) + " AND p.value_type = 'xs:boolean'", nil
+	}
+	return c.stringOperator("p.value_text", op, *v.StrVal), nil
+}
+
+// semanticID compares a part of the reference in refColumn.
+func (c *conditionCompiler) semanticID(f query.Field, refColumn string, op query.Operator, v query.Value) (string, error) {
+	if f.Part == query.PartReferenceType {
+		pred, err := c.compareString(f, "r.type::text", op, v)
+		return "EXISTS (SELECT 1 FROM reference r WHERE r.id = " + refColumn + " AND " + pred + ")", err
+	}
+	column := "rk.value"
+	if f.Part == query.PartKeyType {
+		column = "rk.type::text"
+	}
+	sub := "EXISTS (SELECT 1 FROM reference_key rk WHERE rk.reference_id = " + refColumn
+	if f.Key != query.AnyKey {
+		sub += " AND rk.position = " + c.b.Arg(f.Key)
+	}
+	pred, err := c.compareString(f, column, op, v)
+	return sub + " AND " + pred + ")", err
+}
+
+// compareString compares a text column, which requires a $strVal.
+func (c *conditionCompiler) compareString(f query.Field, column string, op query.Operator, v query.Value) (string, error) {
+	if v.StrVal == nil {
+		return "", common.NewErrBadRequest(fmt.Sprintf("%s%s#%s is a string and can only be compared with a $strVal", f.Scope, pathSuffix(f), f.Attribute))
+	}
+	return c.stringOperator(column, op, *v.StrVal), nil
+}
+
+func (c *conditionCompiler) stringOperator(column string, op query.Operator, s string) string {
+	switch op {
+	case query.OpContains:
+		return column + " LIKE " + c.b.Arg("%"+EscapeLike(s)+"%")
+	case query.OpStartsWith:
+		return column + " LIKE " + c.b.Arg(EscapeLike(s)+"%")
+	case query.OpEndsWith:
+		return column + " LIKE " + c.b.Arg("%"+EscapeLike(s))
+	case query.OpRegex:
+		return column + " ~ " + c.b.Arg(s)
+	}
+	return c.compare(column, op, c.b.Arg(s))
+}
+
+func (c *conditionCompiler) compare(column string, op query.Operator, placeholder string) string {
+	return column + " " + sqlOperators[op] + " " + placeholder
+}
+
+func pathSuffix(f query.Field) string {
+	if f.Path == "" {
+		return ""
+	}
+	return "." + f.Path
+}
+
+// EscapeLike escapes the wildcards of LIKE, whose default escape character is the backslash.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
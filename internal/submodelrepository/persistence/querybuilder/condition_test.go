@@ -0,0 +1,91 @@
+package querybuilder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+)
+
+func parse(t *testing.T, body string) query.LogicalExpression {
+	t.Helper()
+	q, err := query.Parse(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *q.Condition
+}
+
+func TestSubmodelIDsTypedComparisons(t *testing.T) {
+	cond := parse(t, `{"$condition":{"$and":[
+		{"$eq":[{"$field":"$sm#semanticId"},{"$strVal":"urn:nameplate"}]},
+		{"$lt":[{"$numVal":80},{"$field":"$sme.MaxTemperature#value"}]}
+	]}}`)
+	b, err := SubmodelIDs(cond, 11, "sm-9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, args := b.Build()
+	for _, frag := range []string{
+		"SELECT s.id FROM submodel s",
+		"EXISTS (SELECT 1 FROM reference_key rk WHERE rk.reference_id = s.semantic_id AND rk.position = $1 AND rk.value = $2)",
+		"JOIN property_element p ON p.id = e.id WHERE e.submodel_id = s.id AND e.idshort_path = $3",
+		// the literal comes first, so the operator is mirrored
+		"p.value_num > $4::numeric AND p.value_type IN ('xs:byte'",
+		"AND s.id > $5",
+		"ORDER BY s.id",
+		"LIMIT 11",
+	} {
+		if !strings.Contains(q, frag) {
+			t.Errorf("query missing %q; got: %s", frag, q)
+		}
+	}
+	want := []interface{}{0, "urn:nameplate", "MaxTemperature", "80", "sm-9"}
+	if len(args) != len(want) {
+		t.Fatalf("got args %#v", args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("arg %d = %#v, want %#v", i, args[i], want[i])
+		}
+	}
+}
+
+func TestSubmodelIDsStringOperators(t *testing.T) {
+	cond := parse(t, `{"$condition":{"$or":[
+		{"$contains":[{"$field":"$sme#value"},{"$strVal":"50%_off"}]},
+		{"$not":{"$ge":[{"$field":"$sme.Built#value"},{"$dateTimeVal":"2024-01-01T00:00:00Z"}]}}
+	]}}`)
+	b, err := SubmodelIDs(cond, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, args := b.Build()
+	for _, frag := range []string{
+		"WHERE e.submodel_id = s.id AND p.value_text LIKE $1)",
+		" OR NOT (EXISTS",
+		"p.value_datetime >= $3::timestamptz AND p.value_type IN ('xs:dateTime','xs:date')",
+	} {
+		if !strings.Contains(q, frag) {
+			t.Errorf("query missing %q; got: %s", frag, q)
+		}
+	}
+	if args[0] != `%50\%\_off%` {
+		t.Errorf("LIKE pattern = %q", args[0])
+	}
+}
+
+func TestSubmodelIDsRejectsUnsupportedComparisons(t *testing.T) {
+	for _, body := range []string{
+		`{"$condition":{"$eq":[{"$field":"$sm#idShort"},{"$field":"$sm#id"}]}}`,
+		`{"$condition":{"$eq":[{"$strVal":"a"},{"$strVal":"a"}]}}`,
+		`{"$condition":{"$gt":[{"$field":"$sm#idShort"},{"$numVal":1}]}}`,
+		`{"$condition":{"$contains":[{"$strVal":"a"},{"$field":"$sm#idShort"}]}}`,
+		`{"$condition":{"$starts-with":[{"$field":"$sme.A#value"},{"$numVal":1}]}}`,
+	} {
+		if _, err := SubmodelIDs(parse(t, body), 10, ""); !common.IsErrBadRequest(err) {
+			t.Errorf("%s: want a bad request, got %v", body, err)
+		}
+	}
+}
@@ -57,6 +57,13 @@ func (b *SelectBuilder) Where(predicate string, values ...interface{}) *SelectBu
 	return b
 }
 
+// Arg appends value to the args and returns its placeholder, for predicates that are
+// assembled before they are passed to Where without values.
+func (b *SelectBuilder) Arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
 // WhereIn adds a WHERE col IN ($n, $n+1, ...) predicate and appends values in order.
 // If values is empty, it will add a predicate that is always false (1=0).
 func (b *SelectBuilder) WhereIn(column string, values ...interface{}) *SelectBuilder {
@@ -1,49 +1,66 @@
 package persistence_utils
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"reflect"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	gen "github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 	qb "github.com/eclipse-basyx/basyx-go-components/internal/submodelrepository/persistence/querybuilder"
-	_ "github.com/lib/pq" // PostgreSQL Treiber
 )
 
-func CreateSemanticId(tx *sql.Tx, semanticId *gen.Reference) (sql.NullInt64, error) {
+func CreateSemanticId(ctx context.Context, tx pgx.Tx, semanticId *gen.Reference) (sql.NullInt64, error) {
 	var id int
 	var referenceID sql.NullInt64
 	if semanticId != nil && !isEmptyReference(*semanticId) {
-		err := tx.QueryRow(`INSERT INTO reference (type) VALUES ($1) RETURNING id`, semanticId.Type).Scan(&id)
+		err := tx.QueryRow(ctx, `INSERT INTO reference (type) VALUES ($1) RETURNING id`, semanticId.Type).Scan(&id)
 		if err != nil {
 			return sql.NullInt64{}, err
 		}
 		referenceID = sql.NullInt64{Int64: int64(id), Valid: true}
 
-		references := semanticId.Keys
-		for i := range references {
-			_, err = tx.Exec(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
-				id, i, references[i].Type, references[i].Value)
-			if err != nil {
-				return sql.NullInt64{}, err
-			}
+		if err := InsertReferenceKeys(ctx, tx, int64(id), semanticId.Keys); err != nil {
+			return sql.NullInt64{}, err
 		}
 	}
 	return referenceID, nil
 }
 
-func GetSemanticId(db *sql.DB, referenceID sql.NullInt64) (*gen.Reference, error) {
+// InsertReferenceKeys stores the keys of a reference in a single round trip.
+func InsertReferenceKeys(ctx context.Context, tx pgx.Tx, referenceID int64, keys []gen.Key) error {
+	batch := &pgx.Batch{}
+	for i := range keys {
+		batch.Queue(`INSERT INTO reference_key (reference_id, position, type, value) VALUES ($1, $2, $3, $4)`,
+			referenceID, i, keys[i].Type, keys[i].Value)
+	}
+	return ExecBatch(ctx, tx, batch)
+}
+
+// ExecBatch sends all queued statements of batch at once and returns the first error.
+func ExecBatch(ctx context.Context, tx pgx.Tx, batch *pgx.Batch) error {
+	if batch.Len() == 0 {
+		return nil
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+func GetSemanticId(ctx context.Context, db *pgxpool.Pool, referenceID sql.NullInt64) (*gen.Reference, error) {
 	if !referenceID.Valid {
 		return nil, nil
 	}
 	var refType string
-	// avoid driver-specific type casts in the query string which can confuse the pq parser
+	// avoid driver-specific type casts in the query string which can confuse the parser
 	qRef, argsRef := qb.NewSelect("type").
 		From("reference").
 		Where("id=$1", referenceID.Int64).
 		Build()
-	err := db.QueryRow(qRef, argsRef...).Scan(&refType)
+	err := db.QueryRow(ctx, qRef, argsRef...).Scan(&refType)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -54,7 +71,7 @@ func GetSemanticId(db *sql.DB, referenceID sql.NullInt64) (*gen.Reference, error
 		Where("reference_id=$1", referenceID.Int64).
 		OrderBy("position").
 		Build()
-	rows, err := db.Query(qKeys, argsKeys...)
+	rows, err := db.Query(ctx, qKeys, argsKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -86,26 +103,27 @@ func GetSemanticId(db *sql.DB, referenceID sql.NullInt64) (*gen.Reference, error
 	}, nil
 }
 
-func CreateLangStringNameTypes(tx *sql.Tx, nameTypes []gen.LangStringNameType) (sql.NullInt64, error) {
+func CreateLangStringNameTypes(ctx context.Context, tx pgx.Tx, nameTypes []gen.LangStringNameType) (sql.NullInt64, error) {
 	var id int
 	var nameTypeID sql.NullInt64
 	if len(nameTypes) > 0 {
-		err := tx.QueryRow(`INSERT INTO lang_string_name_type_reference DEFAULT VALUES RETURNING id`).Scan(&id)
+		err := tx.QueryRow(ctx, `INSERT INTO lang_string_name_type_reference DEFAULT VALUES RETURNING id`).Scan(&id)
 		if err != nil {
 			return sql.NullInt64{}, err
 		}
 		nameTypeID = sql.NullInt64{Int64: int64(id), Valid: true}
+		batch := &pgx.Batch{}
 		for i := 0; i < len(nameTypes); i++ {
-			_, err := tx.Exec(`INSERT INTO lang_string_name_type (lang_string_name_type_reference_id, text, language) VALUES ($1, $2, $3)`, nameTypeID.Int64, nameTypes[i].Text, nameTypes[i].Language)
-			if err != nil {
-				return sql.NullInt64{}, err
-			}
+			batch.Queue(`INSERT INTO lang_string_name_type (lang_string_name_type_reference_id, text, language) VALUES ($1, $2, $3)`, nameTypeID.Int64, nameTypes[i].Text, nameTypes[i].Language)
+		}
+		if err := ExecBatch(ctx, tx, batch); err != nil {
+			return sql.NullInt64{}, err
 		}
 	}
 	return nameTypeID, nil
 }
 
-func GetLangStringNameTypes(db *sql.DB, nameTypeID sql.NullInt64) ([]gen.LangStringNameType, error) {
+func GetLangStringNameTypes(ctx context.Context, db *pgxpool.Pool, nameTypeID sql.NullInt64) ([]gen.LangStringNameType, error) {
 	if !nameTypeID.Valid {
 		return nil, nil
 	}
@@ -114,7 +132,7 @@ func GetLangStringNameTypes(db *sql.DB, nameTypeID sql.NullInt64) ([]gen.LangStr
 		From("lang_string_name_type").
 		Where("lang_string_name_type_reference_id=$1", nameTypeID.Int64).
 		Build()
-	rows, err := db.Query(q, args...)
+	rows, err := db.Query(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -133,26 +151,27 @@ func GetLangStringNameTypes(db *sql.DB, nameTypeID sql.NullInt64) ([]gen.LangStr
 	return nameTypes, nil
 }
 
-func CreateLangStringTextTypes(tx *sql.Tx, textTypes []gen.LangStringTextType) (sql.NullInt64, error) {
+func CreateLangStringTextTypes(ctx context.Context, tx pgx.Tx, textTypes []gen.LangStringTextType) (sql.NullInt64, error) {
 	var id int
 	var textTypeID sql.NullInt64
 	if len(textTypes) > 0 {
-		err := tx.QueryRow(`INSERT INTO lang_string_text_type_reference DEFAULT VALUES RETURNING id`).Scan(&id)
+		err := tx.QueryRow(ctx, `INSERT INTO lang_string_text_type_reference DEFAULT VALUES RETURNING id`).Scan(&id)
 		if err != nil {
 			return sql.NullInt64{}, err
 		}
 		textTypeID = sql.NullInt64{Int64: int64(id), Valid: true}
+		batch := &pgx.Batch{}
 		for i := 0; i < len(textTypes); i++ {
-			_, err := tx.Exec(`INSERT INTO lang_string_text_type (lang_string_text_type_reference_id, text, language) VALUES ($1, $2, $3)`, textTypeID.Int64, textTypes[i].Text, textTypes[i].Language)
-			if err != nil {
-				return sql.NullInt64{}, err
-			}
+			batch.Queue(`INSERT INTO lang_string_text_type (lang_string_text_type_reference_id, text, language) VALUES ($1, $2, $3)`, textTypeID.Int64, textTypes[i].Text, textTypes[i].Language)
+		}
+		if err := ExecBatch(ctx, tx, batch); err != nil {
+			return sql.NullInt64{}, err
 		}
 	}
 	return textTypeID, nil
 }
 
-func GetLangStringTextTypes(db *sql.DB, textTypeID sql.NullInt64) ([]gen.LangStringTextType, error) {
+func GetLangStringTextTypes(ctx context.Context, db *pgxpool.Pool, textTypeID sql.NullInt64) ([]gen.LangStringTextType, error) {
 	if !textTypeID.Valid {
 		return nil, nil
 	}
@@ -161,7 +180,7 @@ func GetLangStringTextTypes(db *sql.DB, textTypeID sql.NullInt64) ([]gen.LangStr
 		From("lang_string_text_type").
 		Where("lang_string_text_type_reference_id=$1", textTypeID.Int64).
 		Build()
-	rows, err := db.Query(q, args...)
+	rows, err := db.Query(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ import (
 	"net/http"
 
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
 )
 
 // AssetAdministrationShellBasicDiscoveryAPIAPIRouter defines the required methods for binding the api requests to a responses for the AssetAdministrationShellBasicDiscoveryAPIAPI
@@ -26,6 +27,8 @@ type AssetAdministrationShellBasicDiscoveryAPIAPIRouter interface {
 	GetAllAssetLinksById(http.ResponseWriter, *http.Request)
 	PostAllAssetLinksById(http.ResponseWriter, *http.Request)
 	DeleteAllAssetLinksById(http.ResponseWriter, *http.Request)
+	ImportAssetLinks(http.ResponseWriter, *http.Request)
+	ExportAssetLinks(http.ResponseWriter, *http.Request)
 }
 
 // DescriptionAPIAPIRouter defines the required methods for binding the api requests to a responses for the DescriptionAPIAPI
@@ -41,10 +44,12 @@ type DescriptionAPIAPIRouter interface {
 // and updated with the logic required for the API.
 type AssetAdministrationShellBasicDiscoveryAPIAPIServicer interface {
 	GetAllAssetAdministrationShellIdsByAssetLink(context.Context, []string, int32, string) (model.ImplResponse, error)
-	SearchAllAssetAdministrationShellIdsByAssetLink(ctx context.Context, limit int32, cursor string, assetLink []model.AssetLink) (model.ImplResponse, error)
+	SearchAllAssetAdministrationShellIdsByAssetLink(ctx context.Context, limit int32, cursor string, assetLink []model.AssetLink, options model.AssetLinkSearchOptions) (model.ImplResponse, error)
 	GetAllAssetLinksById(context.Context, string) (model.ImplResponse, error)
 	PostAllAssetLinksById(context.Context, string, []model.SpecificAssetId) (model.ImplResponse, error)
 	DeleteAllAssetLinksById(context.Context, string) (model.ImplResponse, error)
+	ImportAssetLinks(context.Context, bulk.Decoder) (model.ImplResponse, error)
+	ExportAssetLinks(context.Context, bulk.Encoder) (model.ImplResponse, error)
 }
 
 // DescriptionAPIAPIServicer defines the api actions for the DescriptionAPIAPI service
@@ -45,7 +45,7 @@ func WithAssetAdministrationShellBasicDiscoveryAPIAPIErrorHandler(h model.ErrorH
 func NewAssetAdministrationShellBasicDiscoveryAPIAPIController(s AssetAdministrationShellBasicDiscoveryAPIAPIServicer, opts ...AssetAdministrationShellBasicDiscoveryAPIAPIOption) *AssetAdministrationShellBasicDiscoveryAPIAPIController {
 	controller := &AssetAdministrationShellBasicDiscoveryAPIAPIController{
 		service:      s,
-		errorHandler: model.DefaultErrorHandler,
+		errorHandler: common.HandleError,
 	}
 
 	for _, opt := range opts {
@@ -83,6 +83,16 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) Routes() Routes
 			"/lookup/shells/{aasIdentifier}",
 			c.DeleteAllAssetLinksById,
 		},
+		"ImportAssetLinks": Route{
+			strings.ToUpper("Post"),
+			"/lookup/shells/$import",
+			c.ImportAssetLinks,
+		},
+		"ExportAssetLinks": Route{
+			strings.ToUpper("Get"),
+			"/lookup/shells/$export",
+			c.ExportAssetLinks,
+		},
 	}
 }
 
@@ -91,6 +101,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) GetAllAssetAdmi
 	query, err := parseQuery(r.URL.RawQuery)
 	if err != nil {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Invalid query parameters"),
 			http.StatusBadRequest,
 			componentName,
@@ -115,6 +126,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) GetAllAssetAdmi
 		)
 		if err != nil {
 			result := common.NewErrorResponse(
+				r.Context(),
 				common.NewErrBadRequest("Invalid 'limit' parameter"),
 				http.StatusBadRequest,
 				componentName,
@@ -148,6 +160,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) SearchAllAssetA
 	query, err := parseQuery(r.URL.RawQuery)
 	if err != nil {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Invalid query parameters"),
 			http.StatusBadRequest,
 			componentName,
@@ -167,6 +180,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) SearchAllAssetA
 		)
 		if err != nil {
 			result := common.NewErrorResponse(
+				r.Context(),
 				common.NewErrBadRequest("Invalid 'limit' parameter"),
 				http.StatusBadRequest,
 				componentName,
@@ -184,12 +198,67 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) SearchAllAssetA
 		cursorParam = query.Get("cursor")
 	}
 
+	// Optional search modes (extension): matchMode, caseInsensitive, combine
+	var optionsParam model.AssetLinkSearchOptions
+	if query.Has("matchMode") {
+		mode, err := model.NewAssetLinkMatchModeFromValue(query.Get("matchMode"))
+		if err != nil {
+			result := common.NewErrorResponse(
+				r.Context(),
+				common.NewErrBadRequest("Invalid 'matchMode' parameter"),
+				http.StatusBadRequest,
+				componentName,
+				"SearchAllAssetAdministrationShellIdsByAssetLink",
+				"matchMode",
+			)
+			EncodeJSONResponse(result.Body, &result.Code, w)
+			return
+		}
+		optionsParam.MatchMode = mode
+	}
+	if query.Has("caseInsensitive") {
+		param, err := parseBoolParameter(query.Get("caseInsensitive"), WithParse[bool](parseBool))
+		if err != nil {
+			result := common.NewErrorResponse(
+				r.Context(),
+				common.NewErrBadRequest("Invalid 'caseInsensitive' parameter"),
+				http.StatusBadRequest,
+				componentName,
+				"SearchAllAssetAdministrationShellIdsByAssetLink",
+				"caseInsensitive",
+			)
+			EncodeJSONResponse(result.Body, &result.Code, w)
+			return
+		}
+		optionsParam.CaseInsensitive = param
+	}
+	if query.Has("combine") {
+		switch query.Get("combine") {
+		case "all":
+			optionsParam.MatchAny = false
+		case "any":
+			optionsParam.MatchAny = true
+		default:
+			result := common.NewErrorResponse(
+				r.Context(),
+				common.NewErrBadRequest("Invalid 'combine' parameter, expected 'all' or 'any'"),
+				http.StatusBadRequest,
+				componentName,
+				"SearchAllAssetAdministrationShellIdsByAssetLink",
+				"combine",
+			)
+			EncodeJSONResponse(result.Body, &result.Code, w)
+			return
+		}
+	}
+
 	// Body: []AssetLink
 	var assetLinksParam []model.AssetLink
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
 	if err := dec.Decode(&assetLinksParam); err != nil {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Incorrect RequestBody"),
 			http.StatusBadRequest,
 			componentName,
@@ -204,6 +273,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) SearchAllAssetA
 	for _, al := range assetLinksParam {
 		if err := model.AssertAssetLinkRequired(al); err != nil {
 			result := common.NewErrorResponse(
+				r.Context(),
 				common.NewErrBadRequest("Invalid asset link element"),
 				http.StatusBadRequest,
 				componentName,
@@ -221,6 +291,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) SearchAllAssetA
 		limitParam,
 		cursorParam,
 		assetLinksParam,
+		optionsParam,
 	)
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -235,6 +306,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) GetAllAssetLink
 	aasIdentifierParam := chi.URLParam(r, "aasIdentifier")
 	if aasIdentifierParam == "" {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Missing path parameter 'aasIdentifier'"),
 			http.StatusBadRequest,
 			componentName,
@@ -260,6 +332,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) PostAllAssetLin
 	aasIdentifierParam := chi.URLParam(r, "aasIdentifier")
 	if aasIdentifierParam == "" {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Missing path parameter 'aasIdentifier'"),
 			http.StatusBadRequest,
 			componentName,
@@ -275,6 +348,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) PostAllAssetLin
 	d.DisallowUnknownFields()
 	if err := d.Decode(&specificAssetIdParam); err != nil {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Incorrect RequestBody"),
 			http.StatusBadRequest,
 			componentName,
@@ -288,6 +362,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) PostAllAssetLin
 	for _, el := range specificAssetIdParam {
 		if err := model.AssertSpecificAssetIdRequired(el); err != nil {
 			result := common.NewErrorResponse(
+				r.Context(),
 				common.NewErrBadRequest("Invalid SpecificAssetId element"),
 				http.StatusBadRequest,
 				componentName,
@@ -314,6 +389,7 @@ func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) DeleteAllAssetL
 	aasIdentifierParam := chi.URLParam(r, "aasIdentifier")
 	if aasIdentifierParam == "" {
 		result := common.NewErrorResponse(
+			r.Context(),
 			common.NewErrBadRequest("Missing path parameter 'aasIdentifier'"),
 			http.StatusBadRequest,
 			componentName,
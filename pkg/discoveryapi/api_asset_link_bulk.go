@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
+	"github.com/eclipse-basyx/basyx-go-components/internal/discoveryservice/bulk"
+)
+
+// ImportAssetLinks - Bulk upload of asset links as NDJSON or CSV (extension, not part of the specification).
+// The format is taken from the 'format' query parameter, falling back to the Content-Type header.
+func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) ImportAssetLinks(w http.ResponseWriter, r *http.Request) {
+	format, ok := c.bulkFormat(w, r, r.Header.Get("Content-Type"), "ImportAssetLinks")
+	if !ok {
+		return
+	}
+
+	dec, err := bulk.NewDecoder(format, r.Body)
+	if err != nil {
+		result := common.NewErrorResponse(
+			r.Context(),
+			common.NewErrBadRequest(err.Error()),
+			http.StatusBadRequest,
+			componentName,
+			"ImportAssetLinks",
+			"format",
+		)
+		EncodeJSONResponse(result.Body, &result.Code, w)
+		return
+	}
+
+	result, err := c.service.ImportAssetLinks(r.Context(), dec)
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ExportAssetLinks - Streams all asset links as NDJSON or CSV (extension, not part of the specification).
+// The format is taken from the 'format' query parameter, falling back to the Accept header.
+func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) ExportAssetLinks(w http.ResponseWriter, r *http.Request) {
+	format, ok := c.bulkFormat(w, r, r.Header.Get("Accept"), "ExportAssetLinks")
+	if !ok {
+		return
+	}
+
+	tw := &trackingWriter{ResponseWriter: w}
+	enc, err := bulk.NewEncoder(format, tw)
+	if err != nil {
+		result := common.NewErrorResponse(r.Context(), err, http.StatusInternalServerError, componentName, "ExportAssetLinks", "encoder")
+		EncodeJSONResponse(result.Body, &result.Code, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	result, err := c.service.ExportAssetLinks(r.Context(), enc)
+	if err != nil {
+		if tw.written {
+			// Headers are already sent; the truncated body is all the client gets.
+			slog.ErrorContext(r.Context(), "ExportAssetLinks aborted after partial response", "error", err)
+			return
+		}
+		w.Header().Del("Content-Type")
+		c.errorHandler(w, r, err, &result)
+	}
+}
+
+func (c *AssetAdministrationShellBasicDiscoveryAPIAPIController) bulkFormat(w http.ResponseWriter, r *http.Request, header string, function string) (bulk.Format, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		result := common.NewErrorResponse(
+			r.Context(),
+			common.NewErrBadRequest("Invalid query parameters"),
+			http.StatusBadRequest,
+			componentName,
+			function,
+			"query",
+		)
+		EncodeJSONResponse(result.Body, &result.Code, w)
+		return "", false
+	}
+	if !query.Has("format") {
+		return bulk.FormatFromContentType(header), true
+	}
+	format, err := bulk.ParseFormat(query.Get("format"))
+	if err != nil {
+		result := common.NewErrorResponse(
+			r.Context(),
+			common.NewErrBadRequest(err.Error()),
+			http.StatusBadRequest,
+			componentName,
+			function,
+			"format",
+		)
+		EncodeJSONResponse(result.Body, &result.Code, w)
+		return "", false
+	}
+	return format, true
+}
+
+// trackingWriter remembers whether any part of the body has been sent.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(p)
+}
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 )
 
@@ -38,7 +39,7 @@ func WithDescriptionAPIAPIErrorHandler(h model.ErrorHandler) DescriptionAPIAPIOp
 func NewDescriptionAPIAPIController(s DescriptionAPIAPIServicer, opts ...DescriptionAPIAPIOption) *DescriptionAPIAPIController {
 	controller := &DescriptionAPIAPIController{
 		service:      s,
-		errorHandler: model.DefaultErrorHandler,
+		errorHandler: common.HandleError,
 	}
 
 	for _, opt := range opts {
@@ -14,7 +14,6 @@ package openapi
 import (
 	"encoding/json"
 	"errors"
-	"time"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -25,12 +24,13 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // A Route defines the parameters for an api endpoint
 type Route struct {
-	Method	  string
-	Pattern	 string
+	Method      string
+	Pattern     string
 	HandlerFunc http.HandlerFunc
 }
 
@@ -148,7 +148,7 @@ func readFileHeaderToTempFile(fileHeader *multipart.FileHeader) (*os.File, error
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return file, nil
 }
 
@@ -337,8 +337,7 @@ func parseNumericArrayParameter[T Number](param, delim string, required bool, fn
 	return values, nil
 }
 
-
 // parseQuery parses query parameters and returns an error if any malformed value pairs are encountered.
 func parseQuery(rawQuery string) (url.Values, error) {
 	return url.ParseQuery(rawQuery)
-}
\ No newline at end of file
+}
@@ -14,9 +14,18 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/query"
 )
 
+// AggregateAPIAPIRouter defines the required methods for binding the api requests to a responses for the AggregateAPIAPI
+// The AggregateAPIAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a AggregateAPIAPIServicer to perform the required actions, then write the service results to the http response.
+type AggregateAPIAPIRouter interface {
+	AggregateSubmodels(http.ResponseWriter, *http.Request)
+}
+
 // DescriptionAPIAPIRouter defines the required methods for binding the api requests to a responses for the DescriptionAPIAPI
 // The DescriptionAPIAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a DescriptionAPIAPIServicer to perform the required actions, then write the service results to the http response.
@@ -24,6 +33,29 @@ type DescriptionAPIAPIRouter interface {
 	GetDescription(http.ResponseWriter, *http.Request)
 }
 
+// LookupAPIAPIRouter defines the required methods for binding the api requests to a responses for the LookupAPIAPI
+// The LookupAPIAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a LookupAPIAPIServicer to perform the required actions, then write the service results to the http response.
+type LookupAPIAPIRouter interface {
+	LookupSubmodelElements(http.ResponseWriter, *http.Request)
+	LookupSubmodelElementsValueOnly(http.ResponseWriter, *http.Request)
+	LookupSubmodelElementsReference(http.ResponseWriter, *http.Request)
+}
+
+// QueryAPIAPIRouter defines the required methods for binding the api requests to a responses for the QueryAPIAPI
+// The QueryAPIAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a QueryAPIAPIServicer to perform the required actions, then write the service results to the http response.
+type QueryAPIAPIRouter interface {
+	QuerySubmodels(http.ResponseWriter, *http.Request)
+}
+
+// SearchAPIAPIRouter defines the required methods for binding the api requests to a responses for the SearchAPIAPI
+// The SearchAPIAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a SearchAPIAPIServicer to perform the required actions, then write the service results to the http response.
+type SearchAPIAPIRouter interface {
+	SearchSubmodels(http.ResponseWriter, *http.Request)
+}
+
 // SerializationAPIAPIRouter defines the required methods for binding the api requests to a responses for the SerializationAPIAPI
 // The SerializationAPIAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a SerializationAPIAPIServicer to perform the required actions, then write the service results to the http response.
@@ -80,6 +112,12 @@ type SubmodelRepositoryAPIAPIRouter interface {
 	GetOperationAsyncResultValueOnly(http.ResponseWriter, *http.Request)
 }
 
+// AggregateAPIAPIServicer defines the api actions for the AggregateAPIAPI service, which
+// aggregates property values over submodels, see package aggregate.
+type AggregateAPIAPIServicer interface {
+	AggregateSubmodels(context.Context, aggregate.Request) (model.ImplResponse, error)
+}
+
 // DescriptionAPIAPIServicer defines the api actions for the DescriptionAPIAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -88,6 +126,26 @@ type DescriptionAPIAPIServicer interface {
 	GetDescription(context.Context) (model.ImplResponse, error)
 }
 
+// LookupAPIAPIServicer defines the api actions for the LookupAPIAPI service, the lookup of
+// submodel elements by semanticId across submodels, see package lookup.
+type LookupAPIAPIServicer interface {
+	LookupSubmodelElements(context.Context, string, string, bool, int32, string) (model.ImplResponse, error)
+	LookupSubmodelElementsValueOnly(context.Context, string, string, bool, int32, string) (model.ImplResponse, error)
+	LookupSubmodelElementsReference(context.Context, string, string, bool, int32, string) (model.ImplResponse, error)
+}
+
+// QueryAPIAPIServicer defines the api actions for the QueryAPIAPI service, which evaluates
+// queries of the AAS Query Language, see package query.
+type QueryAPIAPIServicer interface {
+	QuerySubmodels(context.Context, query.Query, int32, string) (model.ImplResponse, error)
+}
+
+// SearchAPIAPIServicer defines the api actions for the SearchAPIAPI service, the full-text
+// search over the texts of submodels, see package search.
+type SearchAPIAPIServicer interface {
+	SearchSubmodels(context.Context, string, []string, int32, string) (model.ImplResponse, error)
+}
+
 // SerializationAPIAPIServicer defines the api actions for the SerializationAPIAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
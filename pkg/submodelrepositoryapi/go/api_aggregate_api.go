@@ -0,0 +1,77 @@
+/*
+ * DotAAS Part 2 | HTTP/REST | Submodel Repository Service Specification
+ *
+ * The entire Submodel Repository Service Specification as part of the [Specification of the Asset Administration Shell: Part 2](http://industrialdigitaltwin.org/en/content-hub).   Publisher: Industrial Digital Twin Association (IDTA) 2023
+ *
+ * API version: V3.0.3_SSP-001
+ * Contact: info@idtwin.org
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/aggregate"
+)
+
+// AggregateAPIAPIController binds http requests to an api service and writes the service results to the http response
+type AggregateAPIAPIController struct {
+	service      AggregateAPIAPIServicer
+	errorHandler ErrorHandler
+	contextPath  string
+}
+
+// AggregateAPIAPIOption for how the controller is set up.
+type AggregateAPIAPIOption func(*AggregateAPIAPIController)
+
+// WithAggregateAPIAPIErrorHandler inject ErrorHandler into controller
+func WithAggregateAPIAPIErrorHandler(h ErrorHandler) AggregateAPIAPIOption {
+	return func(c *AggregateAPIAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewAggregateAPIAPIController creates a default api controller
+func NewAggregateAPIAPIController(s AggregateAPIAPIServicer, contextPath string, opts ...AggregateAPIAPIOption) *AggregateAPIAPIController {
+	controller := &AggregateAPIAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+		contextPath:  contextPath,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the AggregateAPIAPIController
+func (c *AggregateAPIAPIController) Routes() Routes {
+	return Routes{
+		"AggregateSubmodels": Route{
+			strings.ToUpper("Post"),
+			c.contextPath + "/aggregate/submodels",
+			c.AggregateSubmodels,
+		},
+	}
+}
+
+// AggregateSubmodels - Computes aggregates of a property over all submodels with a semanticId
+func (c *AggregateAPIAPIController) AggregateSubmodels(w http.ResponseWriter, r *http.Request) {
+	requestParam, err := aggregate.Parse(r.Body)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.AggregateSubmodels(r.Context(), requestParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
+}
@@ -64,5 +64,5 @@ func (c *DescriptionAPIAPIController) GetDescription(w http.ResponseWriter, r *h
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
@@ -0,0 +1,139 @@
+/*
+ * DotAAS Part 2 | HTTP/REST | Submodel Repository Service Specification
+ *
+ * The entire Submodel Repository Service Specification as part of the [Specification of the Asset Administration Shell: Part 2](http://industrialdigitaltwin.org/en/content-hub).   Publisher: Industrial Digital Twin Association (IDTA) 2023
+ *
+ * API version: V3.0.3_SSP-001
+ * Contact: info@idtwin.org
+ */
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
+)
+
+// LookupAPIAPIController binds http requests to an api service and writes the service results to the http response
+type LookupAPIAPIController struct {
+	service      LookupAPIAPIServicer
+	errorHandler ErrorHandler
+	contextPath  string
+}
+
+// LookupAPIAPIOption for how the controller is set up.
+type LookupAPIAPIOption func(*LookupAPIAPIController)
+
+// WithLookupAPIAPIErrorHandler inject ErrorHandler into controller
+func WithLookupAPIAPIErrorHandler(h ErrorHandler) LookupAPIAPIOption {
+	return func(c *LookupAPIAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewLookupAPIAPIController creates a default api controller
+func NewLookupAPIAPIController(s LookupAPIAPIServicer, contextPath string, opts ...LookupAPIAPIOption) *LookupAPIAPIController {
+	controller := &LookupAPIAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+		contextPath:  contextPath,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the LookupAPIAPIController
+func (c *LookupAPIAPIController) Routes() Routes {
+	return Routes{
+		"LookupSubmodelElements": Route{
+			strings.ToUpper("Get"),
+			c.contextPath + "/lookup/submodel-elements",
+			c.LookupSubmodelElements,
+		},
+		"LookupSubmodelElementsValueOnly": Route{
+			strings.ToUpper("Get"),
+			c.contextPath + "/lookup/submodel-elements/$value",
+			c.LookupSubmodelElementsValueOnly,
+		},
+		"LookupSubmodelElementsReference": Route{
+			strings.ToUpper("Get"),
+			c.contextPath + "/lookup/submodel-elements/$reference",
+			c.LookupSubmodelElementsReference,
+		},
+	}
+}
+
+// LookupSubmodelElements - Returns the submodel elements with the semanticId across all submodels
+func (c *LookupAPIAPIController) LookupSubmodelElements(w http.ResponseWriter, r *http.Request) {
+	c.lookup(w, r, c.service.LookupSubmodelElements)
+}
+
+// LookupSubmodelElementsValueOnly - Returns the values of the submodel elements with the semanticId across all submodels
+func (c *LookupAPIAPIController) LookupSubmodelElementsValueOnly(w http.ResponseWriter, r *http.Request) {
+	c.lookup(w, r, c.service.LookupSubmodelElementsValueOnly)
+}
+
+// LookupSubmodelElementsReference - Returns the references of the submodel elements with the semanticId across all submodels
+func (c *LookupAPIAPIController) LookupSubmodelElementsReference(w http.ResponseWriter, r *http.Request) {
+	c.lookup(w, r, c.service.LookupSubmodelElementsReference)
+}
+
+// lookup parses the parameters shared by the three representations and calls serve.
+func (c *LookupAPIAPIController) lookup(w http.ResponseWriter, r *http.Request, serve func(context.Context, string, string, bool, int32, string) (model.ImplResponse, error)) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var semanticIdParam string
+	if query.Has("semanticId") {
+		semanticIdParam = query.Get("semanticId")
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "semanticId"}, nil)
+		return
+	}
+	matchParam := query.Get("match")
+	var supplementalParam bool
+	if query.Has("supplemental") {
+		param, err := parseBoolParameter(
+			query.Get("supplemental"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "supplemental", Err: err}, nil)
+			return
+		}
+
+		supplementalParam = param
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	}
+	cursorParam := query.Get("cursor")
+	result, err := serve(r.Context(), semanticIdParam, matchParam, supplementalParam, limitParam, cursorParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
+}
@@ -0,0 +1,97 @@
+/*
+ * DotAAS Part 2 | HTTP/REST | Submodel Repository Service Specification
+ *
+ * The entire Submodel Repository Service Specification as part of the [Specification of the Asset Administration Shell: Part 2](http://industrialdigitaltwin.org/en/content-hub).   Publisher: Industrial Digital Twin Association (IDTA) 2023
+ *
+ * API version: V3.0.3_SSP-001
+ * Contact: info@idtwin.org
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	aasquery "github.com/eclipse-basyx/basyx-go-components/internal/common/query"
+)
+
+// QueryAPIAPIController binds http requests to an api service and writes the service results to the http response
+type QueryAPIAPIController struct {
+	service      QueryAPIAPIServicer
+	errorHandler ErrorHandler
+	contextPath  string
+}
+
+// QueryAPIAPIOption for how the controller is set up.
+type QueryAPIAPIOption func(*QueryAPIAPIController)
+
+// WithQueryAPIAPIErrorHandler inject ErrorHandler into controller
+func WithQueryAPIAPIErrorHandler(h ErrorHandler) QueryAPIAPIOption {
+	return func(c *QueryAPIAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewQueryAPIAPIController creates a default api controller
+func NewQueryAPIAPIController(s QueryAPIAPIServicer, contextPath string, opts ...QueryAPIAPIOption) *QueryAPIAPIController {
+	controller := &QueryAPIAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+		contextPath:  contextPath,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the QueryAPIAPIController
+func (c *QueryAPIAPIController) Routes() Routes {
+	return Routes{
+		"QuerySubmodels": Route{
+			strings.ToUpper("Post"),
+			c.contextPath + "/query/submodels",
+			c.QuerySubmodels,
+		},
+	}
+}
+
+// QuerySubmodels - Returns all Submodels that conform to the input query
+func (c *QueryAPIAPIController) QuerySubmodels(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	}
+	cursorParam := query.Get("cursor")
+	queryParam, err := aasquery.Parse(r.Body)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.QuerySubmodels(r.Context(), queryParam, limitParam, cursorParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
+}
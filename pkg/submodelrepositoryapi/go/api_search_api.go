@@ -0,0 +1,106 @@
+/*
+ * DotAAS Part 2 | HTTP/REST | Submodel Repository Service Specification
+ *
+ * The entire Submodel Repository Service Specification as part of the [Specification of the Asset Administration Shell: Part 2](http://industrialdigitaltwin.org/en/content-hub).   Publisher: Industrial Digital Twin Association (IDTA) 2023
+ *
+ * API version: V3.0.3_SSP-001
+ * Contact: info@idtwin.org
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SearchAPIAPIController binds http requests to an api service and writes the service results to the http response
+type SearchAPIAPIController struct {
+	service      SearchAPIAPIServicer
+	errorHandler ErrorHandler
+	contextPath  string
+}
+
+// SearchAPIAPIOption for how the controller is set up.
+type SearchAPIAPIOption func(*SearchAPIAPIController)
+
+// WithSearchAPIAPIErrorHandler inject ErrorHandler into controller
+func WithSearchAPIAPIErrorHandler(h ErrorHandler) SearchAPIAPIOption {
+	return func(c *SearchAPIAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewSearchAPIAPIController creates a default api controller
+func NewSearchAPIAPIController(s SearchAPIAPIServicer, contextPath string, opts ...SearchAPIAPIOption) *SearchAPIAPIController {
+	controller := &SearchAPIAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+		contextPath:  contextPath,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the SearchAPIAPIController
+func (c *SearchAPIAPIController) Routes() Routes {
+	return Routes{
+		"SearchSubmodels": Route{
+			strings.ToUpper("Get"),
+			c.contextPath + "/search/submodels",
+			c.SearchSubmodels,
+		},
+	}
+}
+
+// SearchSubmodels - Returns the texts of submodels and their elements that contain the search text
+func (c *SearchAPIAPIController) SearchSubmodels(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var qParam string
+	if query.Has("q") {
+		qParam = query.Get("q")
+	} else {
+		c.errorHandler(w, r, &RequiredError{Field: "q"}, nil)
+		return
+	}
+	// languages may be repeated or comma-separated
+	var languageParam []string
+	for _, param := range query["language"] {
+		for _, language := range strings.Split(param, ",") {
+			if language = strings.TrimSpace(language); language != "" {
+				languageParam = append(languageParam, language)
+			}
+		}
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	}
+	cursorParam := query.Get("cursor")
+	result, err := c.service.SearchSubmodels(r.Context(), qParam, languageParam, limitParam, cursorParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
+}
@@ -93,5 +93,5 @@ func (c *SerializationAPIAPIController) GenerateSerializationByIds(w http.Respon
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
@@ -341,7 +341,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodels(w http.ResponseWrit
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PostSubmodel - Creates a new Submodel
@@ -368,7 +368,7 @@ func (c *SubmodelRepositoryAPIAPIController) PostSubmodel(w http.ResponseWriter,
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelsMetadata - Returns the metadata attributes of all Submodels
@@ -421,7 +421,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelsMetadata(w http.Resp
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelsValueOnly - Returns all Submodels in their ValueOnly representation
@@ -492,7 +492,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelsValueOnly(w http.Res
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelsReference - Returns the References for all Submodels
@@ -554,7 +554,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelsReference(w http.Res
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelsPath - Returns all Submodels in the Path notation
@@ -616,7 +616,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelsPath(w http.Response
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelById - Returns a specific Submodel
@@ -656,7 +656,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelById(w http.ResponseWrit
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PutSubmodelById - Updates an existing Submodel
@@ -688,7 +688,7 @@ func (c *SubmodelRepositoryAPIAPIController) PutSubmodelById(w http.ResponseWrit
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // DeleteSubmodelById - Deletes a Submodel
@@ -705,7 +705,7 @@ func (c *SubmodelRepositoryAPIAPIController) DeleteSubmodelById(w http.ResponseW
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelById - Updates an existing Submodel
@@ -751,7 +751,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelById(w http.ResponseWr
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelByIdMetadata - Returns the metadata attributes of a specific Submodel
@@ -768,7 +768,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelByIdMetadata(w http.Resp
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelByIdMetadata - Updates the metadata attributes of an existing Submodel
@@ -800,7 +800,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelByIdMetadata(w http.Re
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelByIdValueOnly - Returns a specific Submodel in the ValueOnly representation
@@ -840,7 +840,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelByIdValueOnly(w http.Res
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelByIdValueOnly - Updates the values of an existing Submodel
@@ -878,7 +878,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelByIdValueOnly(w http.R
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelByIdReference - Returns the Reference of a specific Submodel
@@ -895,7 +895,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelByIdReference(w http.Res
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelByIdPath - Returns a specific Submodel in the Path notation
@@ -926,7 +926,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelByIdPath(w http.Response
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelElements - Returns all submodel elements including their hierarchy
@@ -988,7 +988,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelElements(w http.Respo
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PostSubmodelElementSubmodelRepo - Creates a new submodel element
@@ -1029,7 +1029,7 @@ func (c *SubmodelRepositoryAPIAPIController) PostSubmodelElementSubmodelRepo(w h
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelElementsMetadataSubmodelRepo - Returns the metadata attributes of all submodel elements including their hierarchy
@@ -1073,7 +1073,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelElementsMetadataSubmo
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelElementsValueOnlySubmodelRepo - Returns all submodel elements including their hierarchy in the ValueOnly representation
@@ -1135,7 +1135,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelElementsValueOnlySubm
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelElementsReferenceSubmodelRepo - Returns the References of all submodel elements
@@ -1188,7 +1188,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelElementsReferenceSubm
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetAllSubmodelElementsPathSubmodelRepo - Returns all submodel elements including their hierarchy in the Path notation
@@ -1241,7 +1241,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetAllSubmodelElementsPathSubmodelR
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelElementByPathSubmodelRepo - Returns a specific submodel element from the Submodel at a specified path
@@ -1286,7 +1286,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelElementByPathSubmodelRep
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PutSubmodelElementByPathSubmodelRepo - Updates an existing submodel element at a specified path within submodel elements hierarchy
@@ -1344,7 +1344,7 @@ func (c *SubmodelRepositoryAPIAPIController) PutSubmodelElementByPathSubmodelRep
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PostSubmodelElementByPathSubmodelRepo - Creates a new submodel element at a specified path within submodel elements hierarchy
@@ -1388,7 +1388,7 @@ func (c *SubmodelRepositoryAPIAPIController) PostSubmodelElementByPathSubmodelRe
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // DeleteSubmodelElementByPathSubmodelRepo - Deletes a submodel element at a specified path within the submodel elements hierarchy
@@ -1410,7 +1410,7 @@ func (c *SubmodelRepositoryAPIAPIController) DeleteSubmodelElementByPathSubmodel
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelElementByPathSubmodelRepo - Updates an existing SubmodelElement
@@ -1468,7 +1468,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelElementByPathSubmodelR
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelElementByPathMetadataSubmodelRepo - Returns the matadata attributes of a specific submodel element from the Submodel at a specified path
@@ -1490,7 +1490,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelElementByPathMetadataSub
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelElementByPathMetadataSubmodelRepo - Updates the metadata attributes an existing SubmodelElement
@@ -1527,7 +1527,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelElementByPathMetadataS
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelElementByPathValueOnlySubmodelRepo - Returns a specific submodel element from the Submodel at a specified path in the ValueOnly representation
@@ -1572,7 +1572,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelElementByPathValueOnlySu
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PatchSubmodelElementByPathValueOnlySubmodelRepo - Updates the value of an existing SubmodelElement
@@ -1623,7 +1623,7 @@ func (c *SubmodelRepositoryAPIAPIController) PatchSubmodelElementByPathValueOnly
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelElementByPathReferenceSubmodelRepo - Returns the Reference of a specific submodel element from the Submodel at a specified path
@@ -1645,7 +1645,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelElementByPathReferenceSu
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetSubmodelElementByPathPathSubmodelRepo - Returns a specific submodel element from the Submodel at a specified path in the Path notation
@@ -1681,7 +1681,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetSubmodelElementByPathPathSubmode
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetFileByPathSubmodelRepo - Downloads file content from a specific submodel element from the Submodel at a specified path
@@ -1703,7 +1703,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetFileByPathSubmodelRepo(w http.Re
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // PutFileByPathSubmodelRepo - Uploads file content to an existing submodel element at a specified path within submodel elements hierarchy
@@ -1742,7 +1742,7 @@ func (c *SubmodelRepositoryAPIAPIController) PutFileByPathSubmodelRepo(w http.Re
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // DeleteFileByPathSubmodelRepo - Deletes file content of an existing submodel element at a specified path within submodel elements hierarchy
@@ -1764,7 +1764,7 @@ func (c *SubmodelRepositoryAPIAPIController) DeleteFileByPathSubmodelRepo(w http
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // InvokeOperationSubmodelRepo - Synchronously or asynchronously invokes an Operation at a specified path
@@ -1822,7 +1822,7 @@ func (c *SubmodelRepositoryAPIAPIController) InvokeOperationSubmodelRepo(w http.
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // InvokeOperationValueOnly - Synchronously or asynchronously invokes an Operation at a specified path
@@ -1885,7 +1885,7 @@ func (c *SubmodelRepositoryAPIAPIController) InvokeOperationValueOnly(w http.Res
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // InvokeOperationAsync - Asynchronously invokes an Operation at a specified path
@@ -1922,7 +1922,7 @@ func (c *SubmodelRepositoryAPIAPIController) InvokeOperationAsync(w http.Respons
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // InvokeOperationAsyncValueOnly - Asynchronously invokes an Operation at a specified path
@@ -1964,7 +1964,7 @@ func (c *SubmodelRepositoryAPIAPIController) InvokeOperationAsyncValueOnly(w htt
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetOperationAsyncStatus - Returns the status of an asynchronously invoked Operation
@@ -1991,7 +1991,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetOperationAsyncStatus(w http.Resp
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetOperationAsyncResult - Returns the Operation result of an asynchronously invoked Operation
@@ -2018,7 +2018,7 @@ func (c *SubmodelRepositoryAPIAPIController) GetOperationAsyncResult(w http.Resp
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
 
 // GetOperationAsyncResultValueOnly - Returns the Operation result of an asynchronously invoked Operation
@@ -2045,5 +2045,5 @@ func (c *SubmodelRepositoryAPIAPIController) GetOperationAsyncResultValueOnly(w
 		return
 	}
 	// If no error, encode the body and the result code
-	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+	_ = encodeJSONResponse(r.Context(), result.Body, &result.Code, w)
 }
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/eclipse-basyx/basyx-go-components/internal/common"
 	"github.com/eclipse-basyx/basyx-go-components/internal/common/model"
 )
 
@@ -53,23 +54,18 @@ func (e *RequiredError) Error() string {
 // you would like errors to be handled differently from the DefaultErrorHandler
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error, result *model.ImplResponse)
 
-// DefaultErrorHandler defines the default logic on how to handle errors from the controller. Any errors from parsing
-// request params will return a StatusBadRequest. Otherwise, the error code originating from the servicer will be used.
-func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error, result *model.ImplResponse) {
+// DefaultErrorHandler reports errors with common.HandleError. Errors from parsing request params
+// are reported as StatusBadRequest, missing required params as StatusUnprocessableEntity.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error, result *model.ImplResponse) {
 	var parsingErr *ParsingError
 	if ok := errors.As(err, &parsingErr); ok {
-		// Handle parsing errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusBadRequest), w)
-		return
+		err = common.NewError(common.ErrCodeBadRequest, err.Error(), nil)
 	}
 
 	var requiredErr *RequiredError
 	if ok := errors.As(err, &requiredErr); ok {
-		// Handle missing required errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusUnprocessableEntity), w)
-		return
+		err = common.NewError(common.ErrCodeUnprocessableEntity, err.Error(), nil)
 	}
 
-	// Handle all other errors
-	_ = EncodeJSONResponse(err.Error(), &result.Code, w)
+	common.HandleError(w, r, err, result)
 }
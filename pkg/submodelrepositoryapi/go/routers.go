@@ -10,6 +10,7 @@
 package openapi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -24,6 +25,8 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+
+	"github.com/eclipse-basyx/basyx-go-components/internal/common/tracing"
 )
 
 // A Route defines the parameters for an api endpoint
@@ -95,6 +98,15 @@ func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error
 	return nil
 }
 
+// encodeJSONResponse is EncodeJSONResponse in a span of the request's trace, which separates
+// the time spent encoding large submodels from the time spent reading them.
+func encodeJSONResponse(ctx context.Context, i interface{}, status *int, w http.ResponseWriter) error {
+	span := tracing.EncodeJSON(ctx)
+	err := EncodeJSONResponse(i, status, w)
+	tracing.End(span, err)
+	return err
+}
+
 // ReadFormFileToTempFile reads file data from a request form and writes it to a temporary file
 func ReadFormFileToTempFile(r *http.Request, key string) (*os.File, error) {
 	_, fileHeader, err := r.FormFile(key)